@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"neurotrade/internal/backtest"
+	"neurotrade/internal/service"
+)
+
+// runBacktestCLI implements "neurotrade backtest --from --to --preset",
+// a reproducible, database-free counterpart to
+// BacktestHandler.TriggerBacktest: it replays the same Engine/
+// SMACrossoverSignalSource against a CSV-backed KlineStore (no network
+// access required) and writes the resulting Report to disk instead of a
+// backtest_runs row.
+func runBacktestCLI(args []string) {
+	fs := flag.NewFlagSet("backtest", flag.ExitOnError)
+	from := fs.String("from", "", "start of the replay window, RFC3339 (required)")
+	to := fs.String("to", "", "end of the replay window, RFC3339 (required)")
+	preset := fs.Int("preset", 0, "strategy_presets.id under test, for the report's record only")
+	symbols := fs.String("symbols", "BTCUSDT", "comma-separated symbols to replay")
+	dataDir := fs.String("data-dir", "./backtest-data", "directory of <symbol>.csv kline files")
+	balance := fs.Float64("balance", 10000, "starting balance for the single backtest account")
+	out := fs.String("out", "", "path to write the per-trade CSV report (default: backtest_report_preset_<preset>.csv)")
+	fs.Parse(args)
+
+	if *from == "" || *to == "" {
+		log.Fatal("backtest: --from and --to are required (RFC3339, e.g. 2024-01-01T00:00:00Z)")
+	}
+
+	startTime, err := time.Parse(time.RFC3339, *from)
+	if err != nil {
+		log.Fatalf("backtest: invalid --from: %v", err)
+	}
+	endTime, err := time.Parse(time.RFC3339, *to)
+	if err != nil {
+		log.Fatalf("backtest: invalid --to: %v", err)
+	}
+
+	const account = "backtest"
+	cfg := &backtest.Config{
+		StartTime:    startTime,
+		EndTime:      endTime,
+		Symbols:      strings.Split(*symbols, ","),
+		Balances:     map[string]float64{account: *balance},
+		MakerFeeRate: 0.0002,
+		TakerFeeRate: 0.0005,
+	}
+
+	store := backtest.NewCSVKlineStore(*dataDir)
+	riskModel := service.NewATRRiskModel("15m", 14, 1.5, 3.0, 0.002)
+	signalSource := backtest.SMACrossoverSignalSource(account, 10, 30, 0.02, 1.0, *balance, riskModel)
+
+	engine := backtest.NewEngine(cfg, store, signalSource)
+
+	report, err := engine.Run(context.Background())
+	if err != nil {
+		log.Fatalf("backtest: run failed: %v", err)
+	}
+
+	log.Printf("Backtest complete: %d trades, win rate %.1f%%, max drawdown %.2f, sharpe %.2f",
+		report.TotalTrades, report.WinRate*100, report.MaxDrawdown, report.Sharpe)
+
+	reportPath := *out
+	if reportPath == "" {
+		reportPath = fmt.Sprintf("backtest_report_preset_%d.csv", *preset)
+	}
+	if err := report.WriteCSV(reportPath); err != nil {
+		log.Fatalf("backtest: failed to write report: %v", err)
+	}
+	log.Printf("Per-trade report written to %s", reportPath)
+}
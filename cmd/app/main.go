@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
 	"github.com/robfig/cron/v3"
@@ -17,15 +18,38 @@ import (
 
 	"neurotrade/configs"
 	"neurotrade/internal/adapter"
+	"neurotrade/internal/backtest"
+	"neurotrade/internal/crypto"
 	httpdelivery "neurotrade/internal/delivery/http"
 	"neurotrade/internal/domain"
+	"neurotrade/internal/exchange/binance"
 	"neurotrade/internal/infra"
+	"neurotrade/internal/middleware"
+	"neurotrade/internal/notification"
+	"neurotrade/internal/rebalance"
 	"neurotrade/internal/repository"
 	"neurotrade/internal/service"
 	"neurotrade/internal/usecase"
 )
 
 func main() {
+	// "neurotrade backtest --from ... --to ... --preset ..." runs a
+	// reproducible offline replay instead of booting the HTTP server, so an
+	// operator can script/CI a strategy comparison without hitting
+	// BacktestHandler.TriggerBacktest over the network.
+	if len(os.Args) > 1 && os.Args[1] == "backtest" {
+		runBacktestCLI(os.Args[2:])
+		return
+	}
+
+	// "neurotrade migrate up|down [N]|status|force VERSION" drives
+	// database.RunMigrations/RollbackTo/RollbackN/Status/ForceVersion
+	// directly, without booting the HTTP server.
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrateCLI(os.Args[2:])
+		return
+	}
+
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
 		log.Println("Warning: .env file not found, using environment variables")
@@ -44,32 +68,159 @@ func main() {
 	}
 	defer db.Close()
 
+	// Redis-backed JWT revocation: optional, cross-instance layer on top of
+	// AuthMiddleware's in-memory jti set. Left disabled (local-only
+	// revocation) if REDIS_URL isn't configured.
+	redisClient, err := infra.NewRedisClient(ctx, cfg.Redis.URL)
+	if err != nil {
+		log.Printf("WARNING: Redis unavailable, jti revocation will be local-only: %v", err)
+	} else if redisClient != nil {
+		middleware.SetRevocationRedisClient(redisClient)
+		defer redisClient.Close()
+	}
+
+	// Credential envelope: encrypts/decrypts Binance API keys at rest
+	credentialEnvelope, err := crypto.NewEnvelopeFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to initialize credential envelope: %v", err)
+	}
+
 	// Initialize repositories
 	signalRepo := repository.NewSignalRepository(db)
-	userRepo := repository.NewUserRepository(db)
+	userRepo := repository.NewUserRepository(db, credentialEnvelope)
 	positionRepo := repository.NewPaperPositionRepository(db)
+	realPositionRepo := repository.NewPositionRepository(db)
+	withdrawalRepo := repository.NewWithdrawalRepository(db)
+	depositRepo := repository.NewDepositRepository(db)
+	signalSubscriptionRepo := repository.NewSignalSubscriptionRepository(db)
+	systemSettingsRepo := repository.NewSystemSettingsRepository(db)
+	profitStatsRepo := repository.NewProfitStatsRepository(db)
+	hedgePositionRepo := repository.NewHedgePositionRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	executionIdempotencyRepo := repository.NewExecutionIdempotencyRepository(db)
 
 	// Create default user for Phase 3-4 (later will be per-user authentication)
 	defaultUserID := ensureDefaultUserWithPassword(ctx, userRepo)
 
 	// Initialize AI service (Python Bridge)
-	aiService := adapter.NewPythonBridge(cfg.Python.URL)
+	aiService := adapter.NewPythonBridge(cfg.Python.URL, executionIdempotencyRepo)
 
 	// Health check Python engine
 	log.Println("Checking Python Engine health...")
-	if bridge, ok := aiService.(*adapter.PythonBridge); ok {
-		if err := bridge.HealthCheck(ctx); err != nil {
+	pythonBridge, _ := aiService.(*adapter.PythonBridge)
+	if pythonBridge != nil {
+		if err := pythonBridge.HealthCheck(ctx); err != nil {
 			log.Printf("WARNING: Python Engine is not available: %v", err)
 			log.Println("Scheduler will continue, but market scans will fail until Python Engine is running")
 		} else {
 			log.Println("✓ Python Engine is healthy")
 		}
+
+		// Replaces GetWebSocketPrices' REST polling with a persistent WS
+		// connection to the Python engine, reconnecting in the background.
+		pythonBridge.EnablePriceStream()
+		defer func() {
+			closeCtx, closeCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer closeCancel()
+			if err := pythonBridge.ClosePriceStream(closeCtx); err != nil {
+				log.Printf("WARNING: PriceStream did not close cleanly: %v", err)
+			}
+		}()
+	}
+
+	// Notification router fans messages out to every configured backend;
+	// each backend no-ops on its own if it isn't configured.
+	notificationService := notification.NewRouter(
+		notification.BackendConfig{
+			Backend: notification.NewTelegramBackend(os.Getenv("TELEGRAM_BOT_TOKEN"), os.Getenv("TELEGRAM_CHAT_ID")),
+		},
+		notification.BackendConfig{
+			Backend: notification.NewDiscordBackend(os.Getenv("DISCORD_WEBHOOK_URL")),
+		},
+		notification.BackendConfig{
+			Backend:     notification.NewWebhookBackend(os.Getenv("ALERT_WEBHOOK_URL")),
+			MinSeverity: notification.SeverityError,
+		},
+		notification.BackendConfig{
+			Backend: notification.NewSMTPBackend(
+				os.Getenv("SMTP_HOST"),
+				os.Getenv("SMTP_PORT"),
+				os.Getenv("SMTP_USERNAME"),
+				os.Getenv("SMTP_PASSWORD"),
+				os.Getenv("SMTP_FROM"),
+				os.Getenv("SMTP_TO"),
+			),
+			MinSeverity: notification.SeverityError,
+		},
+	)
+
+	// Binance Futures adapter for REAL-mode Position execution. Left nil
+	// (falling back to the legacy aiService close path) unless API
+	// credentials are configured for the process.
+	var futuresExchange binance.FuturesExchange
+	if apiKey := os.Getenv("BINANCE_API_KEY"); apiKey != "" {
+		futuresExchange = binance.NewClient(apiKey, os.Getenv("BINANCE_API_SECRET"))
+	}
+
+	// Hedge exchange account for cross-exchange hedge mode (see
+	// service.HedgeService), opt-in per user via User.HedgeEnabled. A
+	// separate credential pair from BINANCE_API_KEY so the maker and hedge
+	// legs can live on different accounts.
+	var hedgeExchange binance.FuturesExchange
+	if apiKey := os.Getenv("HEDGE_BINANCE_API_KEY"); apiKey != "" {
+		hedgeExchange = binance.NewClient(apiKey, os.Getenv("HEDGE_BINANCE_API_SECRET"))
 	}
+	hedgeService := service.NewHedgeService(hedgeExchange, hedgePositionRepo, cfg.Hedge)
 
 	// Initialize services
 	priceService := service.NewMarketPriceService()
-	virtualBroker := service.NewVirtualBrokerService(positionRepo, userRepo, priceService)
-	reviewService := service.NewReviewService(signalRepo, priceService)
+	// Real-time mark price + ticker cache for ClosePosition's exit price and
+	// MonitorOpenPositions' SL/TP checks; priceService above stays the bulk
+	// REST snapshot used by review/rebalance.
+	tickerPriceService := service.NewBinanceTickerPriceService()
+	orderLimiter := service.NewOrderRateLimiter()
+	// ATR-based SL/TP fallback for signals without explicit SL/TP (and, with
+	// SLTP_ATR_SIZING=true, a forced override even when one arrived). Backed
+	// by priceService's Binance klines fetch.
+	atrRiskModel := service.NewATRRiskModel("15m", 14, 1.5, 3.0, 0.004)
+	var klineProvider domain.KlineProvider = priceService
+	// Exchange-precision (tick/step/minNotional) rounding for REAL-mode order
+	// prices/sizes, reading Binance's exchangeInfo.
+	instrumentInfoService := service.NewMarketInfoService()
+	// Rate-limited, retrying wrapper around aiService.ExecuteClose, so a
+	// burst of REAL-mode closes can't trip Binance's per-second order cap or
+	// per-minute weight budget.
+	executionGateway := service.NewExecutionGateway(aiService, notificationService)
+	// Accrues realized funding into open positions' FundingPaid every
+	// 00:00/08:00/16:00 UTC window; nil fundingProvider (no
+	// BINANCE_FUNDING_RATE_URL configured) just leaves it a no-op.
+	fundingService := service.NewFundingService(service.NewBinanceFundingRateProvider(), realPositionRepo)
+	// Push-based prices for CheckPositions' SL/TP evaluation: subscribes to a
+	// Binance mark-price websocket for whatever symbols currently have open
+	// positions, re-subscribing as positions open/close, so TP/SL latency
+	// isn't capped at the CheckPositions cron's 1-minute cadence. A stale or
+	// never-ticked symbol transparently falls back to priceService's REST
+	// poll inside CheckPositions.
+	priceStream := service.NewPriceStream(tickerPriceService, realPositionRepo)
+	priceStream.Start(ctx)
+	defer priceStream.Close()
+	virtualBroker := service.NewVirtualBrokerService(realPositionRepo, userRepo, priceService, signalRepo, notificationService, aiService, orderLimiter, futuresExchange, atrRiskModel, klineProvider, instrumentInfoService, executionGateway, fundingService, priceStream)
+	// The active strategy preset's exit_rules JSONB column drives which
+	// ExitRule chain ReviewService evaluates; loadActiveExitRuleChain falls
+	// back to DefaultExitRuleChain on any lookup/parse failure so a missing
+	// or malformed column never blocks startup.
+	reviewService := service.NewReviewService(signalRepo, priceService, notificationService, loadActiveExitRuleChain(db, priceService))
+	ledgerService := service.NewLedgerService(userRepo, withdrawalRepo, depositRepo, pythonBridge)
+
+	// Portfolio rebalancer: nudges open exposure toward these target weights.
+	// DryRun is off so the scheduled tick actually trades; the manual preview
+	// endpoint always just plans.
+	rebalanceTargets := rebalance.TargetWeights{"BTCUSDT": 0.4, "ETHUSDT": 0.3, "SOLUSDT": 0.3}
+	rebalanceService := rebalance.NewService(realPositionRepo, priceService, rebalanceTargets, 0.05, false)
+
+	// Rolling per-user profit stats (daily + all_time buckets); risk-free
+	// rate is 0 since there's no natural baseline for a crypto futures bot.
+	statsAggregator := service.NewStatsAggregator(profitStatsRepo, 0)
 
 	// Initialize trading service
 	tradingService := usecase.NewTradingService(
@@ -77,16 +228,34 @@ func main() {
 		signalRepo,
 		positionRepo,
 		userRepo,
-		cfg.Trading.MinConfidence,
-		defaultUserID,
+		notificationService,
+		tickerPriceService,
+		virtualBroker,
+		signalSubscriptionRepo,
+		systemSettingsRepo,
+		statsAggregator,
+		hedgeService,
+		instrumentInfoService,
 	)
 
-	// Initialize market scan scheduler
-	marketScanScheduler := infra.NewScheduler(tradingService, cfg.Trading.DefaultBalance)
-	if err := marketScanScheduler.Start(); err != nil {
-		log.Fatalf("Failed to start market scan scheduler: %v", err)
+	// Initialize the DB-driven scheduler: scheduled_jobs rows (MARKET_SCAN,
+	// REVIEW_SIGNALS, CLOSE_STALE_POSITIONS, BACKTEST_ROLLING) replace what
+	// used to be a single hardcoded "59 * * * *" market scan, so ops can
+	// add/pause/retime jobs via AdminHandler without a redeploy.
+	scheduledJobRepo := repository.NewScheduledJobRepository(db)
+	backtestRunStore := backtest.NewRunStore(db)
+	jobScheduler := infra.NewScheduler(
+		scheduledJobRepo,
+		tradingService,
+		reviewService,
+		tradingService,
+		backtest.NewRollingRunner(backtestRunStore),
+		cfg.Trading.DefaultBalance,
+	)
+	if err := jobScheduler.Start(context.Background()); err != nil {
+		log.Fatalf("Failed to start scheduler: %v", err)
 	}
-	defer marketScanScheduler.Stop()
+	defer jobScheduler.Stop()
 
 	// Initialize Phase 3 cron jobs
 	cronScheduler := cron.New()
@@ -102,6 +271,18 @@ func main() {
 		log.Fatalf("Failed to add virtual broker cron job: %v", err)
 	}
 
+	// Paper Position Monitor: auto-close positions whose SL/TP has been hit,
+	// instead of relying on a user to notice and close manually
+	_, err = cronScheduler.AddFunc("*/1 * * * *", func() {
+		ctx := context.Background()
+		if err := tradingService.MonitorOpenPositions(ctx); err != nil {
+			log.Printf("ERROR: Paper position monitor failed: %v", err)
+		}
+	})
+	if err != nil {
+		log.Fatalf("Failed to add paper position monitor cron job: %v", err)
+	}
+
 	// Review Service: Review signals at minute 5 of every hour
 	_, err = cronScheduler.AddFunc("5 * * * *", func() {
 		ctx := context.Background()
@@ -113,28 +294,131 @@ func main() {
 		log.Fatalf("Failed to add review service cron job: %v", err)
 	}
 
+	// Ledger Sync: Pull REAL-mode withdrawal/deposit history every 15 minutes
+	_, err = cronScheduler.AddFunc("*/15 * * * *", func() {
+		ctx := context.Background()
+		if err := ledgerService.SyncAll(ctx); err != nil {
+			log.Printf("ERROR: Ledger sync failed: %v", err)
+		}
+	})
+	if err != nil {
+		log.Fatalf("Failed to add ledger sync cron job: %v", err)
+	}
+
+	// Portfolio Rebalance: nudge every user's open exposure toward target
+	// weights once an hour
+	_, err = cronScheduler.AddFunc("20 * * * *", func() {
+		ctx := context.Background()
+		users, err := userRepo.GetAll(ctx)
+		if err != nil {
+			log.Printf("ERROR: Rebalance: failed to list users: %v", err)
+			return
+		}
+		for _, user := range users {
+			if _, err := rebalanceService.Execute(ctx, user.ID); err != nil {
+				log.Printf("ERROR: Rebalance failed for user %s: %v", user.ID, err)
+			}
+		}
+	})
+	if err != nil {
+		log.Fatalf("Failed to add rebalance cron job: %v", err)
+	}
+
+	// Equity Snapshot: record every user's balance once a day, at 23:55
+	// Jakarta so the snapshot lands just before StatsAggregator's Jakarta
+	// day boundary rolls over, for the profit-stats equity curve (see
+	// service.StatsAggregator, service.PnLService)
+	_, err = cronScheduler.AddFunc("CRON_TZ=Asia/Jakarta 55 23 * * *", func() {
+		ctx := context.Background()
+		users, err := userRepo.GetAll(ctx)
+		if err != nil {
+			log.Printf("ERROR: Equity snapshot: failed to list users: %v", err)
+			return
+		}
+		for _, user := range users {
+			if err := statsAggregator.SnapshotEquity(ctx, user.ID, user.PaperBalance); err != nil {
+				log.Printf("ERROR: Equity snapshot failed for user %s: %v", user.ID, err)
+			}
+		}
+	})
+	if err != nil {
+		log.Fatalf("Failed to add equity snapshot cron job: %v", err)
+	}
+
+	// Hedge Reconcile: check open hedge exposure against open paper exposure
+	// every 10 minutes (see service.HedgeService.Reconcile)
+	_, err = cronScheduler.AddFunc("*/10 * * * *", func() {
+		ctx := context.Background()
+		openPositions, err := positionRepo.GetOpenPositions(ctx)
+		if err != nil {
+			log.Printf("ERROR: Hedge reconcile: failed to list open positions: %v", err)
+			return
+		}
+		if err := hedgeService.Reconcile(ctx, openPositions); err != nil {
+			log.Printf("ERROR: Hedge reconcile failed: %v", err)
+		}
+	})
+	if err != nil {
+		log.Fatalf("Failed to add hedge reconcile cron job: %v", err)
+	}
+
 	// Start Phase 3 cron scheduler
 	cronScheduler.Start()
 	defer cronScheduler.Stop()
 
+	// REAL-mode Binance Futures: reconcile DB state against the exchange at
+	// startup, then stream fills so SL/TP orders executed by Binance itself
+	// (not our polling loop) still close the Position in the DB.
+	if futuresExchange != nil {
+		if _, err := binance.ReconcileOpenPositions(ctx, futuresExchange, realPositionRepo, defaultUserID.String()); err != nil {
+			log.Printf("WARNING: Binance reconciliation failed: %v", err)
+		}
+
+		stopUserData, err := futuresExchange.StreamUserData(ctx, func(event binance.UserDataEvent) {
+			if err := virtualBroker.HandleUserDataEvent(context.Background(), event); err != nil {
+				log.Printf("ERROR: Failed to handle Binance user data event: %v", err)
+			}
+		})
+		if err != nil {
+			log.Printf("WARNING: Failed to start Binance user data stream: %v", err)
+		} else {
+			defer stopUserData()
+		}
+	}
+
 	log.Println("✓ Phase 3 services initialized:")
 	log.Println("  - Virtual Broker: Every 1 minute (*/1 * * * *)")
 	log.Println("  - Review Service: Minute 5 of every hour (5 * * * *)")
+	log.Println("  - Ledger Sync: Every 15 minutes (*/15 * * * *)")
+	log.Println("  - Equity Snapshot: Daily at 23:55 Jakarta")
+	log.Println("  - Hedge Reconcile: Every 10 minutes (*/10 * * * *)")
 
 	// Initialize Echo HTTP server
 	e := echo.New()
 	e.HideBanner = true
 
 	// Initialize HTTP handlers
-	authHandler := httpdelivery.NewAuthHandler(userRepo)
-	userHandler := httpdelivery.NewUserHandler(userRepo, positionRepo, tradingService)
-	adminHandler := httpdelivery.NewAdminHandler(db)
+	authHandler := httpdelivery.NewAuthHandler(userRepo, sessionRepo)
+	userHandler := httpdelivery.NewUserHandler(userRepo, positionRepo, realPositionRepo, tradingService, virtualBroker, statsAggregator)
+	adminHandler := httpdelivery.NewAdminHandler(db, scheduledJobRepo, jobScheduler)
+	rebalanceHandler := httpdelivery.NewRebalanceHandler(rebalanceService)
+	pnlService := service.NewPnLService(positionRepo, priceService, 5) // 5 bps/side, matches TradingService's 0.05% paper close fee
+	pnlHandler := httpdelivery.NewPnLHandler(pnlService)
+	dependencyHandler := httpdelivery.NewDependencyHandler()
+	backtestHandler := httpdelivery.NewBacktestHandler(backtestRunStore)
+	klineRepo := repository.NewKlineRepository(db)
+	marketHandler := httpdelivery.NewMarketHandler(priceService, klineRepo)
 
 	// Setup routes
 	httpdelivery.SetupRoutes(e, &httpdelivery.RouterConfig{
-		AuthHandler:  authHandler,
-		UserHandler:  userHandler,
-		AdminHandler: adminHandler,
+		AuthHandler:       authHandler,
+		UserHandler:       userHandler,
+		AdminHandler:      adminHandler,
+		RebalanceHandler:  rebalanceHandler,
+		DependencyHandler: dependencyHandler,
+		BacktestHandler:   backtestHandler,
+		MarketHandler:     marketHandler,
+		PnLHandler:        pnlHandler,
 	})
 
 	// Start HTTP server
@@ -153,6 +437,7 @@ func main() {
 	log.Println("  - POST /api/user/mode/toggle (protected)")
 	log.Println("  - GET  /api/user/positions (protected)")
 	log.Println("  - POST /api/user/panic-button (protected)")
+	log.Println("  - GET  /api/user/stats (protected)")
 	log.Println("  - GET  /api/admin/strategies (admin)")
 	log.Println("  - PUT  /api/admin/strategies/active (admin)")
 	log.Println("  - GET  /api/admin/system/health (admin)")
@@ -224,3 +509,28 @@ func ensureDefaultUserWithPassword(ctx context.Context, userRepo domain.UserRepo
 	log.Println("  Password: password123")
 	return userID
 }
+
+// loadActiveExitRuleChain reads the active strategy preset's exit_rules
+// column and parses it via service.ParseExitRuleChain, for ReviewService's
+// startup configuration. Any lookup or parse failure -- no active preset, no
+// exit_rules column yet (pre-migration), malformed JSON -- falls back to
+// service.DefaultExitRuleChain rather than blocking startup.
+func loadActiveExitRuleChain(db *pgxpool.Pool, priceService *service.MarketPriceService) []service.ExitRule {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var exitRulesJSON []byte
+	err := db.QueryRow(ctx, "SELECT COALESCE(exit_rules, '[]') FROM strategy_presets WHERE is_active = true LIMIT 1").Scan(&exitRulesJSON)
+	if err != nil {
+		log.Printf("WARNING: Failed to load active strategy's exit_rules, using default exit rule chain: %v", err)
+		return service.DefaultExitRuleChain()
+	}
+
+	chain, err := service.ParseExitRuleChain(priceService, exitRulesJSON)
+	if err != nil {
+		log.Printf("WARNING: Failed to parse active strategy's exit_rules, using default exit rule chain: %v", err)
+		return service.DefaultExitRuleChain()
+	}
+
+	return chain
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/joho/godotenv"
+
+	"neurotrade/configs"
+	"neurotrade/internal/database"
+	"neurotrade/internal/infra"
+)
+
+// runMigrateCLI implements "neurotrade migrate <subcommand>", a thin wrapper
+// around database.RunMigrations/RollbackTo/RollbackN/Status/ForceVersion for
+// operators who need to drive migrations outside of the normal app boot
+// (e.g. a deploy step, or recovering from a checksum-drift failure).
+func runMigrateCLI(args []string) {
+	if len(args) == 0 {
+		log.Fatal("migrate: expected a subcommand (up, down, status, force)")
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found, using environment variables")
+	}
+	cfg := configs.Load()
+
+	ctx := context.Background()
+	db, err := infra.NewDatabase(ctx, cfg.Database.URL)
+	if err != nil {
+		log.Fatalf("migrate: failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	switch args[0] {
+	case "up":
+		if err := database.RunMigrations(db); err != nil {
+			log.Fatalf("migrate up: %v", err)
+		}
+		log.Println("migrate up: done")
+
+	case "down":
+		fs := flag.NewFlagSet("migrate down", flag.ExitOnError)
+		fs.Parse(args[1:])
+		n := 1
+		if fs.NArg() > 0 {
+			if _, err := fmt.Sscanf(fs.Arg(0), "%d", &n); err != nil {
+				log.Fatalf("migrate down: invalid N %q: %v", fs.Arg(0), err)
+			}
+		}
+		if err := database.RollbackN(ctx, db, n); err != nil {
+			log.Fatalf("migrate down: %v", err)
+		}
+		log.Printf("migrate down: rolled back %d migration(s)", n)
+
+	case "status":
+		statuses, err := database.Status(ctx, db)
+		if err != nil {
+			log.Fatalf("migrate status: %v", err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied"
+			}
+			if s.Drifted {
+				state = "DRIFTED"
+			}
+			downNote := ""
+			if !s.HasDown {
+				downNote = " (no down migration)"
+			}
+			fmt.Printf("%s\t%s%s\n", s.Version, state, downNote)
+		}
+
+	case "force":
+		if len(args) < 2 {
+			log.Fatal("migrate force: expected a version argument")
+		}
+		if err := database.ForceVersion(ctx, db, args[1]); err != nil {
+			log.Fatalf("migrate force: %v", err)
+		}
+		log.Printf("migrate force: %s marked as applied", args[1])
+
+	default:
+		log.Fatalf("migrate: unknown subcommand %q (expected up, down, status, force)", args[0])
+	}
+}
@@ -2,6 +2,8 @@ package configs
 
 import (
 	"os"
+	"strconv"
+	"strings"
 )
 
 // Config holds all configuration for the application
@@ -10,6 +12,8 @@ type Config struct {
 	Database DatabaseConfig
 	Redis    RedisConfig
 	Python   PythonEngineConfig
+	Hedge    HedgeConfig
+	Trading  TradingConfig
 }
 
 // ServerConfig holds server configuration
@@ -33,6 +37,24 @@ type PythonEngineConfig struct {
 	URL string
 }
 
+// HedgeConfig holds cross-exchange hedge mode settings (see
+// service.HedgeService, opt-in per user via User.HedgeEnabled). SymbolMap
+// maps a paper-traded symbol to the symbol traded on the hedge exchange
+// (usually identical, but lets the two venues list the same instrument
+// under different tickers).
+type HedgeConfig struct {
+	SymbolMap   map[string]string
+	HedgeRatio  float64 // fraction of paper exposure offset on the hedge exchange, e.g. 0.25
+	MaxExposure float64 // hard cap on total hedge notional (USDT) across all symbols
+}
+
+// TradingConfig holds the defaults ProcessMarketScan falls back to when a
+// user hasn't configured their own balance/confidence filter yet.
+type TradingConfig struct {
+	DefaultBalance float64 // starting USDT balance assumed for a user with none recorded
+	MinConfidence  int     // signals below this confidence are filtered out by default
+}
+
 // Load loads configuration from environment variables
 func Load() *Config {
 	return &Config{
@@ -49,6 +71,15 @@ func Load() *Config {
 		Python: PythonEngineConfig{
 			URL: getEnv("PYTHON_ENGINE_URL", "http://localhost:8000"),
 		},
+		Hedge: HedgeConfig{
+			SymbolMap:   parseSymbolMap(getEnv("HEDGE_SYMBOL_MAP", "")),
+			HedgeRatio:  getEnvFloat("HEDGE_RATIO", 0.25),
+			MaxExposure: getEnvFloat("HEDGE_MAX_EXPOSURE", 1000),
+		},
+		Trading: TradingConfig{
+			DefaultBalance: getEnvFloat("DEFAULT_BALANCE", 1000),
+			MinConfidence:  getEnvInt("MIN_CONFIDENCE", 70),
+		},
 	}
 }
 
@@ -59,3 +90,43 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvFloat gets an environment variable parsed as a float64, or returns
+// defaultValue if unset or unparseable.
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvInt gets an environment variable parsed as an int, or returns
+// defaultValue if unset or unparseable.
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// parseSymbolMap parses a "PAPER:HEDGE,PAPER2:HEDGE2" env var into a lookup
+// map, e.g. "BTCUSDT:BTCUSDT,ETHUSDT:ETHUSD_PERP". Malformed entries are
+// skipped.
+func parseSymbolMap(raw string) map[string]string {
+	symbolMap := make(map[string]string)
+	if raw == "" {
+		return symbolMap
+	}
+
+	for _, pair := range strings.Split(raw, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			continue
+		}
+		symbolMap[parts[0]] = parts[1]
+	}
+
+	return symbolMap
+}
@@ -0,0 +1,241 @@
+package adapter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"neurotrade/internal/domain"
+	"neurotrade/internal/metrics"
+)
+
+// analysisStreamEventTimeout bounds how long a single SSE event can take to
+// arrive before the stream is treated as stalled and torn down. This only
+// ends the one stream -- pb.httpClient itself is left alone, so a stalled
+// scan doesn't take down later calls.
+const analysisStreamEventTimeout = 30 * time.Second
+
+// AnalysisEventType enumerates the SSE event names
+// /analyze/market/stream dispatches.
+type AnalysisEventType string
+
+const (
+	AnalysisEventBTCContext AnalysisEventType = "btc_context"
+	AnalysisEventSignal     AnalysisEventType = "signal"
+	AnalysisEventProgress   AnalysisEventType = "progress"
+	AnalysisEventDone       AnalysisEventType = "done"
+	AnalysisEventError      AnalysisEventType = "error"
+)
+
+// AnalysisEvent is one decoded SSE event from StreamAnalyzeMarket. Only the
+// field(s) matching Type are populated.
+type AnalysisEvent struct {
+	Type                  AnalysisEventType
+	Timestamp             time.Time
+	BTCContext            map[string]interface{}
+	Signal                *domain.AISignalResponse
+	Progress              string
+	OpportunitiesScreened int
+	ExecutionTimeSeconds  float64
+	Error                 string
+}
+
+// StreamAnalyzeMarket hits the Python engine's /analyze/market/stream
+// endpoint and returns a channel of decoded AnalysisEvents, so a caller can
+// show BTC context, per-signal, and progress updates as the scan runs
+// instead of waiting up to 120s for the whole thing to finish (see
+// AnalyzeMarket, which collects this same stream into one slice). The
+// channel is closed when the stream ends, whether via a "done"/"error"
+// event, a read error, a stall beyond analysisStreamEventTimeout, or ctx
+// cancellation.
+func (pb *PythonBridge) StreamAnalyzeMarket(ctx context.Context, balance float64, mode string) (<-chan AnalysisEvent, error) {
+	if mode == "" {
+		mode = "SCALPER"
+	}
+
+	reqBody := MarketAnalysisRequest{Balance: balance, Mode: mode}
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/analyze/market/stream", pb.baseURL)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := pb.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Python engine: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("Python engine returned error: status=%d, body=%s", resp.StatusCode, string(body))
+	}
+
+	events := make(chan AnalysisEvent, 16)
+	go readAnalysisStream(ctx, resp.Body, events)
+	return events, nil
+}
+
+// readAnalysisStream parses resp.Body as an SSE stream -- "event:"/"data:"
+// lines separated by a blank line -- dispatching one AnalysisEvent per
+// blank-line-terminated block to events, until the body closes, a read
+// stalls beyond analysisStreamEventTimeout, or ctx is cancelled.
+func readAnalysisStream(ctx context.Context, body io.ReadCloser, events chan<- AnalysisEvent) {
+	defer close(events)
+	defer body.Close()
+
+	lines := make(chan string)
+	readDone := make(chan error, 1)
+	go func() {
+		reader := bufio.NewReader(body)
+		for {
+			line, err := reader.ReadString('\n')
+			if line != "" {
+				select {
+				case lines <- line:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				readDone <- err
+				return
+			}
+		}
+	}()
+
+	var eventName string
+	var dataLines []string
+
+	emit := func() {
+		if eventName == "" && len(dataLines) == 0 {
+			return
+		}
+		evt, err := decodeAnalysisEvent(eventName, strings.Join(dataLines, "\n"))
+		if err != nil {
+			metrics.RecordDecodeError()
+			log.Printf("[WARN] PythonBridge: failed to decode SSE event %q: %v", eventName, err)
+		} else {
+			select {
+			case events <- evt:
+			case <-ctx.Done():
+			}
+		}
+		eventName, dataLines = "", nil
+	}
+
+	for {
+		timer := time.NewTimer(analysisStreamEventTimeout)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			log.Printf("[WARN] PythonBridge: analysis stream stalled beyond %s, closing", analysisStreamEventTimeout)
+			return
+		case err := <-readDone:
+			timer.Stop()
+			if err != io.EOF {
+				log.Printf("[WARN] PythonBridge: analysis stream read error: %v", err)
+			}
+			emit()
+			return
+		case line := <-lines:
+			timer.Stop()
+			line = strings.TrimRight(line, "\r\n")
+			switch {
+			case line == "":
+				emit()
+			case strings.HasPrefix(line, "event:"):
+				eventName = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+			case strings.HasPrefix(line, "data:"):
+				dataLines = append(dataLines, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+			}
+		}
+	}
+}
+
+// analysisTimestampEnvelope extracts an optional "timestamp" field shared
+// by every event payload shape, using FlexibleTime since the Python engine
+// doesn't always format timestamps the same way.
+type analysisTimestampEnvelope struct {
+	Timestamp *FlexibleTime `json:"timestamp"`
+}
+
+// decodeAnalysisEvent parses data according to eventName into the matching
+// AnalysisEvent field(s).
+func decodeAnalysisEvent(eventName, data string) (AnalysisEvent, error) {
+	evt := AnalysisEvent{Type: AnalysisEventType(eventName), Timestamp: time.Now()}
+
+	var envelope analysisTimestampEnvelope
+	_ = json.Unmarshal([]byte(data), &envelope) // best-effort; not every payload carries a timestamp
+	if envelope.Timestamp != nil {
+		evt.Timestamp = envelope.Timestamp.Time
+	}
+
+	switch evt.Type {
+	case AnalysisEventBTCContext:
+		var payload struct {
+			Context map[string]interface{} `json:"context"`
+		}
+		if err := json.Unmarshal([]byte(data), &payload); err != nil {
+			return evt, err
+		}
+		evt.BTCContext = payload.Context
+
+	case AnalysisEventSignal:
+		var signal domain.AISignalResponse
+		if err := json.Unmarshal([]byte(data), &signal); err != nil {
+			return evt, err
+		}
+		evt.Signal = &signal
+
+	case AnalysisEventProgress:
+		var payload struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal([]byte(data), &payload); err != nil {
+			return evt, err
+		}
+		evt.Progress = payload.Message
+
+	case AnalysisEventDone:
+		var payload struct {
+			OpportunitiesScreened int     `json:"opportunities_screened"`
+			ExecutionTimeSeconds  float64 `json:"execution_time_seconds"`
+		}
+		if err := json.Unmarshal([]byte(data), &payload); err != nil {
+			return evt, err
+		}
+		evt.OpportunitiesScreened = payload.OpportunitiesScreened
+		evt.ExecutionTimeSeconds = payload.ExecutionTimeSeconds
+
+	case AnalysisEventError:
+		var payload struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal([]byte(data), &payload); err != nil {
+			return evt, err
+		}
+		evt.Error = payload.Message
+
+	default:
+		return evt, fmt.Errorf("unknown SSE event type: %q", eventName)
+	}
+
+	return evt, nil
+}
@@ -0,0 +1,286 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"neurotrade/internal/metrics"
+)
+
+const (
+	// priceStreamReadTimeout bounds how long a single read can go without a
+	// message before connectAndRead treats the connection as dead and lets
+	// run's reconnect loop take over.
+	priceStreamReadTimeout = 30 * time.Second
+
+	// priceStreamMinBackoff/MaxBackoff bound the jittered reconnect delay.
+	priceStreamMinBackoff = 1 * time.Second
+	priceStreamMaxBackoff = 30 * time.Second
+)
+
+// PriceTick is one price update delivered to a PriceStream subscriber.
+type PriceTick struct {
+	Symbol string
+	Price  float64
+	Time   time.Time
+}
+
+// priceEntry is PriceStream's in-process last-known-price cache entry.
+type priceEntry struct {
+	price float64
+	at    time.Time
+}
+
+// subscription is one Subscribe call's delivery channel, optionally filtered
+// to a fixed set of symbols.
+type subscription struct {
+	symbols map[string]bool // nil means "all symbols"
+	ch      chan PriceTick
+}
+
+// PriceStream maintains a persistent WebSocket connection to the Python
+// engine's /ws/prices endpoint, caching the last price per symbol in an
+// in-process sync.Map so Latest and GetWebSocketPrices never block on the
+// network. The connection lifecycle mirrors netstack-style deadline
+// handling: every read resets a deadline on the underlying connection
+// rather than relying on one long-lived timeout, and connectAndRead's
+// cancelCh lets Close interrupt a blocked read immediately instead of
+// waiting it out. A background goroutine reconnects with jittered
+// exponential backoff whenever the connection drops, until Close is called.
+type PriceStream struct {
+	baseURL    string
+	staleAfter time.Duration
+
+	prices sync.Map // string -> priceEntry
+
+	subMu       sync.Mutex
+	subscribers []subscription
+
+	connected atomic.Bool
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+// NewPriceStream creates a PriceStream against baseURL, the same host
+// PythonBridge talks to over REST. staleAfter bounds how long a cached price
+// is trusted before GetWebSocketPrices reports it as stale; Latest returns
+// the cached time and leaves that check to its caller.
+func NewPriceStream(baseURL string, staleAfter time.Duration) *PriceStream {
+	return &PriceStream{
+		baseURL:    baseURL,
+		staleAfter: staleAfter,
+		stopCh:     make(chan struct{}),
+		doneCh:     make(chan struct{}),
+	}
+}
+
+// Start begins the reconnecting WebSocket loop in the background. Call
+// Close to stop it.
+func (ps *PriceStream) Start() {
+	go ps.run()
+}
+
+// Subscribe returns a channel fed with every PriceTick the stream decodes
+// for the given symbols (all symbols if empty), for as long as PriceStream
+// runs. The channel is never closed by PriceStream -- Close tears down the
+// whole stream rather than individual subscriptions, so a caller that stops
+// reading should just drop the channel.
+func (ps *PriceStream) Subscribe(symbols []string) <-chan PriceTick {
+	var want map[string]bool
+	if len(symbols) > 0 {
+		want = make(map[string]bool, len(symbols))
+		for _, symbol := range symbols {
+			want[symbol] = true
+		}
+	}
+
+	ch := make(chan PriceTick, 64)
+
+	ps.subMu.Lock()
+	ps.subscribers = append(ps.subscribers, subscription{symbols: want, ch: ch})
+	ps.subMu.Unlock()
+
+	return ch
+}
+
+// Latest returns the last price cached for symbol, and whether it was found
+// at all. Callers that care about staleness should compare the returned
+// time against staleAfter themselves (GetWebSocketPrices does this for the
+// REST-shaped fallback).
+func (ps *PriceStream) Latest(symbol string) (float64, time.Time, bool) {
+	v, ok := ps.prices.Load(symbol)
+	if !ok {
+		return 0, time.Time{}, false
+	}
+
+	entry := v.(priceEntry)
+	return entry.price, entry.at, true
+}
+
+// Close stops the reconnect loop and waits for it to exit, bounded by ctx,
+// rather than blocking indefinitely on a connection that may never notice
+// it's unwanted.
+func (ps *PriceStream) Close(ctx context.Context) error {
+	ps.stopOnce.Do(func() { close(ps.stopCh) })
+
+	select {
+	case <-ps.doneCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run reconnects to the Python engine's WebSocket with jittered exponential
+// backoff until Close is called.
+func (ps *PriceStream) run() {
+	defer close(ps.doneCh)
+
+	attempt := 0
+	for {
+		select {
+		case <-ps.stopCh:
+			return
+		default:
+		}
+
+		if err := ps.connectAndRead(); err != nil {
+			ps.setConnected(false)
+			log.Printf("[WARN] PriceStream: connection lost: %v", err)
+		}
+
+		attempt++
+		select {
+		case <-ps.stopCh:
+			return
+		case <-time.After(priceStreamBackoff(attempt)):
+		}
+	}
+}
+
+// priceStreamBackoff returns a jittered delay before reconnect attempt n,
+// doubling from priceStreamMinBackoff up to priceStreamMaxBackoff.
+func priceStreamBackoff(attempt int) time.Duration {
+	ceiling := priceStreamMinBackoff << uint(attempt-1)
+	if ceiling <= 0 || ceiling > priceStreamMaxBackoff {
+		ceiling = priceStreamMaxBackoff
+	}
+	delay := priceStreamMinBackoff + time.Duration(rand.Int63n(int64(ceiling)))
+	if delay > priceStreamMaxBackoff {
+		delay = priceStreamMaxBackoff
+	}
+	return delay
+}
+
+// connectAndRead dials the Python engine's WebSocket and reads ticks until
+// the connection fails or Close is called, decoding and caching each one.
+func (ps *PriceStream) connectAndRead() error {
+	wsURL, err := toWebSocketURL(ps.baseURL, "/ws/prices")
+	if err != nil {
+		return err
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		return fmt.Errorf("dial: %w", err)
+	}
+	defer conn.Close()
+
+	ps.setConnected(true)
+	log.Printf("[PriceStream] connected to %s", wsURL)
+
+	// cancelCh lets stopCh interrupt a blocked ReadMessage immediately
+	// instead of waiting out the read deadline below.
+	cancelCh := make(chan struct{})
+	defer close(cancelCh)
+	go func() {
+		select {
+		case <-ps.stopCh:
+			conn.Close()
+		case <-cancelCh:
+		}
+	}()
+
+	for {
+		conn.SetReadDeadline(time.Now().Add(priceStreamReadTimeout))
+
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return fmt.Errorf("read: %w", err)
+		}
+
+		var msg struct {
+			Symbol string  `json:"symbol"`
+			Price  float64 `json:"price"`
+		}
+		if err := json.Unmarshal(data, &msg); err != nil {
+			metrics.RecordDecodeError()
+			log.Printf("[WARN] PriceStream: failed to decode tick: %v", err)
+			continue
+		}
+
+		tick := PriceTick{Symbol: msg.Symbol, Price: msg.Price, Time: time.Now()}
+		ps.prices.Store(tick.Symbol, priceEntry{price: tick.Price, at: tick.Time})
+		ps.broadcast(tick)
+	}
+}
+
+// broadcast fans tick out to every subscriber interested in its symbol,
+// dropping it for a subscriber whose channel is full rather than blocking
+// the read loop (and therefore every other subscriber) on a slow reader.
+func (ps *PriceStream) broadcast(tick PriceTick) {
+	ps.subMu.Lock()
+	defer ps.subMu.Unlock()
+
+	for _, sub := range ps.subscribers {
+		if sub.symbols != nil && !sub.symbols[tick.Symbol] {
+			continue
+		}
+		select {
+		case sub.ch <- tick:
+		default:
+			log.Printf("[WARN] PriceStream: subscriber channel full, dropping tick for %s", tick.Symbol)
+		}
+	}
+}
+
+// setConnected updates both the in-process flag and the Prometheus gauge
+// GetWebSocketPrices' REST-polling predecessor used to use, so existing
+// dashboards keep working against the WS-backed implementation.
+func (ps *PriceStream) setConnected(connected bool) {
+	ps.connected.Store(connected)
+	if connected {
+		metrics.PythonBridgeWSConnected.Set(1)
+	} else {
+		metrics.PythonBridgeWSConnected.Set(0)
+	}
+}
+
+// toWebSocketURL rewrites baseURL's scheme to ws/wss and appends path.
+func toWebSocketURL(baseURL, path string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	if u.Scheme == "https" {
+		u.Scheme = "wss"
+	} else {
+		u.Scheme = "ws"
+	}
+	u.Path = strings.TrimRight(u.Path, "/") + path
+
+	return u.String(), nil
+}
@@ -4,30 +4,121 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
+
+	"neurotrade/internal/crypto"
 	"neurotrade/internal/domain"
+	"neurotrade/internal/metrics"
+	"neurotrade/internal/reliability"
 )
 
+// executionStatusPattern extracts the HTTP status code from the "status=NNN"
+// shape ExecuteEntry/ExecuteClose's error messages wrap a failed Python
+// Engine response in, for isRetryableIdempotentError.
+var executionStatusPattern = regexp.MustCompile(`status=(\d+)`)
+
 // PythonBridge implements AIService interface
 type PythonBridge struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL            string
+	httpClient         *http.Client
+	breaker            *reliability.CircuitBreaker
+	retryCfg           reliability.RetryConfig
+	idempotencyRepo    domain.ExecutionIdempotencyRepository
+	priceStream        *PriceStream
+	credentialEnvelope *crypto.Envelope
 }
 
-// NewPythonBridge creates a new Python Engine bridge
-func NewPythonBridge(baseURL string) domain.AIService {
+// NewPythonBridge creates a new Python Engine bridge. idempotencyRepo may be
+// nil, in which case ExecuteEntry/ExecuteClose retries still happen but a
+// crash between attempts is no longer guaranteed to resolve to the same
+// order.
+func NewPythonBridge(baseURL string, idempotencyRepo domain.ExecutionIdempotencyRepository) domain.AIService {
+	retryCfg := reliability.DefaultRetryConfig()
+	retryCfg.MaxAttempts = 4 // first call plus 3 retries
+	retryCfg.Retryable = isRetryableAIError
+
+	httpClient := &http.Client{
+		Timeout: 120 * time.Second, // AI analysis can take time
+	}
+	httpClient.Transport = newSigningRoundTripper(httpClient.Transport)
+
 	return &PythonBridge{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 120 * time.Second, // AI analysis can take time
-		},
+		baseURL:            baseURL,
+		httpClient:         metrics.Wrap(httpClient),
+		breaker:            reliability.NewCircuitBreaker("python_engine", reliability.DefaultBreakerConfig()),
+		retryCfg:           retryCfg,
+		idempotencyRepo:    idempotencyRepo,
+		credentialEnvelope: loadBridgeCredentialEnvelope(),
+	}
+}
+
+// encryptCredential seals plain with pb.credentialEnvelope when
+// BRIDGE_SIGNING_KEY is configured, otherwise returns it unchanged.
+func (pb *PythonBridge) encryptCredential(plain string) string {
+	if pb.credentialEnvelope == nil || plain == "" {
+		return plain
+	}
+
+	sealed, err := pb.credentialEnvelope.Encrypt(plain)
+	if err != nil {
+		log.Printf("[WARN] PythonBridge: failed to encrypt credential, sending plaintext: %v", err)
+		return plain
 	}
+	return sealed
+}
+
+// priceStreamDefaultStaleAfter bounds how old a PriceStream-cached price can
+// be before GetWebSocketPrices treats the feed as disconnected, even if the
+// underlying WebSocket connection itself hasn't noticed yet.
+const priceStreamDefaultStaleAfter = 10 * time.Second
+
+// EnablePriceStream starts a PriceStream against pb's Python engine and
+// switches GetWebSocketPrices over to reading from its local cache instead
+// of polling /prices. Safe to call at most once; a second call replaces the
+// previous stream without closing it, so callers should only call it during
+// startup.
+func (pb *PythonBridge) EnablePriceStream() {
+	pb.priceStream = NewPriceStream(pb.baseURL, priceStreamDefaultStaleAfter)
+	pb.priceStream.Start()
+}
+
+// ClosePriceStream tears down the PriceStream started by EnablePriceStream,
+// if any, bounded by ctx. It is a no-op if EnablePriceStream was never
+// called.
+func (pb *PythonBridge) ClosePriceStream(ctx context.Context) error {
+	if pb.priceStream == nil {
+		return nil
+	}
+	return pb.priceStream.Close(ctx)
+}
+
+// isRetryableAIError reports whether err from a Python engine call is worth
+// retrying: a connection-level failure or a 5xx response. A 4xx response
+// (bad request, validation) is the caller's fault and won't change on
+// retry, so it short-circuits instead of burning the retry budget.
+func isRetryableAIError(err error) bool {
+	if err == nil || errors.Is(err, reliability.ErrCircuitOpen) {
+		return false
+	}
+
+	msg := err.Error()
+	if strings.Contains(msg, "status=4") {
+		return false
+	}
+
+	// status=5xx or no status at all (dial/timeout/connection-reset) are
+	// both transient.
+	return true
 }
 
 // MarketAnalysisRequest represents the request to Python engine
@@ -65,64 +156,73 @@ func (ft *FlexibleTime) UnmarshalJSON(b []byte) error {
 	return fmt.Errorf("unable to parse timestamp: %s", s)
 }
 
-// MarketAnalysisResponse represents the response from Python engine
-type MarketAnalysisResponse struct {
-	Timestamp             FlexibleTime               `json:"timestamp"`
-	BTCContext            map[string]interface{}     `json:"btc_context"`
-	OpportunitiesScreened int                        `json:"opportunities_screened"`
-	ValidSignals          []*domain.AISignalResponse `json:"valid_signals"`
-	ExecutionTimeSeconds  float64                    `json:"execution_time_seconds"`
-}
-
-// AnalyzeMarket calls the Python Engine to analyze market and generate signals
+// AnalyzeMarket calls the Python Engine to analyze market and generate
+// signals, retrying transient failures (5xx, connection reset) up to 3
+// times with backoff via the reliability package, and short-circuiting
+// through python_engine's circuit breaker once it's tripped open.
 // mode: "SCALPER" for M15 aggressive trading, "INVESTOR" for H1 trend following
 func (pb *PythonBridge) AnalyzeMarket(ctx context.Context, balance float64, mode string) ([]*domain.AISignalResponse, error) {
-	// Default to SCALPER if mode is empty
-	if mode == "" {
-		mode = "SCALPER"
-	}
+	var signals []*domain.AISignalResponse
 
-	// Prepare request
-	reqBody := MarketAnalysisRequest{
-		Balance: balance,
-		Mode:    mode,
-	}
+	err := reliability.Do(ctx, pb.retryCfg, func() error {
+		if err := pb.breaker.Allow(); err != nil {
+			return err
+		}
 
-	jsonData, err := json.Marshal(reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
+		result, err := pb.doAnalyzeMarket(ctx, balance, mode)
+		if err != nil {
+			pb.breaker.RecordFailure(err)
+			return err
+		}
+
+		pb.breaker.RecordSuccess()
+		signals = result
+		return nil
+	})
 
-	// Create HTTP request
-	url := fmt.Sprintf("%s/analyze/market", pb.baseURL)
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		reliability.AICallTotal.WithLabelValues(aiCallResultLabel(err)).Inc()
+		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	reliability.AICallTotal.WithLabelValues("success").Inc()
+	return signals, nil
+}
 
-	// Execute request
-	resp, err := pb.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call Python engine: %w", err)
+// aiCallResultLabel classifies err for the neurotrade_ai_call_total counter
+func aiCallResultLabel(err error) string {
+	if errors.Is(err, reliability.ErrCircuitOpen) {
+		return "circuit_open"
 	}
-	defer resp.Body.Close()
+	return "error"
+}
 
-	// Check status code first
-	if resp.StatusCode != http.StatusOK {
-		// Only read body if there's an error to report
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Python engine returned error: status=%d, body=%s", resp.StatusCode, string(body))
+// doAnalyzeMarket makes a single, unretried call to the Python engine,
+// collecting StreamAnalyzeMarket's SSE events into the same
+// []*domain.AISignalResponse the old one-shot /analyze/market endpoint used
+// to return directly, so AnalyzeMarket's callers don't need to change.
+func (pb *PythonBridge) doAnalyzeMarket(ctx context.Context, balance float64, mode string) ([]*domain.AISignalResponse, error) {
+	events, err := pb.StreamAnalyzeMarket(ctx, balance, mode)
+	if err != nil {
+		return nil, err
 	}
 
-	// Decode response directly from stream to save memory
-	var analysisResp MarketAnalysisResponse
-	if err := json.NewDecoder(resp.Body).Decode(&analysisResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	var signals []*domain.AISignalResponse
+	for evt := range events {
+		switch evt.Type {
+		case AnalysisEventSignal:
+			if evt.Signal != nil {
+				signals = append(signals, evt.Signal)
+			}
+		case AnalysisEventError:
+			return nil, fmt.Errorf("Python engine analysis failed: %s", evt.Error)
+		case AnalysisEventDone:
+			metrics.PythonBridgeLastAnalysisSignalCount.Set(float64(len(signals)))
+			return signals, nil
+		}
 	}
 
-	return analysisResp.ValidSignals, nil
+	return nil, fmt.Errorf("Python engine analysis stream ended before a done event")
 }
 
 // HealthCheck checks if the Python engine is healthy
@@ -146,8 +246,48 @@ func (pb *PythonBridge) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
-// GetWebSocketPrices fetches real-time prices from Python's WebSocket cache
+// GetWebSocketPrices returns the latest prices for symbols. When
+// EnablePriceStream has been called, this is a thin read from PriceStream's
+// local cache -- no network round trip -- and flips Connected to false with
+// a [WARN] once any cached price for symbols is older than the stream's
+// staleness threshold. Otherwise it falls back to the legacy behavior of
+// polling Python's /prices REST endpoint directly.
 func (pb *PythonBridge) GetWebSocketPrices(ctx context.Context, symbols []string) (map[string]float64, error) {
+	if pb.priceStream != nil {
+		return pb.getWebSocketPricesFromStream(symbols)
+	}
+	return pb.getWebSocketPricesREST(ctx, symbols)
+}
+
+// getWebSocketPricesFromStream reads cached prices out of pb.priceStream
+// instead of polling Python's /prices REST endpoint.
+func (pb *PythonBridge) getWebSocketPricesFromStream(symbols []string) (map[string]float64, error) {
+	prices := make(map[string]float64, len(symbols))
+	stale := false
+
+	for _, symbol := range symbols {
+		price, at, ok := pb.priceStream.Latest(symbol)
+		if !ok {
+			continue
+		}
+		prices[symbol] = price
+		if time.Since(at) > pb.priceStream.staleAfter {
+			stale = true
+		}
+	}
+
+	if stale {
+		pb.priceStream.setConnected(false)
+		log.Println("[WARN] PriceStream: cached prices are stale")
+	}
+
+	return prices, nil
+}
+
+// getWebSocketPricesREST fetches real-time prices from Python's WebSocket
+// cache over REST -- the original polling implementation, kept as a
+// fallback for when EnablePriceStream hasn't been called.
+func (pb *PythonBridge) getWebSocketPricesREST(ctx context.Context, symbols []string) (map[string]float64, error) {
 	// Construct URL with symbols parameter
 	url := fmt.Sprintf("%s/prices", pb.baseURL)
 	if len(symbols) > 0 {
@@ -177,10 +317,14 @@ func (pb *PythonBridge) GetWebSocketPrices(ctx context.Context, symbols []string
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&pricesResp); err != nil {
+		metrics.RecordDecodeError()
 		return nil, fmt.Errorf("failed to decode prices response: %w", err)
 	}
 
-	if !pricesResp.Connected {
+	if pricesResp.Connected {
+		metrics.PythonBridgeWSConnected.Set(1)
+	} else {
+		metrics.PythonBridgeWSConnected.Set(0)
 		log.Println("[WARN] Warning: Python WebSocket is disconnected")
 	}
 
@@ -244,92 +388,169 @@ func (pb *PythonBridge) SendFeedback(ctx context.Context, feedback *domain.Feedb
 // REAL TRADING EXECUTION (v6.0)
 // ==========================================
 
-// ExecuteEntry executes a real entry order via Python Engine with SL/TP/Trailing
-func (pb *PythonBridge) ExecuteEntry(ctx context.Context, params *domain.EntryParams) (*domain.ExecutionResult, error) {
-	reqBody := map[string]interface{}{
-		"symbol":            params.Symbol,
-		"side":              params.Side,
-		"amount_usdt":       params.AmountUSDT,
-		"leverage":          params.Leverage,
-		"api_key":           params.APIKey,
-		"api_secret":        params.APISecret,
-		"sl_price":          params.SLPrice,
-		"tp_price":          params.TPPrice,
-		"trailing_callback": params.TrailingCallback,
+// isRetryableIdempotentError reports whether err from an idempotent
+// ExecuteEntry/ExecuteClose call is worth retrying: a connection-level
+// failure (no status at all), or a 5xx/408/425 response. Any other 4xx is a
+// business rejection (bad request, insufficient balance) that retrying can't
+// fix, so it's safe to fail fast even though the call already carries an
+// idempotency key.
+func isRetryableIdempotentError(err error) bool {
+	if err == nil {
+		return false
 	}
 
-	jsonData, err := json.Marshal(reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal execute entry request: %w", err)
+	match := executionStatusPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return true
 	}
 
-	url := fmt.Sprintf("%s/execute/entry", pb.baseURL)
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	status, convErr := strconv.Atoi(match[1])
+	if convErr != nil {
+		return true
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	return status >= 500 || status == http.StatusRequestTimeout || status == http.StatusTooEarly
+}
 
-	resp, err := pb.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call Python execution engine: %w", err)
+// executeIdempotent POSTs reqBody (with idempotencyKey merged in as both the
+// X-Idempotency-Key header and an "idempotency_key" field) to endpoint,
+// retrying a transient failure with jittered exponential backoff. Before
+// placing the call it checks idempotencyRepo for a result already persisted
+// under idempotencyKey -- from either a prior successful attempt or a retry
+// after a crash between attempts -- and short-circuits to that instead of
+// risking a duplicate order.
+func (pb *PythonBridge) executeIdempotent(ctx context.Context, endpoint, idempotencyKey string, reqBody map[string]interface{}) (*domain.ExecutionResult, error) {
+	if pb.idempotencyRepo != nil {
+		if cached, err := pb.idempotencyRepo.Get(ctx, idempotencyKey); err != nil {
+			log.Printf("[WARN] PythonBridge: failed to look up idempotency key %s: %v", idempotencyKey, err)
+		} else if cached != nil {
+			return cached, nil
+		}
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Python execution failed: status=%d, body=%s", resp.StatusCode, string(body))
+	reqBody["idempotency_key"] = idempotencyKey
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal execute request: %w", err)
 	}
 
+	url := fmt.Sprintf("%s%s", pb.baseURL, endpoint)
+
 	var result domain.ExecutionResult
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode execution response: %w", err)
+	err = reliability.Do(ctx, idempotentExecutionRetryConfig(), func() error {
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Idempotency-Key", idempotencyKey)
+
+		resp, err := pb.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to call Python execution engine: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("Python execution failed: status=%d, body=%s", resp.StatusCode, string(body))
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			metrics.RecordDecodeError()
+			return fmt.Errorf("failed to decode execution response: %w", err)
+		}
+
+		// Forwarded by the Python Engine when it proxies Binance's
+		// rate-limit usage headers; zero when it doesn't, which
+		// ExecutionGateway treats as "no usage signal yet".
+		result.UsedWeight1m, _ = strconv.Atoi(resp.Header.Get("X-MBX-USED-WEIGHT-1M"))
+		result.OrderCount1m, _ = strconv.Atoi(resp.Header.Get("X-MBX-ORDER-COUNT-1M"))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if pb.idempotencyRepo != nil {
+		if err := pb.idempotencyRepo.Save(ctx, idempotencyKey, &result); err != nil {
+			log.Printf("[WARN] PythonBridge: failed to persist idempotency key %s: %v", idempotencyKey, err)
+		}
 	}
 
 	return &result, nil
 }
 
-// ExecuteClose executes a real close order via Python Engine
-func (pb *PythonBridge) ExecuteClose(ctx context.Context, symbol, side string, quantity float64, apiKey, apiSecret string) (*domain.ExecutionResult, error) {
-	reqBody := map[string]interface{}{
-		"symbol":     symbol,
-		"side":       side,
-		"quantity":   quantity,
-		"api_key":    apiKey,
-		"api_secret": apiSecret,
+// idempotentExecutionRetryConfig bounds ExecuteEntry/ExecuteClose's retry
+// loop: 3 attempts total, jittered exponential backoff from 500ms up to 5s,
+// only for errors isRetryableIdempotentError calls transient.
+func idempotentExecutionRetryConfig() reliability.RetryConfig {
+	return reliability.RetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Retryable:   isRetryableIdempotentError,
 	}
+}
 
-	jsonData, err := json.Marshal(reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal execute close request: %w", err)
+// ExecuteEntry executes a real entry order via Python Engine with
+// SL/TP/Trailing. If params.IdempotencyKey is empty, a UUIDv7 is generated
+// and shared by every retry attempt inside executeIdempotent; a generated
+// key is not known to any later call, so only a caller-supplied key carries
+// across process restarts.
+func (pb *PythonBridge) ExecuteEntry(ctx context.Context, params *domain.EntryParams) (*domain.ExecutionResult, error) {
+	idempotencyKey := params.IdempotencyKey
+	if idempotencyKey == "" {
+		key, err := uuid.NewV7()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate idempotency key: %w", err)
+		}
+		idempotencyKey = key.String()
 	}
 
-	url := fmt.Sprintf("%s/execute/close", pb.baseURL)
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	reqBody := map[string]interface{}{
+		"symbol":            params.Symbol,
+		"side":              params.Side,
+		"amount_usdt":       params.AmountUSDT,
+		"leverage":          params.Leverage,
+		"api_key":           pb.encryptCredential(params.APIKey),
+		"api_secret":        pb.encryptCredential(params.APISecret),
+		"sl_price":          params.SLPrice,
+		"tp_price":          params.TPPrice,
+		"trailing_callback": params.TrailingCallback,
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := pb.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call Python execution engine: %w", err)
-	}
-	defer resp.Body.Close()
+	return pb.executeIdempotent(ctx, "/execute/entry", idempotencyKey, reqBody)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Python execution failed: status=%d, body=%s", resp.StatusCode, string(body))
+// ExecuteClose executes a real close order via Python Engine. PositionSide is
+// domain.PositionSideBoth/Long/Short, forwarded so the Python engine places
+// the order under the correct side and only sets reduce-only in one-way
+// mode. If params.IdempotencyKey is empty, a UUIDv7 is generated and shared
+// by every retry attempt inside executeIdempotent; a generated key is not
+// known to any later call, so only a caller-supplied key carries across
+// process restarts.
+func (pb *PythonBridge) ExecuteClose(ctx context.Context, params *domain.CloseParams) (*domain.ExecutionResult, error) {
+	idempotencyKey := params.IdempotencyKey
+	if idempotencyKey == "" {
+		key, err := uuid.NewV7()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate idempotency key: %w", err)
+		}
+		idempotencyKey = key.String()
 	}
 
-	var result domain.ExecutionResult
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode execution response: %w", err)
+	reqBody := map[string]interface{}{
+		"symbol":        params.Symbol,
+		"side":          params.Side,
+		"position_side": params.PositionSide,
+		"quantity":      params.Quantity,
+		"api_key":       pb.encryptCredential(params.APIKey),
+		"api_secret":    pb.encryptCredential(params.APISecret),
 	}
 
-	return &result, nil
+	return pb.executeIdempotent(ctx, "/execute/close", idempotencyKey, reqBody)
 }
 
 // GetRealBalance fetches real wallet balance from Python Engine
@@ -337,8 +558,8 @@ func (pb *PythonBridge) GetRealBalance(ctx context.Context, apiKey, apiSecret st
 	url := fmt.Sprintf("%s/execute/balance", pb.baseURL)
 
 	reqBody := map[string]interface{}{
-		"api_key":    apiKey,
-		"api_secret": apiSecret,
+		"api_key":    pb.encryptCredential(apiKey),
+		"api_secret": pb.encryptCredential(apiSecret),
 	}
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
@@ -408,6 +629,7 @@ func (pb *PythonBridge) GetAIAnalytics(ctx context.Context) (map[string]interfac
 
 	var result map[string]interface{}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		metrics.RecordDecodeError()
 		return nil, fmt.Errorf("failed to decode analytics response: %w", err)
 	}
 
@@ -424,8 +646,8 @@ func (pb *PythonBridge) HasOpenPosition(ctx context.Context, symbol string, apiK
 
 	reqBody := hasPositionRequest{
 		Symbol:    symbol,
-		APIKey:    apiKey,
-		APISecret: apiSecret,
+		APIKey:    pb.encryptCredential(apiKey),
+		APISecret: pb.encryptCredential(apiSecret),
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
@@ -455,6 +677,7 @@ func (pb *PythonBridge) HasOpenPosition(ctx context.Context, symbol string, apiK
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		metrics.RecordDecodeError()
 		log.Printf("[WARN] HasOpenPosition decode failed: %v", err)
 		return false, nil
 	}
@@ -482,8 +705,8 @@ func (pb *PythonBridge) BatchHasOpenPositions(ctx context.Context, symbols []str
 
 	reqBody := batchRequest{
 		Symbols:   symbols,
-		APIKey:    apiKey,
-		APISecret: apiSecret,
+		APIKey:    pb.encryptCredential(apiKey),
+		APISecret: pb.encryptCredential(apiSecret),
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
@@ -513,6 +736,7 @@ func (pb *PythonBridge) BatchHasOpenPositions(ctx context.Context, symbols []str
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		metrics.RecordDecodeError()
 		log.Printf("[WARN] BatchHasOpenPositions decode failed: %v", err)
 		return nil, fmt.Errorf("failed to decode batch response: %w", err)
 	}
@@ -528,3 +752,62 @@ func (pb *PythonBridge) BatchHasOpenPositions(ctx context.Context, symbols []str
 
 	return positions, nil
 }
+
+// WalletHistoryEntry is a single withdrawal or deposit record as returned by
+// the Python Engine's Binance wallet history endpoint
+type WalletHistoryEntry struct {
+	Asset          string  `json:"asset"`
+	Address        string  `json:"address"`
+	Network        string  `json:"network"`
+	Amount         float64 `json:"amount"`
+	TxnID          string  `json:"txn_id"`
+	TxnFee         float64 `json:"txn_fee"`
+	TxnFeeCurrency string  `json:"txn_fee_currency"`
+	Time           int64   `json:"time"` // Unix millis, matching Binance's wallet history response
+}
+
+// GetWalletHistory fetches withdrawal and deposit history for a user's
+// Binance account, for the ledger sync worker to upsert into the local DB
+func (pb *PythonBridge) GetWalletHistory(ctx context.Context, apiKey, apiSecret string) (withdrawals, deposits []WalletHistoryEntry, err error) {
+	url := fmt.Sprintf("%s/execute/wallet/history", pb.baseURL)
+
+	reqBody := map[string]interface{}{
+		"api_key":    pb.encryptCredential(apiKey),
+		"api_secret": pb.encryptCredential(apiSecret),
+	}
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal wallet history request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := pb.httpClient.Do(req)
+	if err != nil {
+		log.Printf("[PythonBridge] Failed to fetch wallet history: %v", err)
+		return nil, nil, fmt.Errorf("failed to fetch wallet history: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		log.Printf("[PythonBridge] Wallet history fetch failed: status=%d, body=%s", resp.StatusCode, string(body))
+		return nil, nil, fmt.Errorf("failed to fetch wallet history: status=%d, body=%s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Withdrawals []WalletHistoryEntry `json:"withdrawals"`
+		Deposits    []WalletHistoryEntry `json:"deposits"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		metrics.RecordDecodeError()
+		return nil, nil, fmt.Errorf("failed to decode wallet history response: %w", err)
+	}
+
+	return result.Withdrawals, result.Deposits, nil
+}
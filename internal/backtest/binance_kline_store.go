@@ -0,0 +1,151 @@
+package backtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"neurotrade/internal/domain"
+)
+
+// binanceKlinesBaseURL is Binance's public market-data endpoint -- no API
+// key required, unlike the signed trading endpoints PythonBridge talks to.
+const binanceKlinesBaseURL = "https://api.binance.com"
+
+// binanceKlineLimit is the max rows Binance returns per /klines call.
+const binanceKlineLimit = 1000
+
+// BinanceKlineStore loads historical klines directly from Binance's public
+// REST API, for a backtest run that doesn't have a pre-downloaded CSV on
+// disk. Paginates in binanceKlineLimit-row pages since Binance caps a
+// single response regardless of the requested range.
+type BinanceKlineStore struct {
+	httpClient *http.Client
+	interval   string
+}
+
+// NewBinanceKlineStore creates a BinanceKlineStore fetching candles at
+// interval (e.g. "1m", "5m", matching Binance's kline interval strings).
+func NewBinanceKlineStore(interval string) *BinanceKlineStore {
+	return &BinanceKlineStore{
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		interval:   interval,
+	}
+}
+
+// binanceKline mirrors one row of Binance's /api/v3/klines array-of-arrays
+// response: [openTime, open, high, low, close, volume, closeTime, ...]
+type binanceKline [12]interface{}
+
+// LoadKlines implements KlineStore
+func (s *BinanceKlineStore) LoadKlines(symbol string, start, end time.Time) ([]domain.Kline, error) {
+	var klines []domain.Kline
+
+	cursor := start
+	for cursor.Before(end) {
+		page, err := s.fetchPage(symbol, cursor, end)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		klines = append(klines, page...)
+
+		lastOpen := page[len(page)-1].OpenTime
+		if !lastOpen.After(cursor) {
+			break // Binance returned no forward progress; avoid looping forever
+		}
+		cursor = lastOpen.Add(time.Millisecond)
+
+		if len(page) < binanceKlineLimit {
+			break // short page means we've reached the end of what Binance has
+		}
+	}
+
+	return klines, nil
+}
+
+// fetchPage fetches a single page of up to binanceKlineLimit klines starting
+// at start
+func (s *BinanceKlineStore) fetchPage(symbol string, start, end time.Time) ([]domain.Kline, error) {
+	url := fmt.Sprintf("%s/api/v3/klines?symbol=%s&interval=%s&startTime=%d&endTime=%d&limit=%d",
+		binanceKlinesBaseURL, symbol, s.interval, start.UnixMilli(), end.UnixMilli(), binanceKlineLimit)
+
+	resp, err := s.httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch klines for %s: %w", symbol, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("binance klines returned status=%d for %s", resp.StatusCode, symbol)
+	}
+
+	var rows []binanceKline
+	if err := json.NewDecoder(resp.Body).Decode(&rows); err != nil {
+		return nil, fmt.Errorf("failed to decode klines for %s: %w", symbol, err)
+	}
+
+	klines := make([]domain.Kline, 0, len(rows))
+	for _, row := range rows {
+		k, err := decodeBinanceKline(row)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse kline row for %s: %w", symbol, err)
+		}
+		klines = append(klines, k)
+	}
+
+	return klines, nil
+}
+
+// decodeBinanceKline converts one raw /klines row into a domain.Kline
+func decodeBinanceKline(row binanceKline) (domain.Kline, error) {
+	openTimeMs, ok := row[0].(float64)
+	if !ok {
+		return domain.Kline{}, fmt.Errorf("unexpected open_time type %T", row[0])
+	}
+
+	open, err := parseBinanceFloat(row[1])
+	if err != nil {
+		return domain.Kline{}, fmt.Errorf("open: %w", err)
+	}
+	high, err := parseBinanceFloat(row[2])
+	if err != nil {
+		return domain.Kline{}, fmt.Errorf("high: %w", err)
+	}
+	low, err := parseBinanceFloat(row[3])
+	if err != nil {
+		return domain.Kline{}, fmt.Errorf("low: %w", err)
+	}
+	closePrice, err := parseBinanceFloat(row[4])
+	if err != nil {
+		return domain.Kline{}, fmt.Errorf("close: %w", err)
+	}
+	quoteVolume, err := parseBinanceFloat(row[7])
+	if err != nil {
+		return domain.Kline{}, fmt.Errorf("quote_volume: %w", err)
+	}
+
+	return domain.Kline{
+		OpenTime:    time.UnixMilli(int64(openTimeMs)).UTC(),
+		Open:        open,
+		High:        high,
+		Low:         low,
+		Close:       closePrice,
+		QuoteVolume: quoteVolume,
+	}, nil
+}
+
+// parseBinanceFloat parses one of the string-encoded numeric fields Binance
+// returns alongside the /klines response's few genuinely numeric ones
+func parseBinanceFloat(v interface{}) (float64, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected type %T", v)
+	}
+	return strconv.ParseFloat(s, 64)
+}
@@ -0,0 +1,46 @@
+// Package backtest replays historical klines through the same
+// domain.Position state machine (CheckSLTP / CheckTrailing) used by
+// VirtualBrokerService in live PAPER mode, against an in-memory
+// PositionRepository, so no code changes to domain.Position are needed to
+// backtest a strategy.
+package backtest
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the YAML-driven configuration for a backtest run
+type Config struct {
+	StartTime    time.Time          `yaml:"startTime"`
+	EndTime      time.Time          `yaml:"endTime"`
+	Symbols      []string           `yaml:"symbols"`
+	Balances     map[string]float64 `yaml:"balances"` // starting balance per account/user
+	MakerFeeRate float64            `yaml:"makerFeeRate"`
+	TakerFeeRate float64            `yaml:"takerFeeRate"`
+}
+
+// LoadConfig reads and validates a backtest Config from a YAML file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backtest config: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse backtest config: %w", err)
+	}
+
+	if cfg.EndTime.Before(cfg.StartTime) {
+		return nil, fmt.Errorf("endTime (%s) is before startTime (%s)", cfg.EndTime, cfg.StartTime)
+	}
+	if len(cfg.Symbols) == 0 {
+		return nil, fmt.Errorf("at least one symbol is required")
+	}
+
+	return &cfg, nil
+}
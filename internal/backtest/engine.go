@@ -0,0 +1,206 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"neurotrade/internal/domain"
+)
+
+// OpenRequest is a new position to open on a given bar, as decided by a
+// SignalSource
+type OpenRequest struct {
+	Account    string
+	SignalID   *uuid.UUID
+	Symbol     string
+	Side       string
+	EntryPrice float64
+	SLPrice    float64
+	TPPrice    float64
+	Size       float64
+	Leverage   float64
+}
+
+// SignalSource decides whether to open new positions on a bar. It stands in
+// for the live signal -> position pipeline (e.g. a replayed AIService),
+// letting the engine stay agnostic to strategy logic.
+type SignalSource func(symbol string, bar domain.Kline, openPositions []*domain.Position) []OpenRequest
+
+// bar pairs a Kline with the symbol it belongs to, for a merged, chronological
+// multi-symbol timeline
+type bar struct {
+	symbol string
+	kline  domain.Kline
+}
+
+// Engine replays historical klines through domain.Position's own
+// CheckSLTP/CheckTrailing against an InMemoryPositionRepository
+type Engine struct {
+	cfg          *Config
+	store        KlineStore
+	signalSource SignalSource
+	repo         *InMemoryPositionRepository
+	accountIDs   map[string]uuid.UUID
+}
+
+// accountNamespace is a fixed UUID namespace so account name -> UserID
+// mapping is reproducible across runs, keeping the engine deterministic.
+var accountNamespace = uuid.MustParse("6f6d8d1e-8b61-4b0a-9f8d-1f6b1d9f5b2a")
+
+// NewEngine creates a backtest Engine
+func NewEngine(cfg *Config, store KlineStore, signalSource SignalSource) *Engine {
+	accountIDs := make(map[string]uuid.UUID, len(cfg.Balances))
+	for account := range cfg.Balances {
+		accountIDs[account] = uuid.NewSHA1(accountNamespace, []byte(account))
+	}
+
+	return &Engine{
+		cfg:          cfg,
+		store:        store,
+		signalSource: signalSource,
+		repo:         NewInMemoryPositionRepository(),
+		accountIDs:   accountIDs,
+	}
+}
+
+// Run replays every symbol's klines within the configured time range,
+// chronologically merged, and returns the resulting Report
+func (e *Engine) Run(ctx context.Context) (*Report, error) {
+	timeline, err := e.loadTimeline()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range timeline {
+		if err := e.tick(ctx, b); err != nil {
+			return nil, fmt.Errorf("tick failed for %s @ %s: %w", b.symbol, b.kline.OpenTime, err)
+		}
+	}
+
+	return e.buildReport(ctx)
+}
+
+// loadTimeline loads each configured symbol's klines and merges them into a
+// single chronological timeline
+func (e *Engine) loadTimeline() ([]bar, error) {
+	var timeline []bar
+	for _, symbol := range e.cfg.Symbols {
+		klines, err := e.store.LoadKlines(symbol, e.cfg.StartTime, e.cfg.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load klines for %s: %w", symbol, err)
+		}
+		for _, k := range klines {
+			timeline = append(timeline, bar{symbol: symbol, kline: k})
+		}
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].kline.OpenTime.Before(timeline[j].kline.OpenTime)
+	})
+
+	return timeline, nil
+}
+
+// tick processes one bar: checks SL/TP and trailing for open positions in
+// that symbol (SL before TP, conservative fill within the bar), then asks
+// the SignalSource whether to open anything new
+func (e *Engine) tick(ctx context.Context, b bar) error {
+	open, err := e.repo.GetOpenPositions(ctx)
+	if err != nil {
+		return err
+	}
+
+	var symbolOpen []*domain.Position
+	for _, p := range open {
+		if p.Symbol != b.symbol {
+			continue
+		}
+		symbolOpen = append(symbolOpen, p)
+
+		if closed, err := e.checkBar(ctx, p, b.kline); err != nil {
+			return err
+		} else if closed {
+			continue
+		}
+	}
+
+	for _, req := range e.signalSource(b.symbol, b.kline, symbolOpen) {
+		if err := e.open(ctx, req, b.kline.OpenTime); err != nil {
+			return fmt.Errorf("failed to open position for %s: %w", req.Symbol, err)
+		}
+	}
+
+	return nil
+}
+
+// checkBar applies the standard "SL checked before TP within a bar,
+// conservative fill" rule: SL is tested against Low (LONG) / High (SHORT)
+// first, then TP against the opposite extreme, before falling back to
+// CheckTrailing against the bar's close.
+func (e *Engine) checkBar(ctx context.Context, p *domain.Position, k domain.Kline) (bool, error) {
+	slExtreme, tpExtreme := k.Low, k.High
+	if !p.IsLong() {
+		slExtreme, tpExtreme = k.High, k.Low
+	}
+
+	if shouldClose, status, closedBy := p.CheckSLTP(slExtreme); shouldClose && closedBy != domain.ClosedByTP {
+		return true, e.close(ctx, p, p.SLPrice, status, closedBy, k.OpenTime)
+	}
+	if shouldClose, status, closedBy := p.CheckSLTP(tpExtreme); shouldClose && closedBy == domain.ClosedByTP {
+		return true, e.close(ctx, p, p.TPPrice, status, closedBy, k.OpenTime)
+	}
+
+	if shouldClose, closedBy := p.CheckTrailing(k.Close); shouldClose {
+		return true, e.close(ctx, p, k.Close, domain.StatusClosedWin, closedBy, k.OpenTime)
+	}
+
+	return false, nil
+}
+
+// close realizes PnL net of the configured taker fee and writes the closed
+// position back to the repository
+func (e *Engine) close(ctx context.Context, p *domain.Position, exitPrice float64, status, closedBy string, closedAt time.Time) error {
+	grossPnL := p.CalculateGrossPnL(exitPrice)
+	entryFee := p.Size * p.EntryPrice * e.cfg.TakerFeeRate
+	exitFee := p.Size * exitPrice * e.cfg.TakerFeeRate
+	netPnL := grossPnL - entryFee - exitFee
+	pnlPercent := p.CalculatePnLPercent(exitPrice)
+
+	p.ExitPrice = &exitPrice
+	p.PnL = &netPnL
+	p.PnLPercent = &pnlPercent
+	p.ClosedBy = &closedBy
+	p.Status = status
+	p.ClosedAt = &closedAt
+
+	return e.repo.Update(ctx, p)
+}
+
+// open saves a new position for the given OpenRequest
+func (e *Engine) open(ctx context.Context, req OpenRequest, openedAt time.Time) error {
+	userID, ok := e.accountIDs[req.Account]
+	if !ok {
+		return fmt.Errorf("unknown account %q (not in config balances)", req.Account)
+	}
+
+	position := &domain.Position{
+		ID:         uuid.NewSHA1(accountNamespace, []byte(fmt.Sprintf("%s-%s-%s", req.Account, req.Symbol, openedAt))),
+		UserID:     userID,
+		SignalID:   req.SignalID,
+		Symbol:     req.Symbol,
+		Side:       req.Side,
+		EntryPrice: req.EntryPrice,
+		SLPrice:    req.SLPrice,
+		TPPrice:    req.TPPrice,
+		Size:       req.Size,
+		Leverage:   req.Leverage,
+		Status:     domain.StatusOpen,
+		CreatedAt:  openedAt,
+	}
+
+	return e.repo.Save(ctx, position)
+}
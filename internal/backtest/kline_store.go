@@ -0,0 +1,90 @@
+package backtest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"neurotrade/internal/domain"
+)
+
+// KlineStore loads historical 1m klines for a symbol within a time range.
+// Storage is an implementation detail — CSVKlineStore is the lightweight
+// default; a sqlite- or parquet-backed store can implement the same
+// interface without touching the engine.
+type KlineStore interface {
+	LoadKlines(symbol string, start, end time.Time) ([]domain.Kline, error)
+}
+
+// CSVKlineStore loads klines from one CSV file per symbol, named
+// "<dir>/<symbol>.csv" with columns open_time(unix seconds),open,high,low,close.
+type CSVKlineStore struct {
+	dir string
+}
+
+// NewCSVKlineStore creates a CSVKlineStore rooted at dir
+func NewCSVKlineStore(dir string) *CSVKlineStore {
+	return &CSVKlineStore{dir: dir}
+}
+
+// LoadKlines implements KlineStore
+func (s *CSVKlineStore) LoadKlines(symbol string, start, end time.Time) ([]domain.Kline, error) {
+	path := fmt.Sprintf("%s/%s.csv", s.dir, symbol)
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open kline file for %s: %w", symbol, err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kline file for %s: %w", symbol, err)
+	}
+
+	klines := make([]domain.Kline, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 5 {
+			continue
+		}
+
+		unixSeconds, err := strconv.ParseInt(row[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse open_time %q: %w", row[0], err)
+		}
+		openTime := time.Unix(unixSeconds, 0).UTC()
+		if openTime.Before(start) || openTime.After(end) {
+			continue
+		}
+
+		open, err := strconv.ParseFloat(row[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse open %q: %w", row[1], err)
+		}
+		high, err := strconv.ParseFloat(row[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse high %q: %w", row[2], err)
+		}
+		low, err := strconv.ParseFloat(row[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse low %q: %w", row[3], err)
+		}
+		close, err := strconv.ParseFloat(row[4], 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse close %q: %w", row[4], err)
+		}
+
+		klines = append(klines, domain.Kline{
+			OpenTime: openTime,
+			Open:     open,
+			High:     high,
+			Low:      low,
+			Close:    close,
+		})
+	}
+
+	return klines, nil
+}
@@ -0,0 +1,144 @@
+package backtest
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+
+	"neurotrade/internal/domain"
+)
+
+// Report summarizes a completed backtest run
+type Report struct {
+	TotalTrades  int
+	WinRate      float64
+	MaxDrawdown  float64
+	Sharpe       float64
+	PnLBySymbol  map[string]float64
+	ClosedTrades []*domain.Position
+}
+
+// buildReport aggregates every closed position across every configured
+// account into a single Report
+func (e *Engine) buildReport(ctx context.Context) (*Report, error) {
+	var closed []*domain.Position
+	for _, userID := range e.accountIDs {
+		trades, err := e.repo.GetClosedPositions(ctx, userID, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get closed positions: %w", err)
+		}
+		closed = append(closed, trades...)
+	}
+
+	sort.Slice(closed, func(i, j int) bool {
+		if closed[i].ClosedAt == nil || closed[j].ClosedAt == nil {
+			return false
+		}
+		return closed[i].ClosedAt.Before(*closed[j].ClosedAt)
+	})
+
+	report := &Report{
+		PnLBySymbol:  make(map[string]float64),
+		ClosedTrades: closed,
+	}
+
+	var wins int
+	var returns []float64
+	var equity, peak, maxDrawdown float64
+
+	for _, p := range closed {
+		if p.PnL == nil {
+			continue
+		}
+		report.TotalTrades++
+		report.PnLBySymbol[p.Symbol] += *p.PnL
+		if *p.PnL > 0 {
+			wins++
+		}
+		if p.PnLPercent != nil {
+			returns = append(returns, *p.PnLPercent)
+		}
+
+		equity += *p.PnL
+		if equity > peak {
+			peak = equity
+		}
+		if drawdown := peak - equity; drawdown > maxDrawdown {
+			maxDrawdown = drawdown
+		}
+	}
+
+	if report.TotalTrades > 0 {
+		report.WinRate = float64(wins) / float64(report.TotalTrades)
+	}
+	report.MaxDrawdown = maxDrawdown
+	report.Sharpe = sharpeRatio(returns)
+
+	return report, nil
+}
+
+// sharpeRatio computes mean(returns)/stddev(returns), 0 if there's fewer
+// than 2 returns or no variance
+func sharpeRatio(returns []float64) float64 {
+	if len(returns) < 2 {
+		return 0
+	}
+
+	var mean float64
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+
+	var variance float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	variance /= float64(len(returns) - 1)
+
+	stddev := math.Sqrt(variance)
+	if stddev == 0 {
+		return 0
+	}
+
+	return mean / stddev
+}
+
+// WriteCSV writes the closed trades to path in a column layout compatible
+// with domain.PnLHistoryEntry (GetClosedPositionsHistory): closed_at, pnl,
+// plus symbol/side/status for context.
+func (r *Report) WriteCSV(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report CSV: %w", err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	if err := w.Write([]string{"closed_at", "symbol", "side", "status", "pnl"}); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, p := range r.ClosedTrades {
+		if p.PnL == nil || p.ClosedAt == nil {
+			continue
+		}
+		row := []string{
+			p.ClosedAt.Format("2006-01-02T15:04:05Z07:00"),
+			p.Symbol,
+			p.Side,
+			p.Status,
+			fmt.Sprintf("%.8f", *p.PnL),
+		}
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,292 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"neurotrade/internal/domain"
+)
+
+// InMemoryPositionRepository is a domain.PositionRepository backed by a
+// plain map instead of Postgres, so the backtest engine can drive the exact
+// same Position state machine used in live PAPER mode without a database.
+type InMemoryPositionRepository struct {
+	mu        sync.Mutex
+	positions map[uuid.UUID]*domain.Position
+}
+
+// NewInMemoryPositionRepository creates an empty InMemoryPositionRepository
+func NewInMemoryPositionRepository() *InMemoryPositionRepository {
+	return &InMemoryPositionRepository{
+		positions: make(map[uuid.UUID]*domain.Position),
+	}
+}
+
+// Save creates a new position
+func (r *InMemoryPositionRepository) Save(ctx context.Context, position *domain.Position) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *position
+	r.positions[position.ID] = &cp
+	return nil
+}
+
+// GetByUserID retrieves all positions for a user, newest first
+func (r *InMemoryPositionRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Position, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []*domain.Position
+	for _, p := range r.positions {
+		if p.UserID == userID {
+			cp := *p
+			out = append(out, &cp)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
+
+// GetOpenPositions retrieves all open positions across all users
+func (r *InMemoryPositionRepository) GetOpenPositions(ctx context.Context) ([]*domain.Position, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []*domain.Position
+	for _, p := range r.positions {
+		if p.Status == domain.StatusOpen {
+			cp := *p
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+// Update updates position status, exit price, and PnL
+func (r *InMemoryPositionRepository) Update(ctx context.Context, position *domain.Position) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.positions[position.ID]; !ok {
+		return fmt.Errorf("position %s not found", position.ID)
+	}
+	cp := *position
+	r.positions[position.ID] = &cp
+	return nil
+}
+
+// GetByID retrieves a position by ID
+func (r *InMemoryPositionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Position, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.positions[id]
+	if !ok {
+		return nil, fmt.Errorf("position %s not found", id)
+	}
+	cp := *p
+	return &cp, nil
+}
+
+// GetTodayRealizedPnL retrieves the realized PnL for positions closed on or
+// after startOfDay
+func (r *InMemoryPositionRepository) GetTodayRealizedPnL(ctx context.Context, userID uuid.UUID, startOfDay time.Time) (float64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var total float64
+	for _, p := range r.positions {
+		if p.UserID != userID || p.PnL == nil || p.ClosedAt == nil {
+			continue
+		}
+		if p.ClosedAt.Before(startOfDay) {
+			continue
+		}
+		total += *p.PnL
+	}
+	return total, nil
+}
+
+// GetPnLBySignalIDs retrieves metrics for a list of signal IDs
+func (r *InMemoryPositionRepository) GetPnLBySignalIDs(ctx context.Context, signalIDs []uuid.UUID) (map[uuid.UUID]domain.MetricResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wanted := make(map[uuid.UUID]bool, len(signalIDs))
+	for _, id := range signalIDs {
+		wanted[id] = true
+	}
+
+	out := make(map[uuid.UUID]domain.MetricResult)
+	for _, p := range r.positions {
+		if p.SignalID == nil || !wanted[*p.SignalID] || p.PnL == nil || p.PnLPercent == nil {
+			continue
+		}
+		out[*p.SignalID] = domain.MetricResult{PnL: *p.PnL, PnLPercent: *p.PnLPercent}
+	}
+	return out, nil
+}
+
+// GetClosedPositionsHistory retrieves closed positions for chart data
+func (r *InMemoryPositionRepository) GetClosedPositionsHistory(ctx context.Context, userID uuid.UUID, limit int) ([]domain.PnLHistoryEntry, error) {
+	return r.GetClosedPositionsHistorySince(ctx, userID, time.Time{}, limit)
+}
+
+// GetClosedPositionsHistorySince retrieves closed positions since a specific time
+func (r *InMemoryPositionRepository) GetClosedPositionsHistorySince(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]domain.PnLHistoryEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var entries []domain.PnLHistoryEntry
+	for _, p := range r.positions {
+		if p.UserID != userID || p.PnL == nil || p.ClosedAt == nil {
+			continue
+		}
+		if p.ClosedAt.Before(since) {
+			continue
+		}
+		entries = append(entries, domain.PnLHistoryEntry{ClosedAt: *p.ClosedAt, PnL: *p.PnL})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ClosedAt.Before(entries[j].ClosedAt) })
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries, nil
+}
+
+// GetClosedPositions retrieves detailed closed positions, most recent first
+func (r *InMemoryPositionRepository) GetClosedPositions(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.Position, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []*domain.Position
+	for _, p := range r.positions {
+		if p.UserID != userID || p.Status == domain.StatusOpen {
+			continue
+		}
+		cp := *p
+		out = append(out, &cp)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].ClosedAt == nil || out[j].ClosedAt == nil {
+			return false
+		}
+		return out[i].ClosedAt.After(*out[j].ClosedAt)
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// UpdateTrailingState persists the ladder's peak price, active step, and SL
+func (r *InMemoryPositionRepository) UpdateTrailingState(ctx context.Context, positionID uuid.UUID, peak float64, step int, newSL float64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.positions[positionID]
+	if !ok {
+		return fmt.Errorf("position %s not found", positionID)
+	}
+	p.PeakPrice = &peak
+	p.ActiveTrailingStep = step
+	p.SLPrice = newSL
+	return nil
+}
+
+// GetPositionsWithTrailing retrieves all open positions with a trailing
+// ladder configured
+func (r *InMemoryPositionRepository) GetPositionsWithTrailing(ctx context.Context) ([]*domain.Position, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []*domain.Position
+	for _, p := range r.positions {
+		if p.Status == domain.StatusOpen && p.TrailingConfig != nil {
+			cp := *p
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+// UpdateTrailingTierState persists the flat ladder's high/low water price
+// and active tier
+func (r *InMemoryPositionRepository) UpdateTrailingTierState(ctx context.Context, positionID uuid.UUID, highWater, lowWater *float64, tier int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.positions[positionID]
+	if !ok {
+		return fmt.Errorf("position %s not found", positionID)
+	}
+	p.HighWaterPrice = highWater
+	p.LowWaterPrice = lowWater
+	p.ActiveTrailingTier = tier
+	return nil
+}
+
+// GetPositionsWithTrailingTier retrieves all open positions with a flat
+// activation-ratio/callback-rate trailing ladder configured
+func (r *InMemoryPositionRepository) GetPositionsWithTrailingTier(ctx context.Context) ([]*domain.Position, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []*domain.Position
+	for _, p := range r.positions {
+		if p.Status == domain.StatusOpen && len(p.TrailingActivationRatios) > 0 {
+			cp := *p
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+// GetByParentSignalID retrieves every scaled-entry layer for a signal,
+// ordered by LayerIndex
+func (r *InMemoryPositionRepository) GetByParentSignalID(ctx context.Context, parentSignalID uuid.UUID) ([]*domain.Position, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []*domain.Position
+	for _, p := range r.positions {
+		if p.ParentSignalID != nil && *p.ParentSignalID == parentSignalID {
+			cp := *p
+			out = append(out, &cp)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].LayerIndex < out[j].LayerIndex })
+	return out, nil
+}
+
+// UpdateBatch updates multiple positions
+func (r *InMemoryPositionRepository) UpdateBatch(ctx context.Context, positions []*domain.Position) error {
+	for _, p := range positions {
+		if err := r.Update(ctx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PartialClose reduces an open position's Size by sizeDelta
+func (r *InMemoryPositionRepository) PartialClose(ctx context.Context, id uuid.UUID, sizeDelta float64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.positions[id]
+	if !ok {
+		return fmt.Errorf("position %s not found", id)
+	}
+	p.Size -= sizeDelta
+	if p.Size < 0 {
+		p.Size = 0
+	}
+	return nil
+}
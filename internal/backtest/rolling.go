@@ -0,0 +1,106 @@
+package backtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"neurotrade/internal/service"
+)
+
+// RollingParams configures a BACKTEST_ROLLING scheduled job: replay the
+// trailing LookbackHours of Symbols against the reference
+// SMACrossoverSignalSource and persist the result under PresetID, so an
+// operator can watch a preset's rolling performance without manually
+// triggering TriggerBacktest.
+type RollingParams struct {
+	PresetID        int      `json:"preset_id"`
+	Symbols         []string `json:"symbols"`
+	LookbackHours   int      `json:"lookback_hours"`
+	StartingBalance float64  `json:"starting_balance"`
+}
+
+// RollingRunner runs a BACKTEST_ROLLING scheduled job against live Binance
+// klines, persisting each run via RunStore exactly like an admin-triggered
+// TriggerBacktest -- the two share the same risk model and SignalSource
+// construction so a rolling run and a manually-triggered one over the same
+// window produce the same trades and metrics. Only the account name differs
+// ("backtest-rolling" here, "backtest" in TriggerBacktest).
+type RollingRunner struct {
+	runStore     *RunStore
+	binanceStore *BinanceKlineStore
+}
+
+// NewRollingRunner creates a RollingRunner
+func NewRollingRunner(runStore *RunStore) *RollingRunner {
+	return &RollingRunner{
+		runStore:     runStore,
+		binanceStore: NewBinanceKlineStore("1m"),
+	}
+}
+
+// RunRolling parses paramsJSON as RollingParams and runs the replay
+// synchronously, persisting a Run row regardless of outcome. Satisfies
+// infra.RollingBacktester.
+func (r *RollingRunner) RunRolling(ctx context.Context, paramsJSON []byte) error {
+	var params RollingParams
+	if err := json.Unmarshal(paramsJSON, &params); err != nil {
+		return fmt.Errorf("failed to parse rolling backtest params: %w", err)
+	}
+
+	if params.PresetID <= 0 {
+		return fmt.Errorf("rolling backtest params: preset_id is required")
+	}
+	if len(params.Symbols) == 0 {
+		return fmt.Errorf("rolling backtest params: at least one symbol is required")
+	}
+	if params.LookbackHours <= 0 {
+		return fmt.Errorf("rolling backtest params: lookback_hours must be positive")
+	}
+	if params.StartingBalance <= 0 {
+		return fmt.Errorf("rolling backtest params: starting_balance must be positive")
+	}
+
+	end := time.Now().UTC()
+	start := end.Add(-time.Duration(params.LookbackHours) * time.Hour)
+
+	const account = "backtest-rolling"
+	cfg := &Config{
+		StartTime:    start,
+		EndTime:      end,
+		Symbols:      params.Symbols,
+		Balances:     map[string]float64{account: params.StartingBalance},
+		MakerFeeRate: 0.0002,
+		TakerFeeRate: 0.0005,
+	}
+
+	run := &Run{
+		ID:              uuid.New(),
+		PresetID:        params.PresetID,
+		Symbols:         params.Symbols,
+		StartTime:       start,
+		EndTime:         end,
+		StartingBalance: params.StartingBalance,
+		Status:          RunStatusRunning,
+		CreatedAt:       time.Now(),
+	}
+	if err := r.runStore.Create(ctx, run); err != nil {
+		return fmt.Errorf("failed to create rolling backtest run: %w", err)
+	}
+
+	riskModel := service.NewATRRiskModel("15m", 14, 1.5, 3.0, 0.002)
+	signalSource := SMACrossoverSignalSource(account, 10, 30, 0.02, 1.0, params.StartingBalance, riskModel)
+
+	report, err := NewEngine(cfg, r.binanceStore, signalSource).Run(ctx)
+	if err != nil {
+		if failErr := r.runStore.Fail(ctx, run.ID, err); failErr != nil {
+			return fmt.Errorf("rolling backtest failed (%w) and failed to persist failure: %v", err, failErr)
+		}
+		return fmt.Errorf("rolling backtest failed: %w", err)
+	}
+
+	return r.runStore.Complete(ctx, run.ID, report)
+}
@@ -0,0 +1,234 @@
+package backtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RunStatus is the lifecycle state of a persisted Run
+type RunStatus string
+
+const (
+	RunStatusRunning   RunStatus = "RUNNING"
+	RunStatusCompleted RunStatus = "COMPLETED"
+	RunStatusFailed    RunStatus = "FAILED"
+)
+
+// EquityPoint is one closed trade's cumulative realized PnL, charted as the
+// equity curve for a Run. Distinct from domain.EquityPoint, which snapshots
+// a live user's balance rather than a backtest's simulated one.
+type EquityPoint struct {
+	At     time.Time `json:"at"`
+	Equity float64   `json:"equity"`
+}
+
+// Run is one admin-triggered or scheduled (BACKTEST_ROLLING) backtest,
+// persisted to the
+// backtest_runs table so results survive past the process that produced
+// them and can be compared across strategy presets.
+type Run struct {
+	ID              uuid.UUID
+	PresetID        int
+	Symbols         []string
+	StartTime       time.Time
+	EndTime         time.Time
+	StartingBalance float64
+	Status          RunStatus
+	TotalTrades     int
+	WinRate         float64
+	MaxDrawdown     float64
+	Sharpe          float64
+	EquityCurve     []EquityPoint
+	TradeLog        []*TradeLogEntry
+	Error           string
+	CreatedAt       time.Time
+	CompletedAt     *time.Time
+}
+
+// TradeLogEntry is one closed trade in a Run's per-trade log
+type TradeLogEntry struct {
+	Symbol   string    `json:"symbol"`
+	Side     string    `json:"side"`
+	Status   string    `json:"status"`
+	ClosedBy string    `json:"closed_by"`
+	PnL      float64   `json:"pnl"`
+	ClosedAt time.Time `json:"closed_at"`
+}
+
+// EquityCurve builds the cumulative-PnL equity curve for r's closed trades,
+// in the same chronological order buildReport already sorted them into.
+func (r *Report) EquityCurve() []EquityPoint {
+	points := make([]EquityPoint, 0, len(r.ClosedTrades))
+	var equity float64
+	for _, p := range r.ClosedTrades {
+		if p.PnL == nil || p.ClosedAt == nil {
+			continue
+		}
+		equity += *p.PnL
+		points = append(points, EquityPoint{At: *p.ClosedAt, Equity: equity})
+	}
+	return points
+}
+
+// TradeLog builds r's per-trade log in the same order as EquityCurve
+func (r *Report) TradeLog() []*TradeLogEntry {
+	entries := make([]*TradeLogEntry, 0, len(r.ClosedTrades))
+	for _, p := range r.ClosedTrades {
+		if p.PnL == nil || p.ClosedAt == nil {
+			continue
+		}
+		closedBy := ""
+		if p.ClosedBy != nil {
+			closedBy = *p.ClosedBy
+		}
+		entries = append(entries, &TradeLogEntry{
+			Symbol:   p.Symbol,
+			Side:     p.Side,
+			Status:   p.Status,
+			ClosedBy: closedBy,
+			PnL:      *p.PnL,
+			ClosedAt: *p.ClosedAt,
+		})
+	}
+	return entries
+}
+
+// RunStore persists Runs, queried directly against Postgres the same way
+// AdminHandler reaches for strategy_presets -- a backtest run isn't part of
+// the live trading domain model, so it doesn't need a domain.Repository
+// interface of its own.
+type RunStore struct {
+	db *pgxpool.Pool
+}
+
+// NewRunStore creates a RunStore
+func NewRunStore(db *pgxpool.Pool) *RunStore {
+	return &RunStore{db: db}
+}
+
+// Create inserts a new Run row, normally in RunStatusRunning, so its ID is
+// visible to callers before the (possibly long) replay finishes
+func (s *RunStore) Create(ctx context.Context, run *Run) error {
+	symbolsJSON, err := json.Marshal(run.Symbols)
+	if err != nil {
+		return fmt.Errorf("failed to marshal symbols: %w", err)
+	}
+
+	query := `
+		INSERT INTO backtest_runs (
+			id, preset_id, symbols, start_time, end_time, starting_balance,
+			status, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err = s.db.Exec(ctx, query,
+		run.ID, run.PresetID, symbolsJSON, run.StartTime, run.EndTime,
+		run.StartingBalance, run.Status, run.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create backtest run: %w", err)
+	}
+
+	return nil
+}
+
+// Complete records a finished Run's report, transitioning it to
+// RunStatusCompleted
+func (s *RunStore) Complete(ctx context.Context, runID uuid.UUID, report *Report) error {
+	equityCurveJSON, err := json.Marshal(report.EquityCurve())
+	if err != nil {
+		return fmt.Errorf("failed to marshal equity curve: %w", err)
+	}
+	tradeLogJSON, err := json.Marshal(report.TradeLog())
+	if err != nil {
+		return fmt.Errorf("failed to marshal trade log: %w", err)
+	}
+
+	now := time.Now()
+	query := `
+		UPDATE backtest_runs
+		SET status = $1,
+		    total_trades = $2,
+		    win_rate = $3,
+		    max_drawdown = $4,
+		    sharpe = $5,
+		    equity_curve = $6,
+		    trade_log = $7,
+		    completed_at = $8
+		WHERE id = $9
+	`
+
+	_, err = s.db.Exec(ctx, query,
+		RunStatusCompleted, report.TotalTrades, report.WinRate, report.MaxDrawdown,
+		report.Sharpe, equityCurveJSON, tradeLogJSON, now, runID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to complete backtest run: %w", err)
+	}
+
+	return nil
+}
+
+// Fail records a Run that errored out before producing a Report
+func (s *RunStore) Fail(ctx context.Context, runID uuid.UUID, runErr error) error {
+	now := time.Now()
+	query := `
+		UPDATE backtest_runs
+		SET status = $1,
+		    error = $2,
+		    completed_at = $3
+		WHERE id = $4
+	`
+
+	_, err := s.db.Exec(ctx, query, RunStatusFailed, runErr.Error(), now, runID)
+	if err != nil {
+		return fmt.Errorf("failed to mark backtest run failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a Run by ID, for polling an async run's status/results
+func (s *RunStore) GetByID(ctx context.Context, id uuid.UUID) (*Run, error) {
+	query := `
+		SELECT id, preset_id, symbols, start_time, end_time, starting_balance,
+		       status, total_trades, win_rate, max_drawdown, sharpe,
+		       equity_curve, trade_log, error, created_at, completed_at
+		FROM backtest_runs
+		WHERE id = $1
+	`
+
+	var (
+		run             Run
+		symbolsJSON     []byte
+		equityCurveJSON []byte
+		tradeLogJSON    []byte
+	)
+
+	err := s.db.QueryRow(ctx, query, id).Scan(
+		&run.ID, &run.PresetID, &symbolsJSON, &run.StartTime, &run.EndTime,
+		&run.StartingBalance, &run.Status, &run.TotalTrades, &run.WinRate,
+		&run.MaxDrawdown, &run.Sharpe, &equityCurveJSON, &tradeLogJSON,
+		&run.Error, &run.CreatedAt, &run.CompletedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backtest run: %w", err)
+	}
+
+	if len(symbolsJSON) > 0 {
+		_ = json.Unmarshal(symbolsJSON, &run.Symbols)
+	}
+	if len(equityCurveJSON) > 0 {
+		_ = json.Unmarshal(equityCurveJSON, &run.EquityCurve)
+	}
+	if len(tradeLogJSON) > 0 {
+		_ = json.Unmarshal(tradeLogJSON, &run.TradeLog)
+	}
+
+	return &run, nil
+}
@@ -0,0 +1,67 @@
+package backtest
+
+import "neurotrade/internal/domain"
+
+// SMACrossoverSignalSource is the default reference SignalSource for
+// CLI/admin-triggered backtest runs: a simple fast/slow SMA crossover, sized
+// at a fixed fraction of startingBalance. It deliberately does not replay
+// the live AIService pipeline -- that calls out to the Python engine over
+// the network for every scan, which a historical run can't reproduce
+// deterministically -- so it stands in as the one built-in "preset" an
+// operator can A/B against until signal logs can be recorded and replayed
+// directly. One open position per symbol at a time.
+func SMACrossoverSignalSource(account string, fastWindow, slowWindow int, riskFraction, leverage, startingBalance float64, riskModel domain.RiskModel) SignalSource {
+	history := make(map[string][]domain.Kline)
+
+	return func(symbol string, bar domain.Kline, openPositions []*domain.Position) []OpenRequest {
+		klines := append(history[symbol], bar)
+		if len(klines) > slowWindow {
+			klines = klines[len(klines)-slowWindow:]
+		}
+		history[symbol] = klines
+
+		if len(openPositions) > 0 || len(klines) < slowWindow {
+			return nil
+		}
+
+		fastSMA := closeSMA(klines[len(klines)-fastWindow:])
+		slowSMA := closeSMA(klines)
+
+		var side string
+		switch {
+		case fastSMA > slowSMA:
+			side = domain.SideLong
+		case fastSMA < slowSMA:
+			side = domain.SideShort
+		default:
+			return nil
+		}
+
+		sl, tp, _, ok := riskModel.ComputeSLTP(side, bar.Close, klines)
+		if !ok {
+			return nil
+		}
+
+		size := (startingBalance * riskFraction) / bar.Close
+
+		return []OpenRequest{{
+			Account:    account,
+			Symbol:     symbol,
+			Side:       side,
+			EntryPrice: bar.Close,
+			SLPrice:    sl,
+			TPPrice:    tp,
+			Size:       size,
+			Leverage:   leverage,
+		}}
+	}
+}
+
+// closeSMA averages the Close of every kline in the window
+func closeSMA(klines []domain.Kline) float64 {
+	var sum float64
+	for _, k := range klines {
+		sum += k.Close
+	}
+	return sum / float64(len(klines))
+}
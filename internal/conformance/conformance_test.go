@@ -0,0 +1,32 @@
+package conformance
+
+import (
+	"flag"
+	"os"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "regenerate expected conformance outputs instead of asserting against them")
+
+// TestVectors drives every testdata/vectors/*.json scenario through
+// ReviewService and asserts its review outcomes match the vector's
+// expected_reviews. Set SKIP_CONFORMANCE=1 to bail out early in
+// constrained CI; run with -update after an intentional behavior change
+// to regenerate expected_reviews from the new actual output.
+func TestVectors(t *testing.T) {
+	if os.Getenv("SKIP_CONFORMANCE") == "1" {
+		t.Skip("SKIP_CONFORMANCE=1 set, skipping conformance vectors")
+	}
+
+	vectors, err := LoadVectors("testdata/vectors")
+	if err != nil {
+		t.Fatalf("failed to load vectors: %v", err)
+	}
+
+	for _, v := range vectors {
+		v := v
+		t.Run(v.Name, func(t *testing.T) {
+			RunVector(t, v, *update)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package conformance
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"neurotrade/internal/domain"
+	"neurotrade/internal/notification"
+)
+
+// memorySignalRepo is an in-memory domain.SignalRepository, scoped to what
+// ReviewService.ReviewPastSignals actually calls (GetPendingSignals,
+// UpdateReviewStatus); the rest satisfy the interface but are unused by the
+// harness today.
+type memorySignalRepo struct {
+	mu      sync.Mutex
+	signals map[uuid.UUID]*domain.Signal
+}
+
+func newMemorySignalRepo() *memorySignalRepo {
+	return &memorySignalRepo{signals: make(map[uuid.UUID]*domain.Signal)}
+}
+
+func (r *memorySignalRepo) seed(signal *domain.Signal) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.signals[signal.ID] = signal
+}
+
+func (r *memorySignalRepo) Save(_ context.Context, signal *domain.Signal) error {
+	r.seed(signal)
+	return nil
+}
+
+func (r *memorySignalRepo) GetRecent(_ context.Context, limit int) ([]*domain.Signal, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]*domain.Signal, 0, len(r.signals))
+	for _, s := range r.signals {
+		out = append(out, s)
+	}
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (r *memorySignalRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.Signal, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	signal, ok := r.signals[id]
+	if !ok {
+		return nil, errSignalNotFound(id)
+	}
+	return signal, nil
+}
+
+func (r *memorySignalRepo) GetBySymbol(_ context.Context, symbol string, limit int) ([]*domain.Signal, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []*domain.Signal
+	for _, s := range r.signals {
+		if s.Symbol == symbol {
+			out = append(out, s)
+		}
+		if len(out) == limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (r *memorySignalRepo) UpdateStatus(_ context.Context, id uuid.UUID, status string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	signal, ok := r.signals[id]
+	if !ok {
+		return errSignalNotFound(id)
+	}
+	signal.Status = status
+	return nil
+}
+
+func (r *memorySignalRepo) UpdateReviewStatus(_ context.Context, id uuid.UUID, result string, pnl *float64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	signal, ok := r.signals[id]
+	if !ok {
+		return errSignalNotFound(id)
+	}
+	signal.ReviewResult = &result
+	_ = pnl // vectors assert on result only, not the numeric PnL
+	return nil
+}
+
+// GetPendingSignals ignores olderThanMinutes -- every vector's seeded
+// signals are treated as already past the review threshold.
+func (r *memorySignalRepo) GetPendingSignals(_ context.Context, _ int) ([]*domain.Signal, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]*domain.Signal, 0, len(r.signals))
+	for _, s := range r.signals {
+		if s.ReviewResult == nil {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func errSignalNotFound(id uuid.UUID) error {
+	return &signalNotFoundError{id: id}
+}
+
+type signalNotFoundError struct{ id uuid.UUID }
+
+func (e *signalNotFoundError) Error() string {
+	return "conformance: signal not found: " + e.id.String()
+}
+
+// noopNotifier is a service.NotificationService that drops every event --
+// the harness asserts on review results, not on notification side effects.
+type noopNotifier struct{}
+
+func (noopNotifier) Emit(_ context.Context, _ notification.Topic, _ notification.Payload) error {
+	return nil
+}
@@ -0,0 +1,164 @@
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"neurotrade/internal/domain"
+	"neurotrade/internal/service"
+)
+
+// stubBinanceServer serves the Binance Futures REST endpoints
+// exchange.BinanceExchange hits, backed by a Vector's PriceTape (for the
+// ticker endpoint) and each signal's seeded Klines (for the klines
+// endpoint), so exit rules that need history (EMAStopRule,
+// LowerShadowRule) see deterministic bars.
+func stubBinanceServer(v *Vector) *httptest.Server {
+	prices := make(map[string]float64, len(v.PriceTape))
+	for _, step := range v.PriceTape {
+		prices[strings.ToUpper(step.Symbol)] = step.Price
+	}
+
+	klinesBySymbol := make(map[string][]domain.Kline, len(v.Signals))
+	for _, s := range v.Signals {
+		klinesBySymbol[strings.ToUpper(s.Symbol)] = s.Klines
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/fapi/v1/ticker/price", func(w http.ResponseWriter, r *http.Request) {
+		symbol := strings.ToUpper(r.URL.Query().Get("symbol"))
+		json.NewEncoder(w).Encode(struct {
+			Price string `json:"price"`
+		}{Price: strconv.FormatFloat(prices[symbol], 'f', -1, 64)})
+	})
+
+	mux.HandleFunc("/fapi/v1/klines", func(w http.ResponseWriter, r *http.Request) {
+		symbol := strings.ToUpper(r.URL.Query().Get("symbol"))
+		klines := klinesBySymbol[symbol]
+
+		raw := make([][]interface{}, 0, len(klines))
+		for _, k := range klines {
+			raw = append(raw, []interface{}{
+				k.OpenTime.UnixMilli(),
+				strconv.FormatFloat(k.Open, 'f', -1, 64),
+				strconv.FormatFloat(k.High, 'f', -1, 64),
+				strconv.FormatFloat(k.Low, 'f', -1, 64),
+				strconv.FormatFloat(k.Close, 'f', -1, 64),
+				"0",
+				k.OpenTime.UnixMilli(),
+				strconv.FormatFloat(k.QuoteVolume, 'f', -1, 64),
+				0,
+			})
+		}
+		json.NewEncoder(w).Encode(raw)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+// RunVector seeds an in-memory SignalRepository from v.Signals, runs
+// ReviewService.ReviewPastSignals against a stubbed Binance server scripted
+// from v.PriceTape/v.Signals[].Klines, and either diffs the resulting
+// review outcomes against v.ExpectedReviews (update=false) or overwrites
+// v.ExpectedReviews with the actual outcomes and saves the vector
+// (update=true).
+func RunVector(t *testing.T, v *Vector, update bool) {
+	t.Helper()
+
+	server := stubBinanceServer(v)
+	defer server.Close()
+
+	restoreExchange := setEnv("EXCHANGE", "BINANCE")
+	restoreBaseURL := setEnv("BINANCE_EXCHANGE_BASE_URL", server.URL)
+	defer restoreExchange()
+	defer restoreBaseURL()
+
+	priceService := service.NewMarketPriceService()
+
+	exitRules, err := service.ParseExitRuleChain(priceService, v.ExitRulesJSON)
+	if err != nil {
+		t.Fatalf("%s: failed to parse exit_rules: %v", v.Name, err)
+	}
+
+	repo := newMemorySignalRepo()
+	bySymbol := make(map[string]uuid.UUID, len(v.Signals))
+	for _, vs := range v.Signals {
+		id := uuid.New()
+		bySymbol[vs.Symbol] = id
+		repo.seed(&domain.Signal{
+			ID:         id,
+			Symbol:     vs.Symbol,
+			Type:       vs.Type,
+			EntryPrice: vs.EntryPrice,
+			TPPrice:    vs.TPPrice,
+			SLPrice:    vs.SLPrice,
+			Status:     domain.StatusPending,
+			CreatedAt:  time.Now(),
+		})
+	}
+
+	reviewService := service.NewReviewService(repo, priceService, noopNotifier{}, exitRules)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := reviewService.ReviewPastSignals(ctx, 0); err != nil {
+		t.Fatalf("%s: ReviewPastSignals: %v", v.Name, err)
+	}
+
+	var actual []ExpectedReview
+	for symbol, id := range bySymbol {
+		signal, err := repo.GetByID(ctx, id)
+		if err != nil {
+			t.Fatalf("%s: %v", v.Name, err)
+		}
+		result := ""
+		if signal.ReviewResult != nil {
+			result = *signal.ReviewResult
+		}
+		actual = append(actual, ExpectedReview{Symbol: symbol, Result: result})
+	}
+	sort.Slice(actual, func(i, j int) bool { return actual[i].Symbol < actual[j].Symbol })
+
+	if update {
+		v.ExpectedReviews = actual
+		if err := v.Save(); err != nil {
+			t.Fatalf("%s: failed to save updated vector: %v", v.Name, err)
+		}
+		return
+	}
+
+	expected := append([]ExpectedReview(nil), v.ExpectedReviews...)
+	sort.Slice(expected, func(i, j int) bool { return expected[i].Symbol < expected[j].Symbol })
+
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("%s: review results mismatch\n got:  %+v\n want: %+v", v.Name, actual, expected)
+	}
+}
+
+// setEnv sets key to value and returns a func restoring the previous value
+// (or unsetting it if it wasn't set), for deferred cleanup around a single
+// RunVector call sharing the process-global environment.
+func setEnv(key, value string) func() {
+	prev, had := os.LookupEnv(key)
+	os.Setenv(key, value)
+	return func() {
+		if had {
+			os.Setenv(key, prev)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
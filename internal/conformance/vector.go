@@ -0,0 +1,108 @@
+// Package conformance provides a regression net for the review pipeline's
+// WIN/LOSS/FLOATING semantics (and any exit rules layered on top of it,
+// see service.ExitRule): each testdata/vectors/*.json file describes a
+// scenario -- a set of pending signals, a preset's exit_rules
+// configuration, a scripted price tape -- and the review outcome every
+// signal is expected to land on. Running `go test ./internal/conformance`
+// drives service.ReviewService against that scenario with in-memory fakes
+// and diffs actual vs. expected review results.
+//
+// This intentionally covers review determinism only, not signal
+// generation: TradingService's signal-generation path pulls in the AI
+// client, exchange client, and hedge service, none of which have
+// lightweight in-memory fakes in this codebase yet. Extending the
+// corpus/harness to cover emitted Signal shape (type, entry, TP, SL) is
+// future work once those fakes exist.
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"neurotrade/internal/domain"
+)
+
+// VectorSignal is one pending signal a Vector seeds into the in-memory
+// SignalRepository before review runs.
+type VectorSignal struct {
+	Symbol     string         `json:"symbol"`
+	Type       string         `json:"type"`
+	EntryPrice float64        `json:"entry_price"`
+	TPPrice    float64        `json:"tp_price"`
+	SLPrice    float64        `json:"sl_price"`
+	Klines     []domain.Kline `json:"klines"` // history fed to history-dependent exit rules (EMAStopRule, LowerShadowRule)
+}
+
+// TapeStep is one scripted current price ReviewPastSignals will observe for
+// Symbol via MarketPriceService, served by the harness's stubbed Binance
+// server.
+type TapeStep struct {
+	Symbol string  `json:"symbol"`
+	Price  float64 `json:"price"`
+}
+
+// ExpectedReview is the review outcome a Vector asserts for Symbol.
+type ExpectedReview struct {
+	Symbol string `json:"symbol"`
+	Result string `json:"result"`
+}
+
+// Vector is one full conformance scenario loaded from testdata/vectors.
+type Vector struct {
+	Name            string           `json:"name"`
+	ExitRulesJSON   json.RawMessage  `json:"exit_rules"`
+	Signals         []VectorSignal   `json:"signals"`
+	PriceTape       []TapeStep       `json:"price_tape"`
+	ExpectedReviews []ExpectedReview `json:"expected_reviews"`
+
+	path string // source file, used by --update to write results back
+}
+
+// LoadVectors reads every *.json file in dir into a Vector, sorted by file
+// name so test ordering (and hence output ordering with -v) is stable.
+func LoadVectors(dir string) ([]*Vector, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vectors dir %s: %w", dir, err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && filepath.Ext(e.Name()) == ".json" {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	vectors := make([]*Vector, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read vector %s: %w", path, err)
+		}
+
+		var v Vector
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, fmt.Errorf("failed to parse vector %s: %w", path, err)
+		}
+		v.path = path
+
+		vectors = append(vectors, &v)
+	}
+
+	return vectors, nil
+}
+
+// Save rewrites v's source file with its current ExpectedReviews, for
+// --update after an intentional behavior change.
+func (v *Vector) Save() error {
+	raw, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal vector %s: %w", v.Name, err)
+	}
+	return os.WriteFile(v.path, append(raw, '\n'), 0644)
+}
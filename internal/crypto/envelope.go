@@ -0,0 +1,112 @@
+// Package crypto provides an AES-GCM envelope for encrypting secrets (such
+// as exchange API credentials) before they touch the database, so a DB leak
+// alone doesn't hand over live trading credentials.
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// envelopeVersionV1 is prefixed to every ciphertext so future key/algorithm
+// rotations can recognize and migrate older blobs.
+const envelopeVersionV1 = "v1"
+
+// MasterKeyEnvVar is the environment variable holding the base64-encoded
+// AES-256 master key. In production this should be sourced from a KMS and
+// injected into the env at deploy time rather than committed anywhere.
+const MasterKeyEnvVar = "CREDENTIAL_ENCRYPTION_KEY"
+
+// Envelope encrypts and decrypts secrets with a single AES-256-GCM key.
+type Envelope struct {
+	aead cipher.AEAD
+}
+
+// NewEnvelope builds an Envelope from a raw 32-byte AES-256 key.
+func NewEnvelope(key []byte) (*Envelope, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("crypto: master key must be 32 bytes, got %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: failed to create cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: failed to create GCM: %w", err)
+	}
+
+	return &Envelope{aead: aead}, nil
+}
+
+// NewEnvelopeFromEnv builds an Envelope from the base64-encoded master key in
+// MasterKeyEnvVar.
+func NewEnvelopeFromEnv() (*Envelope, error) {
+	encoded := os.Getenv(MasterKeyEnvVar)
+	if encoded == "" {
+		return nil, fmt.Errorf("crypto: %s is not set", MasterKeyEnvVar)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: failed to decode %s: %w", MasterKeyEnvVar, err)
+	}
+
+	return NewEnvelope(key)
+}
+
+// Encrypt seals plaintext into a versioned, base64-encoded blob. An empty
+// plaintext encrypts to an empty string so unset credentials stay unset
+// instead of becoming a non-empty ciphertext of "".
+func (e *Envelope) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	nonce := make([]byte, e.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("crypto: failed to generate nonce: %w", err)
+	}
+
+	sealed := e.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return envelopeVersionV1 + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a blob produced by Encrypt back into plaintext.
+func (e *Envelope) Decrypt(blob string) (string, error) {
+	if blob == "" {
+		return "", nil
+	}
+
+	version, encoded, ok := strings.Cut(blob, ":")
+	if !ok || version != envelopeVersionV1 {
+		return "", fmt.Errorf("crypto: unrecognized envelope version in blob")
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("crypto: failed to decode blob: %w", err)
+	}
+
+	nonceSize := e.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", errors.New("crypto: blob shorter than nonce size")
+	}
+
+	nonce, ciphertext := sealed[:nonceSize], sealed[nonceSize:]
+	plaintext, err := e.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("crypto: failed to decrypt blob: %w", err)
+	}
+
+	return string(plaintext), nil
+}
@@ -2,94 +2,189 @@ package database
 
 import (
 	"context"
+	"crypto/sha256"
 	"embed"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"path/filepath"
 	"sort"
 	"strings"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 //go:embed migrations/*.sql
 var migrationsFS embed.FS
 
-// RunMigrations runs all database migrations on startup
+// migrationLockKey is a fixed Postgres advisory lock key guarding the whole
+// migration run, so two app instances booting concurrently against the
+// same database serialize their DDL instead of racing it.
+const migrationLockKey = 837465123
+
+// migration is one discovered version's up/down SQL, paired by filename
+// convention: "NNN_name.sql" or "NNN_name.up.sql" is the up migration,
+// "NNN_name.down.sql" its optional rollback. A version without a down file
+// can be applied but not rolled back via RollbackTo/RollbackN.
+type migration struct {
+	version  string
+	upFile   string
+	upSQL    string
+	downFile string
+	downSQL  string
+	noTx     bool // upSQL's first line is "-- notx" (e.g. CREATE INDEX CONCURRENTLY, which can't run inside a transaction)
+}
+
+// loadMigrations discovers every migration under migrations/, sorted by
+// version ascending.
+func loadMigrations() ([]*migration, error) {
+	entries, err := migrationsFS.ReadDir("migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	byVersion := make(map[string]*migration)
+	var versions []string
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		idx := strings.IndexByte(entry.Name(), '_')
+		if idx < 0 {
+			return nil, fmt.Errorf("migration file %s doesn't follow the NNN_name.sql convention", entry.Name())
+		}
+		version := entry.Name()[:idx]
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{version: version}
+			byVersion[version] = m
+			versions = append(versions, version)
+		}
+
+		content, err := migrationsFS.ReadFile(filepath.Join("migrations", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		if strings.HasSuffix(entry.Name(), ".down.sql") {
+			m.downFile = entry.Name()
+			m.downSQL = string(content)
+			continue
+		}
+
+		m.upFile = entry.Name()
+		m.upSQL = string(content)
+		m.noTx = isNoTx(string(content))
+	}
+
+	sort.Strings(versions)
+
+	migrations := make([]*migration, 0, len(versions))
+	for _, v := range versions {
+		m := byVersion[v]
+		if m.upFile == "" {
+			return nil, fmt.Errorf("migration version %s has a .down.sql file but no matching up migration", v)
+		}
+		migrations = append(migrations, m)
+	}
+
+	return migrations, nil
+}
+
+// isNoTx reports whether sql's first non-blank line is the literal marker
+// "-- notx", opting a migration out of RunMigrations'/RollbackTo's
+// transaction wrapping.
+func isNoTx(sql string) bool {
+	trimmed := strings.TrimSpace(sql)
+	if idx := strings.IndexByte(trimmed, '\n'); idx >= 0 {
+		trimmed = trimmed[:idx]
+	}
+	return strings.TrimSpace(trimmed) == "-- notx"
+}
+
+func checksum(sql string) string {
+	sum := sha256.Sum256([]byte(sql))
+	return hex.EncodeToString(sum[:])
+}
+
+// RunMigrations applies every pending migration in version order, inside a
+// Postgres advisory lock so concurrent app boots don't race each other's
+// DDL. Each migration runs inside its own transaction unless its up file
+// starts with "-- notx". A previously-applied version whose up file content
+// no longer matches its recorded checksum fails startup rather than
+// drifting silently -- see "neurotrade migrate force" to resolve that
+// deliberately.
 func RunMigrations(db *pgxpool.Pool) error {
 	ctx := context.Background()
 
+	conn, err := db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection for migrations: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration advisory lock: %w", err)
+	}
+	defer conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", migrationLockKey)
+
 	log.Println("Running database migrations...")
 
-	// Create migrations tracking table if not exists
-	_, err := db.Exec(ctx, `
+	if _, err := conn.Exec(ctx, `
 		CREATE TABLE IF NOT EXISTS schema_migrations (
 			version VARCHAR(255) PRIMARY KEY,
+			checksum VARCHAR(64) NOT NULL DEFAULT '',
 			applied_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 		)
-	`)
-	if err != nil {
+	`); err != nil {
 		return fmt.Errorf("failed to create migrations table: %w", err)
 	}
-
-	// Read all migration files
-	entries, err := migrationsFS.ReadDir("migrations")
-	if err != nil {
-		return fmt.Errorf("failed to read migrations directory: %w", err)
+	if _, err := conn.Exec(ctx, `ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS checksum VARCHAR(64) NOT NULL DEFAULT ''`); err != nil {
+		return fmt.Errorf("failed to add checksum column: %w", err)
 	}
 
-	// Filter and sort .sql files
-	var migrationFiles []string
-	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".sql") {
-			migrationFiles = append(migrationFiles, entry.Name())
-		}
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
 	}
-	sort.Strings(migrationFiles) // Sort to ensure order (001, 002, 003, etc.)
+	log.Printf("Found %d migration version(s)", len(migrations))
 
-	log.Printf("Found %d migration file(s)", len(migrationFiles))
-
-	// Run each migration if not already applied
 	appliedCount := 0
-	for _, filename := range migrationFiles {
-		version := strings.TrimSuffix(filename, ".sql")
-
-		// Check if already applied
-		var exists bool
-		err := db.QueryRow(ctx, `
-			SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE version = $1)
-		`, version).Scan(&exists)
-		if err != nil {
-			return fmt.Errorf("failed to check migration status for %s: %w", version, err)
+	for _, m := range migrations {
+		var appliedChecksum string
+		err := conn.QueryRow(ctx, `SELECT checksum FROM schema_migrations WHERE version = $1`, m.version).Scan(&appliedChecksum)
+		applied := true
+		if err == pgx.ErrNoRows {
+			applied = false
+		} else if err != nil {
+			return fmt.Errorf("failed to check migration status for %s: %w", m.version, err)
 		}
 
-		if exists {
-			log.Printf("  [SKIP] %s (already applied)", filename)
+		sum := checksum(m.upSQL)
+
+		if applied {
+			if appliedChecksum != "" && appliedChecksum != sum {
+				return fmt.Errorf("migration %s has drifted since being applied (checksum mismatch) -- "+
+					"use 'neurotrade migrate force %s' once you've confirmed the new content is safe to adopt", m.version, m.version)
+			}
+			log.Printf("  [SKIP] %s (already applied)", m.upFile)
 			continue
 		}
 
-		// Read migration file
-		content, err := migrationsFS.ReadFile(filepath.Join("migrations", filename))
-		if err != nil {
-			return fmt.Errorf("failed to read migration %s: %w", filename, err)
+		log.Printf("  [APPLY] %s...", m.upFile)
+		if err := execSQL(ctx, conn, m.upSQL, m.noTx); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", m.version, err)
 		}
 
-		// Execute migration
-		log.Printf("  [APPLY] %s...", filename)
-		_, err = db.Exec(ctx, string(content))
-		if err != nil {
-			return fmt.Errorf("failed to apply migration %s: %w", filename, err)
+		if _, err := conn.Exec(ctx, `INSERT INTO schema_migrations (version, checksum) VALUES ($1, $2)`, m.version, sum); err != nil {
+			return fmt.Errorf("failed to mark migration %s as applied: %w", m.version, err)
 		}
 
-		// Mark as applied
-		_, err = db.Exec(ctx, `
-			INSERT INTO schema_migrations (version) VALUES ($1)
-		`, version)
-		if err != nil {
-			return fmt.Errorf("failed to mark migration %s as applied: %w", version, err)
-		}
-
-		log.Printf("  [OK] %s applied successfully", filename)
+		log.Printf("  [OK] %s applied successfully", m.upFile)
 		appliedCount++
 	}
 
@@ -101,3 +196,226 @@ func RunMigrations(db *pgxpool.Pool) error {
 
 	return nil
 }
+
+// execSQL runs sql against conn, wrapped in its own transaction unless
+// noTx, in which case it runs directly (for statements like CREATE INDEX
+// CONCURRENTLY that Postgres refuses inside a transaction block).
+func execSQL(ctx context.Context, conn *pgxpool.Conn, sql string, noTx bool) error {
+	if noTx {
+		_, err := conn.Exec(ctx, sql)
+		return err
+	}
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, sql); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// RollbackTo rolls back every applied migration with a version greater than
+// targetVersion, newest first, running each one's down.sql inside the same
+// advisory lock RunMigrations uses. targetVersion "" rolls back everything.
+// Fails without changing anything already-applied if any migration in the
+// range has no down.sql.
+func RollbackTo(ctx context.Context, db *pgxpool.Pool, targetVersion string) error {
+	conn, err := db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection for rollback: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration advisory lock: %w", err)
+	}
+	defer conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", migrationLockKey)
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+	byVersion := make(map[string]*migration, len(migrations))
+	for _, m := range migrations {
+		byVersion[m.version] = m
+	}
+
+	rows, err := conn.Query(ctx, `SELECT version FROM schema_migrations WHERE version > $1 ORDER BY version DESC`, targetVersion)
+	if err != nil {
+		return fmt.Errorf("failed to list applied migrations: %w", err)
+	}
+	var toRollback []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			rows.Close()
+			return err
+		}
+		toRollback = append(toRollback, v)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Fail fast if any version in range can't be rolled back, before
+	// mutating anything.
+	for _, version := range toRollback {
+		if m, ok := byVersion[version]; !ok || m.downSQL == "" {
+			return fmt.Errorf("no down migration available for version %s, aborting rollback before touching the database", version)
+		}
+	}
+
+	for _, version := range toRollback {
+		m := byVersion[version]
+
+		log.Printf("  [ROLLBACK] %s...", m.downFile)
+		if err := execSQL(ctx, conn, m.downSQL, m.noTx); err != nil {
+			return fmt.Errorf("failed to roll back migration %s: %w", version, err)
+		}
+
+		if _, err := conn.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, version); err != nil {
+			return fmt.Errorf("failed to unmark migration %s: %w", version, err)
+		}
+
+		log.Printf("  [OK] %s rolled back successfully", version)
+	}
+
+	return nil
+}
+
+// RollbackN rolls back the n most recently applied migrations, equivalent
+// to RollbackTo the version n steps back from the latest applied one.
+func RollbackN(ctx context.Context, db *pgxpool.Pool, n int) error {
+	if n <= 0 {
+		return fmt.Errorf("n must be positive")
+	}
+
+	rows, err := db.Query(ctx, `SELECT version FROM schema_migrations ORDER BY version DESC`)
+	if err != nil {
+		return fmt.Errorf("failed to list applied migrations: %w", err)
+	}
+	var applied []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			rows.Close()
+			return err
+		}
+		applied = append(applied, v)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(applied) == 0 {
+		log.Println("No applied migrations to roll back")
+		return nil
+	}
+
+	target := ""
+	if n < len(applied) {
+		target = applied[n]
+	}
+
+	return RollbackTo(ctx, db, target)
+}
+
+// MigrationStatus is one discovered version's applied/drift state, for the
+// "neurotrade migrate status" CLI subcommand.
+type MigrationStatus struct {
+	Version string
+	Applied bool
+	Drifted bool
+	HasDown bool
+}
+
+// Status reports every discovered migration's applied/drift state.
+func Status(ctx context.Context, db *pgxpool.Pool) ([]MigrationStatus, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string]string) // version -> recorded checksum
+	rows, err := db.Query(ctx, `SELECT version, checksum FROM schema_migrations`)
+	if err == nil {
+		for rows.Next() {
+			var v, sum string
+			if err := rows.Scan(&v, &sum); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			applied[v] = sum
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	// A missing schema_migrations table (migrations never run yet) just
+	// means nothing is applied -- not a reason to fail a status check.
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		sum, isApplied := applied[m.version]
+		statuses = append(statuses, MigrationStatus{
+			Version: m.version,
+			Applied: isApplied,
+			Drifted: isApplied && sum != "" && sum != checksum(m.upSQL),
+			HasDown: m.downSQL != "",
+		})
+	}
+
+	return statuses, nil
+}
+
+// ForceVersion marks version as applied (recording its current checksum)
+// without running its migration, for deliberately resolving a
+// drifted-checksum or stuck-state situation an operator has manually
+// confirmed is safe.
+func ForceVersion(ctx context.Context, db *pgxpool.Pool, version string) error {
+	if _, err := db.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version VARCHAR(255) PRIMARY KEY,
+			checksum VARCHAR(64) NOT NULL DEFAULT '',
+			applied_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		)
+	`); err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	var sum string
+	found := false
+	for _, m := range migrations {
+		if m.version == version {
+			sum = checksum(m.upSQL)
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("no migration found for version %s", version)
+	}
+
+	_, err = db.Exec(ctx, `
+		INSERT INTO schema_migrations (version, checksum) VALUES ($1, $2)
+		ON CONFLICT (version) DO UPDATE SET checksum = EXCLUDED.checksum
+	`, version, sum)
+	if err != nil {
+		return fmt.Errorf("failed to force version %s: %w", version, err)
+	}
+
+	return nil
+}
@@ -2,30 +2,39 @@ package http
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
-	"github.com/labstack/echo/v4"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+
+	"neurotrade/internal/infra"
+	"neurotrade/internal/repository"
 )
 
 // AdminHandler handles admin-related requests
 type AdminHandler struct {
-	db *pgxpool.Pool
+	db        *pgxpool.Pool
+	jobRepo   *repository.ScheduledJobRepository
+	scheduler *infra.Scheduler
 }
 
 // NewAdminHandler creates a new AdminHandler
-func NewAdminHandler(db *pgxpool.Pool) *AdminHandler {
+func NewAdminHandler(db *pgxpool.Pool, jobRepo *repository.ScheduledJobRepository, scheduler *infra.Scheduler) *AdminHandler {
 	return &AdminHandler{
-		db: db,
+		db:        db,
+		jobRepo:   jobRepo,
+		scheduler: scheduler,
 	}
 }
 
 // StrategyPreset represents a strategy preset
 type StrategyPreset struct {
-	ID           int    `json:"id"`
-	Name         string `json:"name"`
-	SystemPrompt string `json:"system_prompt"`
-	IsActive     bool   `json:"is_active"`
+	ID           int             `json:"id"`
+	Name         string          `json:"name"`
+	SystemPrompt string          `json:"system_prompt"`
+	IsActive     bool            `json:"is_active"`
+	ExitRules    json.RawMessage `json:"exit_rules,omitempty"`
 }
 
 // GetStrategies returns all strategy presets
@@ -35,7 +44,7 @@ func (h *AdminHandler) GetStrategies(c echo.Context) error {
 	defer cancel()
 
 	query := `
-		SELECT id, name, system_prompt, is_active
+		SELECT id, name, system_prompt, is_active, COALESCE(exit_rules, '[]')
 		FROM strategy_presets
 		ORDER BY id ASC
 	`
@@ -49,7 +58,7 @@ func (h *AdminHandler) GetStrategies(c echo.Context) error {
 	var strategies []StrategyPreset
 	for rows.Next() {
 		var s StrategyPreset
-		if err := rows.Scan(&s.ID, &s.Name, &s.SystemPrompt, &s.IsActive); err != nil {
+		if err := rows.Scan(&s.ID, &s.Name, &s.SystemPrompt, &s.IsActive, &s.ExitRules); err != nil {
 			return InternalServerErrorResponse(c, "Failed to scan strategy", err)
 		}
 		strategies = append(strategies, s)
@@ -109,13 +118,22 @@ func (h *AdminHandler) SetActiveStrategy(c echo.Context) error {
 		return NotFoundResponse(c, "Strategy preset not found")
 	}
 
+	// Read back the newly-active preset's exit rule chain so callers (and
+	// ReviewService, on its next restart) can see exactly what will run
+	// without a second GetStrategies round trip.
+	var exitRules json.RawMessage
+	if err := tx.QueryRow(ctx, "SELECT COALESCE(exit_rules, '[]') FROM strategy_presets WHERE id = $1", req.PresetID).Scan(&exitRules); err != nil {
+		return InternalServerErrorResponse(c, "Failed to read activated strategy's exit rules", err)
+	}
+
 	// Commit transaction
 	if err := tx.Commit(ctx); err != nil {
 		return InternalServerErrorResponse(c, "Failed to commit transaction", err)
 	}
 
 	return SuccessMessageResponse(c, "Active strategy updated successfully", map[string]interface{}{
-		"preset_id": req.PresetID,
+		"preset_id":  req.PresetID,
+		"exit_rules": exitRules,
 	})
 }
 
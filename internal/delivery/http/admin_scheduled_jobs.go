@@ -0,0 +1,234 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"neurotrade/internal/infra"
+	"neurotrade/internal/repository"
+)
+
+// parseIDParam parses the ":id" path param as an integer, for the
+// scheduled-jobs endpoints below (scheduled_jobs.id is a SERIAL, unlike most
+// other resources here which key off a uuid.UUID).
+func parseIDParam(c echo.Context) (int, error) {
+	return strconv.Atoi(c.Param("id"))
+}
+
+// ListScheduledJobs returns every scheduled job, enabled or not
+// GET /api/admin/scheduled-jobs
+func (h *AdminHandler) ListScheduledJobs(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	jobs, err := h.jobRepo.GetAll(ctx)
+	if err != nil {
+		return InternalServerErrorResponse(c, "Failed to fetch scheduled jobs", err)
+	}
+
+	return SuccessResponse(c, map[string]interface{}{
+		"jobs":  jobs,
+		"count": len(jobs),
+	})
+}
+
+// ScheduledJobRequest is the request body for creating/updating a scheduled job
+type ScheduledJobRequest struct {
+	Name       string          `json:"name"`
+	CronExpr   string          `json:"cron_expr"`
+	JobType    string          `json:"job_type"`
+	ParamsJSON json.RawMessage `json:"params_json"`
+	Enabled    *bool           `json:"enabled"`
+}
+
+// validJobTypes is the set of JobType values the scheduler knows how to
+// dispatch; kept here rather than in the repository package since it's only
+// the HTTP layer that needs to validate untrusted input.
+var validJobTypes = map[repository.JobType]bool{
+	repository.JobTypeMarketScan:          true,
+	repository.JobTypeReviewSignals:       true,
+	repository.JobTypeCloseStalePositions: true,
+	repository.JobTypeBacktestRolling:     true,
+}
+
+// CreateScheduledJob adds a new scheduled job and hot-reloads the scheduler
+// POST /api/admin/scheduled-jobs
+func (h *AdminHandler) CreateScheduledJob(c echo.Context) error {
+	var req ScheduledJobRequest
+	if err := c.Bind(&req); err != nil {
+		return BadRequestResponse(c, "Invalid request payload")
+	}
+
+	if req.Name == "" || req.CronExpr == "" {
+		return BadRequestResponse(c, "name and cron_expr are required")
+	}
+	if !validJobTypes[repository.JobType(req.JobType)] {
+		return BadRequestResponse(c, "Invalid job_type")
+	}
+	if _, err := infra.NextRun(req.CronExpr, time.Now()); err != nil {
+		return BadRequestResponse(c, "Invalid cron_expr: "+err.Error())
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	job := &repository.ScheduledJob{
+		Name:       req.Name,
+		CronExpr:   req.CronExpr,
+		JobType:    repository.JobType(req.JobType),
+		ParamsJSON: req.ParamsJSON,
+		Enabled:    enabled,
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.jobRepo.Create(ctx, job); err != nil {
+		return InternalServerErrorResponse(c, "Failed to create scheduled job", err)
+	}
+	if err := h.scheduler.Reload(ctx); err != nil {
+		return InternalServerErrorResponse(c, "Job created but scheduler reload failed", err)
+	}
+
+	return SuccessResponse(c, job)
+}
+
+// UpdateScheduledJob overwrites an existing scheduled job's cron expression,
+// type, params and enabled flag, then hot-reloads the scheduler
+// PUT /api/admin/scheduled-jobs/:id
+func (h *AdminHandler) UpdateScheduledJob(c echo.Context) error {
+	id, err := parseIDParam(c)
+	if err != nil {
+		return BadRequestResponse(c, "Invalid job id")
+	}
+
+	var req ScheduledJobRequest
+	if err := c.Bind(&req); err != nil {
+		return BadRequestResponse(c, "Invalid request payload")
+	}
+
+	if req.CronExpr == "" {
+		return BadRequestResponse(c, "cron_expr is required")
+	}
+	if !validJobTypes[repository.JobType(req.JobType)] {
+		return BadRequestResponse(c, "Invalid job_type")
+	}
+	if _, err := infra.NextRun(req.CronExpr, time.Now()); err != nil {
+		return BadRequestResponse(c, "Invalid cron_expr: "+err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	job, err := h.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		return NotFoundResponse(c, "Scheduled job not found")
+	}
+
+	job.CronExpr = req.CronExpr
+	job.JobType = repository.JobType(req.JobType)
+	if req.ParamsJSON != nil {
+		job.ParamsJSON = req.ParamsJSON
+	}
+	if req.Enabled != nil {
+		job.Enabled = *req.Enabled
+	}
+
+	if err := h.jobRepo.Update(ctx, job); err != nil {
+		return InternalServerErrorResponse(c, "Failed to update scheduled job", err)
+	}
+	if err := h.scheduler.Reload(ctx); err != nil {
+		return InternalServerErrorResponse(c, "Job updated but scheduler reload failed", err)
+	}
+
+	return SuccessResponse(c, job)
+}
+
+// SetScheduledJobEnabledRequest is the request body for enabling/disabling a job
+type SetScheduledJobEnabledRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// SetScheduledJobEnabled pauses or resumes a scheduled job without touching
+// its schedule, then hot-reloads the scheduler
+// PUT /api/admin/scheduled-jobs/:id/enabled
+func (h *AdminHandler) SetScheduledJobEnabled(c echo.Context) error {
+	id, err := parseIDParam(c)
+	if err != nil {
+		return BadRequestResponse(c, "Invalid job id")
+	}
+
+	var req SetScheduledJobEnabledRequest
+	if err := c.Bind(&req); err != nil {
+		return BadRequestResponse(c, "Invalid request payload")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.jobRepo.SetEnabled(ctx, id, req.Enabled); err != nil {
+		return InternalServerErrorResponse(c, "Failed to update scheduled job", err)
+	}
+	if err := h.scheduler.Reload(ctx); err != nil {
+		return InternalServerErrorResponse(c, "Job updated but scheduler reload failed", err)
+	}
+
+	return SuccessMessageResponse(c, "Scheduled job updated", map[string]interface{}{
+		"id":      id,
+		"enabled": req.Enabled,
+	})
+}
+
+// TriggerScheduledJob runs a scheduled job immediately, outside its cron
+// schedule, for manual testing of a new job's params without waiting for it
+// to fire
+// POST /api/admin/scheduled-jobs/:id/trigger
+func (h *AdminHandler) TriggerScheduledJob(c echo.Context) error {
+	id, err := parseIDParam(c)
+	if err != nil {
+		return BadRequestResponse(c, "Invalid job id")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 30*time.Second)
+	defer cancel()
+
+	if err := h.scheduler.RunNowByID(ctx, id); err != nil {
+		return InternalServerErrorResponse(c, "Failed to run scheduled job", err)
+	}
+
+	return SuccessMessageResponse(c, "Scheduled job triggered", map[string]interface{}{"id": id})
+}
+
+// GetScheduledJobNextRun previews when a scheduled job will next fire,
+// computed via cron.ParseStandard rather than waiting for the real tick
+// GET /api/admin/scheduled-jobs/:id/next-run
+func (h *AdminHandler) GetScheduledJobNextRun(c echo.Context) error {
+	id, err := parseIDParam(c)
+	if err != nil {
+		return BadRequestResponse(c, "Invalid job id")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	job, err := h.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		return NotFoundResponse(c, "Scheduled job not found")
+	}
+
+	next, err := infra.NextRun(job.CronExpr, time.Now())
+	if err != nil {
+		return InternalServerErrorResponse(c, "Failed to compute next run", err)
+	}
+
+	return SuccessResponse(c, map[string]interface{}{
+		"id":       job.ID,
+		"next_run": next,
+	})
+}
@@ -15,13 +15,15 @@ import (
 
 // AuthHandler handles authentication-related requests
 type AuthHandler struct {
-	userRepo domain.UserRepository
+	userRepo    domain.UserRepository
+	sessionRepo domain.SessionRepository
 }
 
 // NewAuthHandler creates a new AuthHandler
-func NewAuthHandler(userRepo domain.UserRepository) *AuthHandler {
+func NewAuthHandler(userRepo domain.UserRepository, sessionRepo domain.SessionRepository) *AuthHandler {
 	return &AuthHandler{
-		userRepo: userRepo,
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
 	}
 }
 
@@ -33,8 +35,73 @@ type LoginRequest struct {
 
 // LoginResponse represents the login response
 type LoginResponse struct {
-	Token string      `json:"token"`
-	User  *UserOutput `json:"user"`
+	Token        string      `json:"token"`
+	RefreshToken string      `json:"refresh_token"`
+	User         *UserOutput `json:"user"`
+}
+
+// RefreshRequest represents the refresh request payload
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshResponse represents the refresh response
+type RefreshResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// issueSession generates an access+refresh token pair for user, persists the
+// refresh token's session row, and sets both as HTTP-only cookies. Returns
+// the raw access and refresh tokens for callers that also need them in the
+// JSON response body (e.g. non-browser clients).
+func (h *AuthHandler) issueSession(c echo.Context, ctx context.Context, user *domain.User) (accessToken, refreshToken string, err error) {
+	sessionID := uuid.New()
+
+	accessToken, claims, err := middleware.GenerateAccessToken(user.ID, user.Role, sessionID)
+	if err != nil {
+		return "", "", err
+	}
+
+	refreshToken, err = middleware.GenerateRefreshToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	now := time.Now()
+	session := &domain.Session{
+		ID:               sessionID,
+		UserID:           user.ID,
+		RefreshTokenHash: middleware.HashRefreshToken(refreshToken),
+		UserAgent:        c.Request().UserAgent(),
+		IP:               c.RealIP(),
+		ExpiresAt:        now.Add(middleware.RefreshTokenTTL),
+		CreatedAt:        now,
+	}
+	if err := h.sessionRepo.Create(ctx, session); err != nil {
+		return "", "", err
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     "token",
+		Value:    accessToken,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   false, // Set to true in production with HTTPS
+		SameSite: http.SameSiteStrictMode,
+		Expires:  claims.ExpiresAt.Time,
+	})
+	c.SetCookie(&http.Cookie{
+		Name:     "refresh_token",
+		Value:    refreshToken,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   false, // Set to true in production with HTTPS
+		SameSite: http.SameSiteStrictMode,
+		Expires:  session.ExpiresAt,
+	})
+
+	return accessToken, refreshToken, nil
 }
 
 // UserOutput represents user data in API responses
@@ -73,27 +140,16 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		return UnauthorizedResponse(c, "Invalid credentials")
 	}
 
-	// Generate JWT token
-	token, err := middleware.GenerateJWT(user.ID, user.Role)
+	// Generate access+refresh token pair and persist the session
+	token, refreshToken, err := h.issueSession(c, ctx, user)
 	if err != nil {
 		return InternalServerErrorResponse(c, "Failed to generate token", err)
 	}
 
-	// Set HTTP-only cookie
-	cookie := &http.Cookie{
-		Name:     "token",
-		Value:    token,
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   false, // Set to true in production with HTTPS
-		SameSite: http.SameSiteStrictMode,
-		MaxAge:   86400, // 24 hours
-	}
-	c.SetCookie(cookie)
-
 	// Return response
 	return SuccessResponse(c, LoginResponse{
-		Token: token,
+		Token:        token,
+		RefreshToken: refreshToken,
 		User: &UserOutput{
 			ID:           user.ID.String(),
 			Username:     user.Username,
@@ -104,23 +160,131 @@ func (h *AuthHandler) Login(c echo.Context) error {
 	})
 }
 
-// Logout handles user logout
+// Refresh exchanges a still-valid refresh token for a new access+refresh
+// pair. The presented refresh token is rotated (revoked and replaced) on
+// every call, one-time-use, so a stolen-and-replayed refresh token is only
+// ever usable once before the legitimate holder's next refresh fails loudly.
+// POST /api/auth/refresh
+func (h *AuthHandler) Refresh(c echo.Context) error {
+	var req RefreshRequest
+	_ = c.Bind(&req)
+
+	rawToken := req.RefreshToken
+	if rawToken == "" {
+		if cookie, err := c.Cookie("refresh_token"); err == nil {
+			rawToken = cookie.Value
+		}
+	}
+	if rawToken == "" {
+		return UnauthorizedResponse(c, "Missing refresh token")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	session, err := h.sessionRepo.GetByTokenHash(ctx, middleware.HashRefreshToken(rawToken))
+	if err != nil {
+		return UnauthorizedResponse(c, "Invalid refresh token")
+	}
+
+	if !session.IsActive(time.Now()) {
+		return UnauthorizedResponse(c, "Refresh token expired or revoked")
+	}
+
+	user, err := h.userRepo.GetByID(ctx, session.UserID)
+	if err != nil {
+		return UnauthorizedResponse(c, "Invalid refresh token")
+	}
+
+	// Rotate: revoke the presented token before issuing its replacement so a
+	// concurrent replay can't both succeed.
+	if err := h.sessionRepo.Revoke(ctx, session.ID, time.Now()); err != nil {
+		return InternalServerErrorResponse(c, "Failed to rotate refresh token", err)
+	}
+
+	token, newRefreshToken, err := h.issueSession(c, ctx, user)
+	if err != nil {
+		return InternalServerErrorResponse(c, "Failed to generate token", err)
+	}
+
+	return SuccessResponse(c, RefreshResponse{
+		Token:        token,
+		RefreshToken: newRefreshToken,
+	})
+}
+
+// Logout handles user logout, revoking the current access token's jti and
+// the refresh token's session so both are unusable immediately rather than
+// just clearing the client's cookies.
 // POST /api/auth/logout
 func (h *AuthHandler) Logout(c echo.Context) error {
-	// Clear the cookie
-	cookie := &http.Cookie{
-		Name:     "token",
-		Value:    "",
-		Path:     "/",
-		HttpOnly: true,
-		MaxAge:   -1, // Delete cookie
+	if jti, err := middleware.GetJTI(c); err == nil {
+		middleware.RevokeJTI(jti, time.Now().Add(middleware.AccessTokenTTL))
 	}
-	c.SetCookie(cookie)
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	// The access token's own SessionID is the authoritative way to find the
+	// session to revoke; fall back to hashing the refresh_token cookie for
+	// tokens minted before SessionID existed.
+	if sessionID, err := middleware.GetSessionID(c); err == nil && sessionID != uuid.Nil {
+		_ = h.sessionRepo.Revoke(ctx, sessionID, time.Now())
+	} else if cookie, err := c.Cookie("refresh_token"); err == nil {
+		if session, err := h.sessionRepo.GetByTokenHash(ctx, middleware.HashRefreshToken(cookie.Value)); err == nil {
+			_ = h.sessionRepo.Revoke(ctx, session.ID, time.Now())
+		}
+	}
+
+	clearAuthCookies(c)
 
 	// Redirect to login page
 	return c.Redirect(http.StatusFound, "/login")
 }
 
+// LogoutAll revokes every refresh-token session belonging to the
+// authenticated user, e.g. for a "log out of all devices" action.
+// POST /api/auth/logout-all
+func (h *AuthHandler) LogoutAll(c echo.Context) error {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	if jti, err := middleware.GetJTI(c); err == nil {
+		middleware.RevokeJTI(jti, time.Now().Add(middleware.AccessTokenTTL))
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.sessionRepo.RevokeAllForUser(ctx, userID, time.Now()); err != nil {
+		return InternalServerErrorResponse(c, "Failed to revoke sessions", err)
+	}
+
+	clearAuthCookies(c)
+
+	return SuccessResponse(c, map[string]string{"message": "Logged out of all devices"})
+}
+
+// clearAuthCookies deletes the access and refresh token cookies.
+func clearAuthCookies(c echo.Context) {
+	c.SetCookie(&http.Cookie{
+		Name:     "token",
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		MaxAge:   -1,
+	})
+	c.SetCookie(&http.Cookie{
+		Name:     "refresh_token",
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		MaxAge:   -1,
+	})
+}
+
 // Register handles user registration (for future use)
 // POST /api/auth/register
 func (h *AuthHandler) Register(c echo.Context) error {
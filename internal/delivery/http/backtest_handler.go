@@ -0,0 +1,158 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"neurotrade/internal/backtest"
+	"neurotrade/internal/service"
+)
+
+// BacktestHandler runs strategy backtests against historical klines, so an
+// operator can A/B a StrategyPreset before flipping is_active via
+// AdminHandler.SetActiveStrategy.
+type BacktestHandler struct {
+	runStore     *backtest.RunStore
+	binanceStore *backtest.BinanceKlineStore
+}
+
+// NewBacktestHandler creates a new BacktestHandler
+func NewBacktestHandler(runStore *backtest.RunStore) *BacktestHandler {
+	return &BacktestHandler{
+		runStore:     runStore,
+		binanceStore: backtest.NewBinanceKlineStore("1m"),
+	}
+}
+
+// TriggerBacktestRequest is the request body for TriggerBacktest
+type TriggerBacktestRequest struct {
+	PresetID        int      `json:"preset_id"`
+	Symbols         []string `json:"symbols"`
+	From            string   `json:"from"` // RFC3339
+	To              string   `json:"to"`   // RFC3339
+	StartingBalance float64  `json:"starting_balance"`
+}
+
+// TriggerBacktest starts a backtest run in the background against
+// BinanceKlineStore and returns the run's ID immediately so the caller can
+// poll GetBacktestRun for results; the row starts in RunStatusRunning and is
+// updated to COMPLETED/FAILED once the replay finishes.
+// POST /api/admin/backtest
+func (h *BacktestHandler) TriggerBacktest(c echo.Context) error {
+	var req TriggerBacktestRequest
+	if err := c.Bind(&req); err != nil {
+		return BadRequestResponse(c, "Invalid request payload")
+	}
+
+	if req.PresetID <= 0 {
+		return BadRequestResponse(c, "Invalid preset_id")
+	}
+	if len(req.Symbols) == 0 {
+		return BadRequestResponse(c, "At least one symbol is required")
+	}
+	if req.StartingBalance <= 0 {
+		return BadRequestResponse(c, "starting_balance must be positive")
+	}
+
+	from, err := time.Parse(time.RFC3339, req.From)
+	if err != nil {
+		return BadRequestResponse(c, "Invalid from (expected RFC3339)")
+	}
+	to, err := time.Parse(time.RFC3339, req.To)
+	if err != nil {
+		return BadRequestResponse(c, "Invalid to (expected RFC3339)")
+	}
+	if !to.After(from) {
+		return BadRequestResponse(c, "to must be after from")
+	}
+
+	const account = "backtest"
+	cfg := &backtest.Config{
+		StartTime:    from,
+		EndTime:      to,
+		Symbols:      req.Symbols,
+		Balances:     map[string]float64{account: req.StartingBalance},
+		MakerFeeRate: 0.0002,
+		TakerFeeRate: 0.0005,
+	}
+
+	run := &backtest.Run{
+		ID:              uuid.New(),
+		PresetID:        req.PresetID,
+		Symbols:         req.Symbols,
+		StartTime:       from,
+		EndTime:         to,
+		StartingBalance: req.StartingBalance,
+		Status:          backtest.RunStatusRunning,
+		CreatedAt:       time.Now(),
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+	if err := h.runStore.Create(ctx, run); err != nil {
+		return InternalServerErrorResponse(c, "Failed to create backtest run", err)
+	}
+
+	// Run the replay in the background -- a multi-symbol, multi-month
+	// replay can take well beyond a reasonable HTTP timeout -- and persist
+	// the finished Report (or the error) back onto the same row.
+	go h.runAsync(run.ID, cfg, account)
+
+	return SuccessResponse(c, map[string]interface{}{
+		"run_id": run.ID,
+		"status": run.Status,
+	})
+}
+
+// runAsync replays cfg's range through a fresh Engine using the reference
+// SMA-crossover SignalSource, then persists the result against runID.
+func (h *BacktestHandler) runAsync(runID uuid.UUID, cfg *backtest.Config, account string) {
+	ctx := context.Background()
+
+	riskModel := service.NewATRRiskModel("15m", 14, 1.5, 3.0, 0.002)
+	signalSource := backtest.SMACrossoverSignalSource(account, 10, 30, 0.02, 1.0, cfg.Balances[account], riskModel)
+
+	engine := backtest.NewEngine(cfg, h.binanceStore, signalSource)
+
+	report, err := engine.Run(ctx)
+	if err != nil {
+		log.Printf("ERROR: Backtest run %s failed: %v", runID, err)
+		if err := h.runStore.Fail(ctx, runID, err); err != nil {
+			log.Printf("ERROR: Backtest run %s: failed to persist failure: %v", runID, err)
+		}
+		return
+	}
+
+	if err := h.runStore.Complete(ctx, runID, report); err != nil {
+		log.Printf("ERROR: Backtest run %s: failed to persist report: %v", runID, err)
+		return
+	}
+
+	log.Printf("[OK] Backtest run %s complete: %d trades, win rate %.1f%%, sharpe %.2f",
+		runID, report.TotalTrades, report.WinRate*100, report.Sharpe)
+}
+
+// GetBacktestRun retrieves a previously triggered run's status and (once
+// finished) its report
+// GET /api/admin/backtest/:id
+func (h *BacktestHandler) GetBacktestRun(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return BadRequestResponse(c, "Invalid run id")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	run, err := h.runStore.GetByID(ctx, id)
+	if err != nil {
+		return NotFoundResponse(c, fmt.Sprintf("Backtest run not found: %s", id))
+	}
+
+	return SuccessResponse(c, run)
+}
@@ -0,0 +1,25 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"neurotrade/internal/reliability"
+)
+
+// DependencyHandler exposes the health of external dependencies guarded by
+// a reliability.CircuitBreaker (Python AI engine, Telegram, ...)
+type DependencyHandler struct{}
+
+// NewDependencyHandler creates a new DependencyHandler
+func NewDependencyHandler() *DependencyHandler {
+	return &DependencyHandler{}
+}
+
+// GetDependencyHealth returns every registered circuit breaker's state,
+// last error, and rolling success rate
+// GET /health/deps
+func (h *DependencyHandler) GetDependencyHealth(c echo.Context) error {
+	return SuccessResponse(c, map[string]interface{}{
+		"dependencies": reliability.AllStatuses(),
+	})
+}
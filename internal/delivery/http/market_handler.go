@@ -0,0 +1,72 @@
+package http
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"neurotrade/internal/repository"
+	"neurotrade/internal/service"
+)
+
+// MarketHandler exposes MarketPriceService's kline series over HTTP, so
+// both the frontend's charting and the Python AI bridge can pull the same
+// OHLCV series instead of the latter fetching Binance directly. Every fetch
+// is persisted through klineRepo, building up a rolling window that
+// repository.KlineRepository.GetRange serves. FetchKlinesRange-driven
+// backtests and ReviewService's ExitRule chain do not read that window and
+// fetch from the exchange themselves.
+type MarketHandler struct {
+	priceService *service.MarketPriceService
+	klineRepo    *repository.KlineRepository
+}
+
+// NewMarketHandler creates a new MarketHandler
+func NewMarketHandler(priceService *service.MarketPriceService, klineRepo *repository.KlineRepository) *MarketHandler {
+	return &MarketHandler{priceService: priceService, klineRepo: klineRepo}
+}
+
+const defaultKlinesLimit = 200
+
+// GetKlines returns up to limit recent candles for symbol/interval, oldest
+// first, and persists them to klineRepo for later range queries.
+// GET /api/market/klines?symbol=&interval=&limit=
+func (h *MarketHandler) GetKlines(c echo.Context) error {
+	symbol := c.QueryParam("symbol")
+	if symbol == "" {
+		return BadRequestResponse(c, "symbol is required")
+	}
+	symbol = strings.ToUpper(symbol)
+
+	interval := c.QueryParam("interval")
+	if interval == "" {
+		return BadRequestResponse(c, "interval is required")
+	}
+
+	limit := defaultKlinesLimit
+	if raw := c.QueryParam("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			return BadRequestResponse(c, "Invalid limit")
+		}
+		limit = parsed
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 10*time.Second)
+	defer cancel()
+
+	klines, err := h.priceService.FetchKlines(ctx, symbol, interval, limit)
+	if err != nil {
+		return BadRequestResponse(c, err.Error())
+	}
+
+	if err := h.klineRepo.Upsert(ctx, symbol, interval, klines); err != nil {
+		log.Printf("WARNING: Failed to cache klines for %s/%s: %v", symbol, interval, err)
+	}
+
+	return SuccessResponse(c, klines)
+}
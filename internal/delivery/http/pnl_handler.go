@@ -0,0 +1,94 @@
+package http
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"neurotrade/internal/middleware"
+	"neurotrade/internal/service"
+	"neurotrade/internal/utils"
+)
+
+// PnLHandler exposes service.PnLService's FIFO-lot PnL reports over HTTP.
+type PnLHandler struct {
+	pnlService *service.PnLService
+}
+
+// NewPnLHandler creates a new PnLHandler
+func NewPnLHandler(pnlService *service.PnLService) *PnLHandler {
+	return &PnLHandler{pnlService: pnlService}
+}
+
+// pnlWindow parses the optional from/to query params (RFC3339), defaulting
+// to the trailing 30 days ending now, in Jakarta time to match this
+// codebase's day-bucketing convention (see utils.GetJakartaTime).
+func pnlWindow(c echo.Context) (from, to time.Time, err error) {
+	to = utils.GetJakartaTime()
+	from = to.AddDate(0, 0, -30)
+
+	if raw := c.QueryParam("from"); raw != "" {
+		from, err = time.ParseInLocation(time.RFC3339, raw, utils.GetLocation())
+		if err != nil {
+			return from, to, err
+		}
+	}
+	if raw := c.QueryParam("to"); raw != "" {
+		to, err = time.ParseInLocation(time.RFC3339, raw, utils.GetLocation())
+		if err != nil {
+			return from, to, err
+		}
+	}
+
+	return from, to, nil
+}
+
+// GetMyPnL returns the current user's PnL report.
+// GET /api/user/pnl?symbol=&from=&to=
+func (h *PnLHandler) GetMyPnL(c echo.Context) error {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	from, to, err := pnlWindow(c)
+	if err != nil {
+		return BadRequestResponse(c, "Invalid from/to (expected RFC3339)")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 10*time.Second)
+	defer cancel()
+
+	report, err := h.pnlService.GenerateReport(ctx, userID, c.QueryParam("symbol"), from, to)
+	if err != nil {
+		return InternalServerErrorResponse(c, "Failed to generate PnL report", err)
+	}
+
+	return SuccessResponse(c, report)
+}
+
+// GetUserPnL returns an arbitrary user's PnL report, for admin support/audit.
+// GET /api/admin/pnl?user_id=&symbol=&from=&to=
+func (h *PnLHandler) GetUserPnL(c echo.Context) error {
+	userID, err := uuid.Parse(c.QueryParam("user_id"))
+	if err != nil {
+		return BadRequestResponse(c, "Invalid or missing user_id")
+	}
+
+	from, to, err := pnlWindow(c)
+	if err != nil {
+		return BadRequestResponse(c, "Invalid from/to (expected RFC3339)")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 10*time.Second)
+	defer cancel()
+
+	report, err := h.pnlService.GenerateReport(ctx, userID, c.QueryParam("symbol"), from, to)
+	if err != nil {
+		return InternalServerErrorResponse(c, "Failed to generate PnL report", err)
+	}
+
+	return SuccessResponse(c, report)
+}
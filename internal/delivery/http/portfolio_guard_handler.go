@@ -0,0 +1,102 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"neurotrade/internal/middleware"
+	"neurotrade/internal/portfolioguard"
+)
+
+// PortfolioGuardHandler handles portfolio exposure-cap reporting
+type PortfolioGuardHandler struct {
+	portfolioGuard *portfolioguard.Service
+}
+
+// NewPortfolioGuardHandler creates a new PortfolioGuardHandler
+func NewPortfolioGuardHandler(portfolioGuard *portfolioguard.Service) *PortfolioGuardHandler {
+	return &PortfolioGuardHandler{
+		portfolioGuard: portfolioGuard,
+	}
+}
+
+// GetExposure renders an HTML fragment of the current admin's exposure
+// utilization against MAX_SYMBOL_EXPOSURE_PCT/MAX_SIDE_EXPOSURE_PCT/
+// MAX_CORRELATED_EXPOSURE_PCT as HTMX-style bars, one per symbol/side/
+// correlation-group bucket currently holding notional.
+// GET /api/admin/portfolio/exposure
+func (h *PortfolioGuardHandler) GetExposure(c echo.Context) error {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 10*time.Second)
+	defer cancel()
+
+	buckets, err := h.portfolioGuard.Snapshot(ctx, userID)
+	if err != nil {
+		return InternalServerErrorResponse(c, "Failed to compute portfolio exposure", err)
+	}
+
+	return c.HTML(http.StatusOK, buildExposureHTML(buckets))
+}
+
+// buildExposureHTML renders one utilization bar per bucket, capped buckets
+// shown red past 100% of their cap and green otherwise. An uncapped bucket
+// (CapPct <= 0) still reports its raw notional but draws no bar.
+func buildExposureHTML(buckets []portfolioguard.Bucket) string {
+	if len(buckets) == 0 {
+		return `
+			<div class="inline-block bg-white border-2 border-black text-black font-bold px-6 py-3 shadow-[4px_4px_0px_0px_#000]">
+				No open exposure
+			</div>
+		`
+	}
+
+	html := ""
+	for _, b := range buckets {
+		if b.CapPct <= 0 {
+			html += fmt.Sprintf(`
+			<div class="mb-4">
+				<div class="flex justify-between mb-1">
+					<span class="font-bold text-black uppercase text-sm">%s: %s</span>
+					<span class="font-bold text-black text-sm">%.2f notional (uncapped)</span>
+				</div>
+			</div>
+		`, b.Kind, b.Label, b.Notional)
+			continue
+		}
+
+		pctOfCap := b.UsedPct / b.CapPct * 100
+		if pctOfCap > 100 {
+			pctOfCap = 100
+		}
+		if pctOfCap < 0 {
+			pctOfCap = 0
+		}
+
+		barBgClass := "bg-[#51cf66]"
+		if b.UsedPct > b.CapPct {
+			barBgClass = "bg-[#ff6b6b]"
+		}
+
+		html += fmt.Sprintf(`
+			<div class="mb-4">
+				<div class="flex justify-between mb-1">
+					<span class="font-bold text-black uppercase text-sm">%s: %s</span>
+					<span class="font-bold text-black text-sm">%.2f%% / %.2f%% cap</span>
+				</div>
+				<div class="w-full bg-white border-2 border-black h-4">
+					<div class="%s h-full border-r-2 border-black" style="width: %.2f%%"></div>
+				</div>
+			</div>
+		`, b.Kind, b.Label, b.UsedPct, b.CapPct, barBgClass, pctOfCap)
+	}
+
+	return html
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"neurotrade/internal/middleware"
+	"neurotrade/internal/rebalance"
+)
+
+// RebalanceHandler handles portfolio rebalancing requests
+type RebalanceHandler struct {
+	rebalanceService *rebalance.Service
+}
+
+// NewRebalanceHandler creates a new RebalanceHandler
+func NewRebalanceHandler(rebalanceService *rebalance.Service) *RebalanceHandler {
+	return &RebalanceHandler{
+		rebalanceService: rebalanceService,
+	}
+}
+
+// TriggerRebalance computes and immediately executes the rebalance plan for
+// the current user
+// POST /api/user/rebalance
+func (h *RebalanceHandler) TriggerRebalance(c echo.Context) error {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 30*time.Second)
+	defer cancel()
+
+	actions, err := h.rebalanceService.Execute(ctx, userID)
+	if err != nil {
+		return InternalServerErrorResponse(c, "Failed to execute rebalance", err)
+	}
+
+	return SuccessResponse(c, map[string]interface{}{
+		"actions": actions,
+		"count":   len(actions),
+	})
+}
+
+// GetRebalancePlan previews the rebalance actions for the current user
+// without executing them
+// GET /api/user/rebalance/plan
+func (h *RebalanceHandler) GetRebalancePlan(c echo.Context) error {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 10*time.Second)
+	defer cancel()
+
+	actions, err := h.rebalanceService.Plan(ctx, userID)
+	if err != nil {
+		return InternalServerErrorResponse(c, "Failed to compute rebalance plan", err)
+	}
+
+	return SuccessResponse(c, map[string]interface{}{
+		"actions": actions,
+		"count":   len(actions),
+	})
+}
@@ -3,15 +3,22 @@ package http
 import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	custommiddleware "neurotrade/internal/middleware"
 )
 
 // RouterConfig holds all dependencies for routing
 type RouterConfig struct {
-	AuthHandler  *AuthHandler
-	UserHandler  *UserHandler
-	AdminHandler *AdminHandler
+	AuthHandler           *AuthHandler
+	UserHandler           *UserHandler
+	AdminHandler          *AdminHandler
+	RebalanceHandler      *RebalanceHandler
+	DependencyHandler     *DependencyHandler
+	PortfolioGuardHandler *PortfolioGuardHandler
+	BacktestHandler       *BacktestHandler
+	MarketHandler         *MarketHandler
+	PnLHandler            *PnLHandler
 }
 
 // SetupRoutes configures all HTTP routes
@@ -45,24 +52,52 @@ func SetupRoutes(e *echo.Echo, config *RouterConfig) {
 		})
 	})
 
+	// Dependency health: each circuit breaker's state, last error, and
+	// rolling success rate (Python AI engine, Telegram, ...)
+	e.GET("/health/deps", config.DependencyHandler.GetDependencyHealth)
+
+	// Prometheus scrape target for reliability.AICallTotal/SignalSavedTotal
+	// and the breaker-state gauges
+	e.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
+
 	// API group
 	api := e.Group("/api")
 
+	// Market data routes (public) -- klines are non-sensitive public market
+	// data, pulled by both the frontend's charting and the Python AI bridge.
+	market := api.Group("/market")
+	{
+		market.GET("/klines", config.MarketHandler.GetKlines)
+	}
+
 	// Auth routes (public)
 	auth := api.Group("/auth")
 	{
 		auth.POST("/login", config.AuthHandler.Login)
-		auth.POST("/logout", config.AuthHandler.Logout)
+		auth.POST("/refresh", config.AuthHandler.Refresh)
 		auth.POST("/register", config.AuthHandler.Register)
 	}
 
+	// Logout routes require a valid access token so the handler can revoke
+	// its jti and resolve the authenticated user for logout-all.
+	authProtected := api.Group("/auth", custommiddleware.AuthMiddleware)
+	{
+		authProtected.POST("/logout", config.AuthHandler.Logout)
+		authProtected.POST("/logout-all", config.AuthHandler.LogoutAll)
+	}
+
 	// User routes (protected with AuthMiddleware)
 	user := api.Group("/user", custommiddleware.AuthMiddleware)
 	{
 		user.GET("/me", config.UserHandler.GetMe)
 		user.POST("/mode/toggle", config.UserHandler.ToggleMode)
+		user.PUT("/position-mode", config.UserHandler.SetPositionMode)
 		user.GET("/positions", config.UserHandler.GetPositions)
 		user.POST("/panic-button", config.UserHandler.PanicButton)
+		user.GET("/stats", config.UserHandler.GetStats)
+		user.POST("/rebalance", config.RebalanceHandler.TriggerRebalance)
+		user.GET("/rebalance/plan", config.RebalanceHandler.GetRebalancePlan)
+		user.GET("/pnl", config.PnLHandler.GetMyPnL)
 	}
 
 	// Admin routes (protected with Auth + Admin middleware)
@@ -72,6 +107,16 @@ func SetupRoutes(e *echo.Echo, config *RouterConfig) {
 		admin.PUT("/strategies/active", config.AdminHandler.SetActiveStrategy)
 		admin.GET("/system/health", config.AdminHandler.GetSystemHealth)
 		admin.GET("/statistics", config.AdminHandler.GetStatistics)
-		admin.POST("/market-scan/trigger", config.AdminHandler.TriggerMarketScan)
+		admin.GET("/portfolio/exposure", config.PortfolioGuardHandler.GetExposure)
+		admin.POST("/backtest", config.BacktestHandler.TriggerBacktest)
+		admin.GET("/backtest/:id", config.BacktestHandler.GetBacktestRun)
+		admin.GET("/pnl", config.PnLHandler.GetUserPnL)
+
+		admin.GET("/scheduled-jobs", config.AdminHandler.ListScheduledJobs)
+		admin.POST("/scheduled-jobs", config.AdminHandler.CreateScheduledJob)
+		admin.PUT("/scheduled-jobs/:id", config.AdminHandler.UpdateScheduledJob)
+		admin.PUT("/scheduled-jobs/:id/enabled", config.AdminHandler.SetScheduledJobEnabled)
+		admin.POST("/scheduled-jobs/:id/trigger", config.AdminHandler.TriggerScheduledJob)
+		admin.GET("/scheduled-jobs/:id/next-run", config.AdminHandler.GetScheduledJobNextRun)
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
 	"neurotrade/internal/domain"
@@ -13,25 +14,42 @@ import (
 
 // UserHandler handles user-related requests
 type UserHandler struct {
-	userRepo       domain.UserRepository
-	positionRepo   domain.PaperPositionRepository
-	tradingService interface {
+	userRepo         domain.UserRepository
+	positionRepo     domain.PaperPositionRepository
+	realPositionRepo domain.PositionRepository
+	tradingService   interface {
 		CloseAllPositions(ctx context.Context, userID string) error
 	}
+	realBroker interface {
+		PanicCloseAll(ctx context.Context, userID uuid.UUID) error
+	}
+	statsService interface {
+		GetStats(ctx context.Context, userID uuid.UUID, symbol string, days int) (*domain.ProfitStats, []*domain.EquityPoint, error)
+	}
 }
 
 // NewUserHandler creates a new UserHandler
 func NewUserHandler(
 	userRepo domain.UserRepository,
 	positionRepo domain.PaperPositionRepository,
+	realPositionRepo domain.PositionRepository,
 	tradingService interface {
 		CloseAllPositions(ctx context.Context, userID string) error
 	},
+	realBroker interface {
+		PanicCloseAll(ctx context.Context, userID uuid.UUID) error
+	},
+	statsService interface {
+		GetStats(ctx context.Context, userID uuid.UUID, symbol string, days int) (*domain.ProfitStats, []*domain.EquityPoint, error)
+	},
 ) *UserHandler {
 	return &UserHandler{
-		userRepo:       userRepo,
-		positionRepo:   positionRepo,
-		tradingService: tradingService,
+		userRepo:         userRepo,
+		positionRepo:     positionRepo,
+		realPositionRepo: realPositionRepo,
+		tradingService:   tradingService,
+		realBroker:       realBroker,
+		statsService:     statsService,
 	}
 }
 
@@ -104,6 +122,53 @@ func (h *UserHandler) ToggleMode(c echo.Context) error {
 	})
 }
 
+// SetPositionModeRequest represents the position mode change request
+type SetPositionModeRequest struct {
+	Mode string `json:"mode"` // "ONE_WAY" or "HEDGE"
+}
+
+// SetPositionMode switches a user between Binance Futures one-way and hedge
+// (dual-side) position mode, mirroring Binance's own constraint that this can
+// only change while the user has no open REAL positions.
+// PUT /api/user/position-mode
+func (h *UserHandler) SetPositionMode(c echo.Context) error {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	var req SetPositionModeRequest
+	if err := c.Bind(&req); err != nil {
+		return BadRequestResponse(c, "Invalid request payload")
+	}
+
+	if req.Mode != domain.PositionModeOneWay && req.Mode != domain.PositionModeHedge {
+		return BadRequestResponse(c, "Invalid mode. Must be 'ONE_WAY' or 'HEDGE'")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	positions, err := h.realPositionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return InternalServerErrorResponse(c, "Failed to check open positions", err)
+	}
+	for _, p := range positions {
+		if p.Status == domain.StatusOpen {
+			return BadRequestResponse(c, "Cannot change position mode while a position is open")
+		}
+	}
+
+	if err := h.userRepo.UpdatePositionMode(ctx, userID, req.Mode); err != nil {
+		return InternalServerErrorResponse(c, "Failed to update position mode", err)
+	}
+
+	return SuccessResponse(c, map[string]interface{}{
+		"position_mode": req.Mode,
+		"message":       "Position mode updated successfully",
+	})
+}
+
 // PositionOutput represents a position in API responses
 type PositionOutput struct {
 	ID         string   `json:"id"`
@@ -175,12 +240,176 @@ func (h *UserHandler) GetPositions(c echo.Context) error {
 		})
 	}
 
-	// REAL mode: fetch from Binance API (Phase 5 - not implemented yet)
+	// REAL mode
+	positions, err := h.realPositionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return InternalServerErrorResponse(c, "Failed to get positions", err)
+	}
+
+	if c.QueryParam("view") == "grouped" {
+		return SuccessResponse(c, map[string]interface{}{
+			"mode":      user.Mode,
+			"positions": groupPositionsBySignal(positions),
+			"count":     len(positions),
+		})
+	}
+
+	output := make([]PositionOutput, 0, len(positions))
+	for _, pos := range positions {
+		closedAt := ""
+		if pos.ClosedAt != nil {
+			closedAt = pos.ClosedAt.Format(time.RFC3339)
+		}
+
+		output = append(output, PositionOutput{
+			ID:         pos.ID.String(),
+			Symbol:     pos.Symbol,
+			Side:       pos.Side,
+			EntryPrice: pos.EntryPrice,
+			SLPrice:    pos.SLPrice,
+			TPPrice:    pos.TPPrice,
+			Size:       pos.Size,
+			ExitPrice:  pos.ExitPrice,
+			PnL:        pos.PnL,
+			Status:     pos.Status,
+			CreatedAt:  pos.CreatedAt.Format(time.RFC3339),
+			ClosedAt:   &closedAt,
+		})
+	}
+
 	return SuccessResponse(c, map[string]interface{}{
 		"mode":      user.Mode,
-		"positions": []interface{}{},
-		"count":     0,
-		"message":   "Real trading not implemented yet",
+		"positions": output,
+		"count":     len(output),
+	})
+}
+
+// GroupedPositionOutput collapses every scaled-entry layer sharing a
+// ParentSignalID into a single aggregate view: size-weighted average entry
+// price, summed size, and summed realized PnL across the layers.
+type GroupedPositionOutput struct {
+	Symbol        string  `json:"symbol"`
+	Side          string  `json:"side"`
+	LayerCount    int     `json:"layer_count"`
+	AvgEntryPrice float64 `json:"avg_entry_price"`
+	TotalSize     float64 `json:"total_size"`
+	TotalPnL      float64 `json:"total_pnl"`
+	Status        string  `json:"status"` // OPEN if any layer is still open, else the last layer's status
+	CreatedAt     string  `json:"created_at"`
+}
+
+// groupPositionsBySignal collapses layers sharing a ParentSignalID into one
+// GroupedPositionOutput each; positions with no ParentSignalID (not part of
+// a scaled entry) each form their own single-layer group.
+func groupPositionsBySignal(positions []*domain.Position) []GroupedPositionOutput {
+	type group struct {
+		layers []*domain.Position
+	}
+
+	order := make([]string, 0, len(positions))
+	groups := make(map[string]*group)
+
+	for _, pos := range positions {
+		key := pos.ID.String()
+		if pos.ParentSignalID != nil {
+			key = pos.ParentSignalID.String()
+		}
+
+		g, ok := groups[key]
+		if !ok {
+			g = &group{}
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.layers = append(g.layers, pos)
+	}
+
+	output := make([]GroupedPositionOutput, 0, len(order))
+	for _, key := range order {
+		layers := groups[key].layers
+
+		var totalSize, weightedEntry, totalPnL float64
+		open := false
+		last := layers[len(layers)-1]
+		for _, layer := range layers {
+			totalSize += layer.Size
+			weightedEntry += layer.Size * layer.EntryPrice
+			if layer.PnL != nil {
+				totalPnL += *layer.PnL
+			}
+			if layer.Status == domain.StatusOpen {
+				open = true
+			}
+		}
+
+		avgEntry := 0.0
+		if totalSize > 0 {
+			avgEntry = weightedEntry / totalSize
+		}
+
+		status := last.Status
+		if open {
+			status = domain.StatusOpen
+		}
+
+		output = append(output, GroupedPositionOutput{
+			Symbol:        last.Symbol,
+			Side:          last.Side,
+			LayerCount:    len(layers),
+			AvgEntryPrice: avgEntry,
+			TotalSize:     totalSize,
+			TotalPnL:      totalPnL,
+			Status:        status,
+			CreatedAt:     layers[0].CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return output
+}
+
+// statsPeriodToDays maps a ?period= query value to the day count GetStats
+// expects ("" and "all" both mean the all_time bucket).
+func statsPeriodToDays(period string) int {
+	switch period {
+	case "", "all":
+		return 0
+	case "7d":
+		return 7
+	case "30d":
+		return 30
+	case "90d":
+		return 90
+	default:
+		return 0
+	}
+}
+
+// GetStats returns a user's rolling profit stats and equity curve for an
+// optional symbol and period ("7d", "30d", "90d"; default/"all" is the
+// all_time bucket). Kept under /api/user (auth middleware resolves the
+// user) rather than a /users/:id path param, matching every other handler
+// in this file.
+// GET /api/user/stats
+func (h *UserHandler) GetStats(c echo.Context) error {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	symbol := c.QueryParam("symbol")
+	days := statsPeriodToDays(c.QueryParam("period"))
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 10*time.Second)
+	defer cancel()
+
+	stats, curve, err := h.statsService.GetStats(ctx, userID, symbol, days)
+	if err != nil {
+		return InternalServerErrorResponse(c, "Failed to get profit stats", err)
+	}
+
+	return SuccessResponse(c, map[string]interface{}{
+		"stats":        stats,
+		"equity_curve": curve,
 	})
 }
 
@@ -195,8 +424,19 @@ func (h *UserHandler) PanicButton(c echo.Context) error {
 	ctx, cancel := context.WithTimeout(c.Request().Context(), 30*time.Second)
 	defer cancel()
 
-	// Close all positions
-	if err := h.tradingService.CloseAllPositions(ctx, userID.String()); err != nil {
+	user, err := h.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return InternalServerErrorResponse(c, "Failed to get user", err)
+	}
+
+	// Close all positions. REAL mode closes scaled-entry layers atomically
+	// per signal via the broker; PAPER mode goes through the existing
+	// position-by-position trading service flow.
+	if user.Mode == domain.ModeReal {
+		if err := h.realBroker.PanicCloseAll(ctx, userID); err != nil {
+			return InternalServerErrorResponse(c, "Failed to close all positions", err)
+		}
+	} else if err := h.tradingService.CloseAllPositions(ctx, userID.String()); err != nil {
 		return InternalServerErrorResponse(c, "Failed to close all positions", err)
 	}
 
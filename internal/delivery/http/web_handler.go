@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"strings"
+	"time"
 
 	"neurotrade/internal/domain"
 	"neurotrade/internal/middleware"
@@ -20,12 +22,27 @@ type MarketPriceService interface {
 	GetPrice(ctx context.Context, symbol string) (float64, error)
 }
 
+// PositionEventSubscriber lets WebHandler subscribe to per-user
+// position-change notifications without depending on the concrete
+// service.PositionEventBus type. Nil disables the SSE stream's live-push
+// behavior, falling back to its keepalive-only cadence.
+type PositionEventSubscriber interface {
+	Subscribe(userID uuid.UUID) (ch chan struct{}, unsubscribe func())
+}
+
+// positionStreamKeepalive is how often HandlePositionsStream sends a
+// keepalive comment on an otherwise idle SSE connection, so proxies/load
+// balancers with shorter idle timeouts don't drop it.
+const positionStreamKeepalive = 15 * time.Second
+
 type WebHandler struct {
 	templates      *template.Template
 	userRepo       domain.UserRepository
 	positionRepo   domain.PaperPositionRepository
 	marketPriceSvc MarketPriceService
 	db             *pgxpool.Pool
+	eventBus       PositionEventSubscriber
+	sessionRepo    domain.SessionRepository
 }
 
 func NewWebHandler(
@@ -34,6 +51,8 @@ func NewWebHandler(
 	positionRepo domain.PaperPositionRepository,
 	db *pgxpool.Pool,
 	marketPriceSvc MarketPriceService,
+	eventBus PositionEventSubscriber,
+	sessionRepo domain.SessionRepository,
 ) *WebHandler {
 	return &WebHandler{
 		templates:      templates,
@@ -41,6 +60,8 @@ func NewWebHandler(
 		positionRepo:   positionRepo,
 		marketPriceSvc: marketPriceSvc,
 		db:             db,
+		eventBus:       eventBus,
+		sessionRepo:    sessionRepo,
 	}
 }
 
@@ -92,23 +113,54 @@ func (h *WebHandler) HandleLoginPost(c echo.Context) error {
 		return c.Redirect(http.StatusFound, "/login?error=Invalid+credentials")
 	}
 
-	// Generate JWT token
-	token, err := middleware.GenerateJWT(user.ID, user.Role)
+	sessionID := uuid.New()
+
+	// Generate access token
+	accessToken, claims, err := middleware.GenerateAccessToken(user.ID, user.Role, sessionID)
 	if err != nil {
 		return c.Redirect(http.StatusFound, "/login?error=Failed+to+generate+token")
 	}
 
-	// Set HTTP-only cookie
-	cookie := &http.Cookie{
+	// Generate and persist a refresh token so the dashboard session survives
+	// past the short-lived access token, mirroring AuthHandler.Login.
+	refreshToken, err := middleware.GenerateRefreshToken()
+	if err != nil {
+		return c.Redirect(http.StatusFound, "/login?error=Failed+to+generate+token")
+	}
+
+	now := time.Now()
+	session := &domain.Session{
+		ID:               sessionID,
+		UserID:           user.ID,
+		RefreshTokenHash: middleware.HashRefreshToken(refreshToken),
+		UserAgent:        c.Request().UserAgent(),
+		IP:               c.RealIP(),
+		ExpiresAt:        now.Add(middleware.RefreshTokenTTL),
+		CreatedAt:        now,
+	}
+	if err := h.sessionRepo.Create(ctx, session); err != nil {
+		return c.Redirect(http.StatusFound, "/login?error=Failed+to+generate+token")
+	}
+
+	// Set HTTP-only cookies
+	c.SetCookie(&http.Cookie{
 		Name:     "token",
-		Value:    token,
+		Value:    accessToken,
 		Path:     "/",
 		HttpOnly: true,
 		Secure:   false, // Set to true in production with HTTPS
 		SameSite: http.SameSiteStrictMode,
-		MaxAge:   86400, // 24 hours
-	}
-	c.SetCookie(cookie)
+		Expires:  claims.ExpiresAt.Time,
+	})
+	c.SetCookie(&http.Cookie{
+		Name:     "refresh_token",
+		Value:    refreshToken,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   false, // Set to true in production with HTTPS
+		SameSite: http.SameSiteStrictMode,
+		Expires:  session.ExpiresAt,
+	})
 
 	// Redirect to dashboard
 	return c.Redirect(http.StatusFound, "/dashboard")
@@ -170,9 +222,101 @@ func (h *WebHandler) HandlePositionsHTML(c echo.Context) error {
 		`)
 	}
 
+	html, err := h.buildPositionsHTML(c.Request().Context(), userID)
+	if err != nil {
+		return c.HTML(http.StatusInternalServerError, `
+			<tr>
+				<td colspan="8" class="py-8 text-center">
+					<div class="inline-block bg-[#ff6b6b] border-2 border-black text-white font-bold px-6 py-3 shadow-[4px_4px_0px_0px_#000]">
+						❌ Error loading positions
+					</div>
+				</td>
+			</tr>
+		`)
+	}
+
+	return c.HTML(http.StatusOK, html)
+}
+
+// GET /api/user/positions/stream - Server-Sent-Events stream of the live
+// positions fragment, replacing /api/user/positions/html's 5s HTMX poll.
+// Pushes a fresh fragment immediately on connect, then again whenever
+// BodyguardService's PositionEventBus notifies this user's positions changed
+// (a close or trailing-stop update), plus a keepalive comment every
+// positionStreamKeepalive so idle proxies don't drop the connection.
+func (h *WebHandler) HandlePositionsStream(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+
+	resp := c.Response()
+	resp.Header().Set("Content-Type", "text/event-stream")
+	resp.Header().Set("Cache-Control", "no-cache")
+	resp.Header().Set("Connection", "keep-alive")
+	resp.WriteHeader(http.StatusOK)
+
+	writeFragment := func() error {
+		html, err := h.buildPositionsHTML(c.Request().Context(), userID)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(resp, "event: positions\n%s\n\n", sseData(html)); err != nil {
+			return err
+		}
+		resp.Flush()
+		return nil
+	}
+
+	if err := writeFragment(); err != nil {
+		return err
+	}
+
+	var notify <-chan struct{}
+	if h.eventBus != nil {
+		ch, unsubscribe := h.eventBus.Subscribe(userID)
+		defer unsubscribe()
+		notify = ch
+	}
+
+	keepalive := time.NewTicker(positionStreamKeepalive)
+	defer keepalive.Stop()
+
+	ctx := c.Request().Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-notify:
+			if err := writeFragment(); err != nil {
+				return nil
+			}
+		case <-keepalive.C:
+			if _, err := fmt.Fprint(resp, ": keepalive\n\n"); err != nil {
+				return nil
+			}
+			resp.Flush()
+		}
+	}
+}
+
+// sseData prefixes every line of an SSE event's payload with "data: ", as
+// required by the spec for multi-line data (an HTML fragment here).
+func sseData(payload string) string {
+	lines := strings.Split(payload, "\n")
+	for i, line := range lines {
+		lines[i] = "data: " + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// buildPositionsHTML renders the open-positions table rows for userID (all
+// open positions if userID is an admin), shared by the HTMX polling endpoint
+// and the SSE stream.
+func (h *WebHandler) buildPositionsHTML(ctx context.Context, userID uuid.UUID) (string, error) {
 	// Check if user is admin
 	isAdmin := false
-	if user, err := h.userRepo.GetByID(c.Request().Context(), userID); err == nil {
+	if user, err := h.userRepo.GetByID(ctx, userID); err == nil {
 		isAdmin = user.Role == domain.RoleAdmin
 	}
 
@@ -181,22 +325,14 @@ func (h *WebHandler) HandlePositionsHTML(c echo.Context) error {
 
 	if isAdmin {
 		// Admin sees ALL open positions
-		allPositions, err = h.positionRepo.GetOpenPositions(c.Request().Context())
+		allPositions, err = h.positionRepo.GetOpenPositions(ctx)
 	} else {
 		// Regular user sees their own positions
-		allPositions, err = h.positionRepo.GetByUserID(c.Request().Context(), userID)
+		allPositions, err = h.positionRepo.GetByUserID(ctx, userID)
 	}
 
 	if err != nil {
-		return c.HTML(http.StatusInternalServerError, `
-			<tr>
-				<td colspan="8" class="py-8 text-center">
-					<div class="inline-block bg-[#ff6b6b] border-2 border-black text-white font-bold px-6 py-3 shadow-[4px_4px_0px_0px_#000]">
-						❌ Error loading positions
-					</div>
-				</td>
-			</tr>
-		`)
+		return "", err
 	}
 
 	// Filter for open positions only
@@ -208,7 +344,7 @@ func (h *WebHandler) HandlePositionsHTML(c echo.Context) error {
 	}
 
 	if len(positions) == 0 {
-		return c.HTML(http.StatusOK, `
+		return `
 			<tr>
 				<td colspan="8" class="py-12 text-center">
 					<div class="inline-block bg-white border-2 border-black text-black font-bold px-6 py-3 shadow-[4px_4px_0px_0px_#000]">
@@ -216,14 +352,14 @@ func (h *WebHandler) HandlePositionsHTML(c echo.Context) error {
 					</div>
 				</td>
 			</tr>
-		`)
+		`, nil
 	}
 
 	// Build HTML rows
 	html := ""
 	for _, pos := range positions {
 		// Get current price
-		currentPrice, err := h.marketPriceSvc.GetPrice(c.Request().Context(), pos.Symbol)
+		currentPrice, err := h.marketPriceSvc.GetPrice(ctx, pos.Symbol)
 		if err != nil {
 			currentPrice = pos.EntryPrice // Fallback to entry price
 		}
@@ -301,7 +437,7 @@ func (h *WebHandler) HandlePositionsHTML(c echo.Context) error {
 		)
 	}
 
-	return c.HTML(http.StatusOK, html)
+	return html, nil
 }
 
 // Helper: Load strategy presets from database
@@ -388,4 +524,5 @@ func RegisterWebRoutes(e *echo.Echo, handler *WebHandler, authMiddleware echo.Mi
 	// Protected routes (require authentication)
 	e.GET("/dashboard", handler.HandleDashboard, authMiddleware)
 	e.GET("/api/user/positions/html", handler.HandlePositionsHTML, authMiddleware)
+	e.GET("/api/user/positions/stream", handler.HandlePositionsStream, authMiddleware)
 }
@@ -0,0 +1,43 @@
+package domain
+
+// BinanceEndpoints holds the REST and websocket base URLs a Binance client
+// should target for a given trading mode.
+type BinanceEndpoints struct {
+	FuturesRESTURL string
+	FuturesWSURL   string
+}
+
+// binanceMainnetEndpoints and binanceTestnetEndpoints are the two base-URL
+// sets a user's Mode can resolve to. REAL always hits live USDT-M futures;
+// TESTNET hits Binance's futures testnet so signals can be validated against
+// real exchange plumbing without risking live funds.
+var (
+	binanceMainnetEndpoints = BinanceEndpoints{
+		FuturesRESTURL: "https://fapi.binance.com",
+		FuturesWSURL:   "wss://fstream.binance.com",
+	}
+	binanceTestnetEndpoints = BinanceEndpoints{
+		FuturesRESTURL: "https://testnet.binancefuture.com",
+		FuturesWSURL:   "wss://stream.binancefuture.com",
+	}
+)
+
+// BinanceEndpointsFor resolves the REST/WS base URLs a Binance client should
+// use for the given user Mode. PAPER has no live exchange calls, so it
+// resolves to the same endpoints as REAL for price-reference purposes.
+func BinanceEndpointsFor(mode string) BinanceEndpoints {
+	if mode == ModeTestnet {
+		return binanceTestnetEndpoints
+	}
+	return binanceMainnetEndpoints
+}
+
+// CredentialsFor returns the API key/secret pair a user holds for the given
+// Mode: the live Binance pair for REAL/PAPER, the separate testnet pair for
+// TESTNET.
+func (u *User) CredentialsFor(mode string) (apiKey, apiSecret string) {
+	if mode == ModeTestnet {
+		return u.BinanceTestnetAPIKey, u.BinanceTestnetAPISecret
+	}
+	return u.BinanceAPIKey, u.BinanceAPISecret
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "context"
+
+// EntryParams carries everything PythonBridge.ExecuteEntry needs to place a
+// real entry order with its SL/TP/trailing exits in one call to the Python
+// Engine.
+type EntryParams struct {
+	Symbol           string
+	Side             string // SideLong / SideShort
+	AmountUSDT       float64
+	Leverage         float64
+	APIKey           string
+	APISecret        string
+	SLPrice          float64
+	TPPrice          float64
+	TrailingCallback float64
+
+	// IdempotencyKey lets a retried ExecuteEntry call resolve to the same
+	// order instead of risking a duplicate fill. PythonBridge auto-generates
+	// a UUIDv7 when this is left empty.
+	IdempotencyKey string
+}
+
+// CloseParams carries everything PythonBridge.ExecuteClose needs to close a
+// real position, mirroring EntryParams for the close side of the same
+// idempotent-retry machinery.
+type CloseParams struct {
+	Symbol       string
+	Side         string // SideLong / SideShort
+	PositionSide string // PositionSideBoth / PositionSideLong / PositionSideShort
+	Quantity     float64
+	APIKey       string
+	APISecret    string
+
+	// IdempotencyKey lets a retried ExecuteClose call resolve to the same
+	// order instead of risking a duplicate fill. PythonBridge auto-generates
+	// a UUIDv7 when this is left empty.
+	IdempotencyKey string
+}
+
+// ExecutionIdempotencyRepository persists the (idempotency key -> execution
+// result) mapping for real-money ExecuteEntry/ExecuteClose calls, so a retry
+// after a process crash between attempts still resolves to the same order
+// instead of risking a duplicate fill.
+type ExecutionIdempotencyRepository interface {
+	// Get returns the previously persisted result for key, or nil if no
+	// attempt under key has completed yet.
+	Get(ctx context.Context, key string) (*ExecutionResult, error)
+
+	// Save persists result under key, called once right after the
+	// execution it resulted from first succeeds.
+	Save(ctx context.Context, key string, result *ExecutionResult) error
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// FundingRate is one settled 8-hour funding payment for a perpetual futures
+// symbol, as published by Binance's funding rate history endpoint.
+type FundingRate struct {
+	Symbol    string
+	Rate      float64
+	FundingAt time.Time
+}
+
+// FundingRateProvider fetches funding rates for a perpetual futures symbol,
+// so FundingService can accrue realized funding into an open Position's PnL.
+// Nil FundingRateProvider on a caller disables funding accrual, leaving PnL
+// computed from price action and trading fees alone.
+type FundingRateProvider interface {
+	// GetFundingRates returns every settled funding payment for symbol in
+	// [since, until), oldest first.
+	GetFundingRates(ctx context.Context, symbol string, since, until time.Time) ([]FundingRate, error)
+
+	// GetCurrentFundingRate returns the latest premium-index-based funding
+	// rate estimate for symbol, for a window that hasn't settled yet.
+	GetCurrentFundingRate(ctx context.Context, symbol string) (float64, error)
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HedgePosition is a smaller, opposite-side live position opened on a
+// separate "hedge" exchange account to partially offset a PaperPosition's
+// exposure, opt-in per user via User.HedgeEnabled (see
+// service.HedgeService). Side is always the opposite of the paper
+// position's Side.
+type HedgePosition struct {
+	ID              uuid.UUID  `json:"id"`
+	PaperPositionID uuid.UUID  `json:"paper_position_id"`
+	UserID          uuid.UUID  `json:"user_id"`
+	Symbol          string     `json:"symbol"`
+	Side            string     `json:"side"`
+	EntryPrice      float64    `json:"entry_price"`
+	Size            float64    `json:"size"`
+	ExitPrice       *float64   `json:"exit_price,omitempty"`
+	PnL             *float64   `json:"pnl,omitempty"`
+	Status          string     `json:"status"`
+	CreatedAt       time.Time  `json:"created_at"`
+	ClosedAt        *time.Time `json:"closed_at,omitempty"`
+}
+
+// HedgePositionRepository persists hedge legs opened against a user's paper
+// positions.
+type HedgePositionRepository interface {
+	// Save creates a new hedge leg
+	Save(ctx context.Context, hedge *HedgePosition) error
+
+	// GetByPaperPositionID retrieves the open hedge leg for a paper
+	// position, if any. Returns nil, nil if no hedge was opened for it.
+	GetByPaperPositionID(ctx context.Context, paperPositionID uuid.UUID) (*HedgePosition, error)
+
+	// Update updates a hedge leg's status, exit price, and PnL
+	Update(ctx context.Context, hedge *HedgePosition) error
+
+	// GetOpenHedges retrieves every open hedge leg, for the reconciliation
+	// loop to sum exposure by symbol
+	GetOpenHedges(ctx context.Context) ([]*HedgePosition, error)
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"math"
+)
+
+// InstrumentInfo holds one symbol's exchange-enforced precision rules,
+// fetched from exchangeInfo and cached by InstrumentRepository. Real orders
+// are rejected when price/quantity isn't an exact multiple of the symbol's
+// tick/step size, so VirtualBrokerService and the signal pipeline round
+// against these values before persisting a signal or submitting a REAL-mode
+// order.
+type InstrumentInfo struct {
+	Symbol           string
+	PriceTickSize    float64
+	QuantityStepSize float64
+	MinNotional      float64
+
+	// ContractSize is 1 for every USDT-M linear contract Binance lists today;
+	// kept as a field (rather than an assumed constant) so a COIN-M or
+	// inverse-contract InstrumentRepository can report otherwise.
+	ContractSize float64
+}
+
+// RoundDirection controls which way a price rounds to the nearest valid
+// tick when it doesn't already land on one.
+type RoundDirection int
+
+const (
+	// RoundNearest is for market orders, where only precision (not
+	// direction) matters since the exchange - not this rounding - decides
+	// the fill price.
+	RoundNearest RoundDirection = iota
+	// RoundDown is for a limit sell: rounding down never asks for more than
+	// the order intended to receive.
+	RoundDown
+	// RoundUp is for a limit buy: rounding up never asks to pay less than
+	// the order intended to offer.
+	RoundUp
+)
+
+// RoundPrice rounds price to the nearest valid multiple of PriceTickSize in
+// the given direction. A non-positive PriceTickSize (instrument info not
+// loaded) returns price unchanged.
+func (i InstrumentInfo) RoundPrice(price float64, dir RoundDirection) float64 {
+	return roundToStep(price, i.PriceTickSize, dir)
+}
+
+// RoundBuyPrice rounds a limit buy price up to the nearest valid tick.
+func (i InstrumentInfo) RoundBuyPrice(price float64) float64 {
+	return i.RoundPrice(price, RoundUp)
+}
+
+// RoundSellPrice rounds a limit sell price down to the nearest valid tick.
+func (i InstrumentInfo) RoundSellPrice(price float64) float64 {
+	return i.RoundPrice(price, RoundDown)
+}
+
+// RoundQuantity rounds size down to the nearest valid multiple of
+// QuantityStepSize. Quantity always rounds down, never up, so a REAL order
+// never requests more size than the position actually holds.
+func (i InstrumentInfo) RoundQuantity(size float64) float64 {
+	return roundToStep(size, i.QuantityStepSize, RoundDown)
+}
+
+// MeetsMinNotional reports whether qty*price clears MinNotional, the
+// smallest order value Binance accepts. A non-positive MinNotional (no rule,
+// or instrument info not loaded) always passes.
+func (i InstrumentInfo) MeetsMinNotional(qty, price float64) bool {
+	if i.MinNotional <= 0 {
+		return true
+	}
+	return qty*price >= i.MinNotional
+}
+
+func roundToStep(value, step float64, dir RoundDirection) float64 {
+	if step <= 0 {
+		return value
+	}
+
+	steps := value / step
+	switch dir {
+	case RoundDown:
+		steps = math.Floor(steps)
+	case RoundUp:
+		steps = math.Ceil(steps)
+	default:
+		steps = math.Round(steps)
+	}
+
+	return steps * step
+}
+
+// InstrumentRepository fetches and caches a symbol's exchange precision
+// rules. Nil InstrumentRepository on a caller disables rounding, leaving
+// prices/sizes exactly as computed (the pre-existing behavior).
+type InstrumentRepository interface {
+	GetInstrumentInfo(ctx context.Context, symbol string) (*InstrumentInfo, error)
+}
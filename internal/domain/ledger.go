@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Withdrawal represents a single withdrawal record pulled from an exchange,
+// mirroring Binance's withdrawal history shape so the sync worker can upsert
+// directly from the API response
+type Withdrawal struct {
+	ID             uuid.UUID `json:"id"`
+	UserID         uuid.UUID `json:"user_id"`
+	Exchange       string    `json:"exchange"` // e.g. "BINANCE"
+	Asset          string    `json:"asset"`
+	Address        string    `json:"address"`
+	Network        string    `json:"network"`
+	Amount         float64   `json:"amount"`
+	TxnID          string    `json:"txn_id"` // exchange-side transaction/withdrawal ID
+	TxnFee         float64   `json:"txn_fee"`
+	TxnFeeCurrency string    `json:"txn_fee_currency"`
+	Time           time.Time `json:"time"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Deposit represents a single deposit record pulled from an exchange
+type Deposit struct {
+	ID             uuid.UUID `json:"id"`
+	UserID         uuid.UUID `json:"user_id"`
+	Exchange       string    `json:"exchange"`
+	Asset          string    `json:"asset"`
+	Address        string    `json:"address"`
+	Network        string    `json:"network"`
+	Amount         float64   `json:"amount"`
+	TxnID          string    `json:"txn_id"`
+	TxnFee         float64   `json:"txn_fee"`
+	TxnFeeCurrency string    `json:"txn_fee_currency"`
+	Time           time.Time `json:"time"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// WithdrawalRepository defines the interface for withdrawal ledger operations
+type WithdrawalRepository interface {
+	// Upsert inserts a withdrawal, or is a no-op if (exchange, txn_id) already exists
+	Upsert(ctx context.Context, withdrawal *Withdrawal) error
+
+	// GetByUserSince retrieves withdrawals for a user since a given time
+	GetByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]*Withdrawal, error)
+
+	// SumByUserSince sums withdrawal amounts for a user since a given time
+	SumByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (float64, error)
+}
+
+// DepositRepository defines the interface for deposit ledger operations
+type DepositRepository interface {
+	// Upsert inserts a deposit, or is a no-op if (exchange, txn_id) already exists
+	Upsert(ctx context.Context, deposit *Deposit) error
+
+	// GetByUserSince retrieves deposits for a user since a given time
+	GetByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]*Deposit, error)
+
+	// SumByUserSince sums deposit amounts for a user since a given time
+	SumByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (float64, error)
+}
@@ -22,18 +22,90 @@ type PaperPosition struct {
 	Status     string     `json:"status"`
 	CreatedAt  time.Time  `json:"created_at"`
 	ClosedAt   *time.Time `json:"closed_at,omitempty"`
+
+	// Flat activation-ratio/callback-rate trailing ladder, identical in
+	// shape and behavior to Position.CheckTrailing's own ladder (see
+	// domain/position.go) but evaluated against a PaperPosition instead of a
+	// REAL-mode one. The two slices must be the same length, sorted
+	// ascending by activation ratio; nil/empty disables the ladder and
+	// leaves the fixed SLPrice/TPPrice check as the only exit.
+	TrailingActivationRatios []float64 `json:"trailing_activation_ratios,omitempty"`
+	TrailingCallbackRates    []float64 `json:"trailing_callback_rates,omitempty"`
+	HighWaterPrice           *float64  `json:"high_water_price,omitempty"`
+	LowWaterPrice            *float64  `json:"low_water_price,omitempty"`
+	ActiveTrailingTier       int       `json:"active_trailing_tier"`
 }
 
-// PositionSide constants
-const (
-	SideLong  = "LONG"
-	SideShort = "SHORT"
-)
+// StatusClosedEmergency marks a close where no real exit price was
+// available (GetMarkPrice and the ticker cache both failed/were stale), so
+// EntryPrice was used as a break-even fallback. Kept distinct from
+// StatusClosedManual so callers can tell a priced close from a blind one.
+// SideLong/SideShort/StatusOpen/StatusClosedWin/StatusClosedLoss/
+// StatusClosedManual live in position.go -- both domain models share them.
+const StatusClosedEmergency = "CLOSED_EMERGENCY"
 
-// PositionStatus constants
-const (
-	StatusOpen         = "OPEN"
-	StatusClosedWin    = "CLOSED_WIN"
-	StatusClosedLoss   = "CLOSED_LOSS"
-	StatusClosedManual = "CLOSED_MANUAL"
-)
+// IsLong checks if the position is a LONG position
+func (p *PaperPosition) IsLong() bool {
+	return p.Side == SideLong || p.Side == "BUY"
+}
+
+// CheckTrailing checks the flat activation-ratio/callback-rate trailing
+// ladder: it updates HighWaterPrice (LONG) or LowWaterPrice (SHORT), advances
+// ActiveTrailingTier to the highest tier whose activation ratio has been
+// reached, and returns (true, ClosedByTrailing) once currentPrice crosses the
+// ratcheted stop for that tier. Mirrors Position.CheckTrailing exactly; see
+// domain/position.go for the REAL-mode equivalent. Callers should check the
+// fixed SLPrice/TPPrice first so a hard SL/TP still wins over the ladder.
+func (p *PaperPosition) CheckTrailing(currentPrice float64) (shouldClose bool, closedBy string) {
+	if len(p.TrailingActivationRatios) == 0 || len(p.TrailingActivationRatios) != len(p.TrailingCallbackRates) {
+		return false, ""
+	}
+
+	var extreme float64
+	if p.IsLong() {
+		extreme = currentPrice
+		if p.HighWaterPrice != nil && *p.HighWaterPrice > extreme {
+			extreme = *p.HighWaterPrice
+		}
+		p.HighWaterPrice = &extreme
+	} else {
+		extreme = currentPrice
+		if p.LowWaterPrice != nil && *p.LowWaterPrice < extreme {
+			extreme = *p.LowWaterPrice
+		}
+		p.LowWaterPrice = &extreme
+	}
+
+	favorableRatio := (extreme - p.EntryPrice) / p.EntryPrice
+	if !p.IsLong() {
+		favorableRatio = (p.EntryPrice - extreme) / p.EntryPrice
+	}
+
+	tier := -1
+	for i, ratio := range p.TrailingActivationRatios {
+		if favorableRatio >= ratio {
+			tier = i
+		}
+	}
+	if tier < 0 {
+		return false, ""
+	}
+	if tier > p.ActiveTrailingTier {
+		p.ActiveTrailingTier = tier
+	}
+
+	callback := p.TrailingCallbackRates[p.ActiveTrailingTier]
+	if p.IsLong() {
+		stop := extreme * (1 - callback)
+		if currentPrice <= stop {
+			return true, ClosedByTrailing
+		}
+	} else {
+		stop := extreme * (1 + callback)
+		if currentPrice >= stop {
+			return true, ClosedByTrailing
+		}
+	}
+
+	return false, ""
+}
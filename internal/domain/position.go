@@ -26,6 +26,158 @@ type Position struct {
 	ClosedBy   *string    `json:"closed_by,omitempty"` // TP, SL, TRAILING, MANUAL
 	CreatedAt  time.Time  `json:"created_at"`
 	ClosedAt   *time.Time `json:"closed_at,omitempty"`
+
+	// Laddered trailing-stop state. TrailingConfig is nil for positions that
+	// don't use a trailing stop; PeakPrice/ActiveTrailingStep only move in the
+	// profit-locking direction once the ladder is engaged.
+	TrailingConfig     *TrailingConfig `json:"trailing_config,omitempty"`
+	PeakPrice          *float64        `json:"peak_price,omitempty"`
+	ActiveTrailingStep int             `json:"active_trailing_step"`
+
+	// ROI-based SL/TP, as a percent of initial margin (size*entry/leverage)
+	// rather than an absolute price. Either or both may be nil; whichever of
+	// the price-based or ROI-based thresholds crosses first closes the
+	// position.
+	ROIStopLossPercent   *float64 `json:"roi_stop_loss_percent,omitempty"`
+	ROITakeProfitPercent *float64 `json:"roi_take_profit_percent,omitempty"`
+
+	// Flat activation-ratio/callback-rate trailing ladder, checked via
+	// CheckTrailing. Unlike TrailingConfig (which ratchets SLPrice for
+	// CheckSLTP to catch), this ladder closes the position directly with
+	// ClosedByTrailing once price crosses the ratcheted stop for the active
+	// tier. The two slices must be the same length, sorted ascending by
+	// activation ratio.
+	TrailingActivationRatios []float64 `json:"trailing_activation_ratios,omitempty"`
+	TrailingCallbackRates    []float64 `json:"trailing_callback_rates,omitempty"`
+	HighWaterPrice           *float64  `json:"high_water_price,omitempty"`
+	LowWaterPrice            *float64  `json:"low_water_price,omitempty"`
+	ActiveTrailingTier       int       `json:"active_trailing_tier"`
+
+	// Scaled-entry layer: one signal broken into several child Positions at
+	// different entry prices to reduce slippage/entry-price risk. Nil
+	// ParentSignalID means this position isn't part of a scaled entry.
+	ParentSignalID *uuid.UUID `json:"parent_signal_id,omitempty"`
+	LayerIndex     int        `json:"layer_index"`
+
+	// EntryATR is the Average True Range used by a RiskModel to derive
+	// SLPrice/TPPrice at entry time (see OpenPositionWithRiskModel). Nil for
+	// positions opened with an explicit SL/TP.
+	EntryATR *float64 `json:"entry_atr,omitempty"`
+
+	// BodyguardTrailingTier is the highest TRAILING_ACTIVATE_PCTS/
+	// TRAILING_CALLBACK_PCTS tier BodyguardService.applyTrailingStop has
+	// ratcheted SLPrice to for this position; it only ever increases, so a
+	// price retrace can't widen the stop back out. Distinct from
+	// ActiveTrailingTier, which belongs to the separate per-position
+	// TrailingActivationRatios/TrailingCallbackRates ladder checked by
+	// CheckTrailing.
+	BodyguardTrailingTier int `json:"bodyguard_trailing_tier"`
+
+	// Opt-in shadow-wick and cumulative-volume take-profit overrides, checked
+	// by BodyguardService alongside CheckSLTP. A nil field falls back to the
+	// SHADOW_TP_RATIO/SHADOW_TP_INTERVAL/CUMVOL_TP_WINDOW/CUMVOL_TP_MIN_QUOTE
+	// env defaults; see CheckShadowTP/CheckCumulativeVolumeTP.
+	ShadowTPRatio    *float64 `json:"shadow_tp_ratio,omitempty"`
+	ShadowTPInterval *string  `json:"shadow_tp_interval,omitempty"`
+	CumVolTPWindow   *int     `json:"cumvol_tp_window,omitempty"`
+	CumVolTPMinQuote *float64 `json:"cumvol_tp_min_quote,omitempty"`
+
+	// SLGraceCount counts consecutive ticks BodyguardService has skipped an
+	// SL breach because price was still inside the Bollinger Band envelope
+	// (see BB_GRACE_TICKS). Resets to 0 once price trades back outside the
+	// band or the position closes; a forced close happens once it exceeds
+	// BB_GRACE_TICKS regardless of the bands.
+	SLGraceCount int `json:"sl_grace_count"`
+
+	// TPLevels scales an exit across several price levels instead of one
+	// fixed TPPrice, checked via NextTPLevel. SizeFraction on each level is a
+	// fraction of Size at the time the level fires (not the original entry
+	// size), so the levels don't need to be rebalanced as earlier ones fill.
+	TPLevels []TPLevel `json:"tp_levels,omitempty"`
+
+	// TrailingStopPct drives UpdateTrailingStopPct's flat percentage trail,
+	// independent of the two ladder-based trailing mechanisms above
+	// (TrailingConfig/UpdateTrailingStep and TrailingActivationRatios/
+	// CheckTrailing): SLPrice only ever ratchets toward
+	// HighWaterMark*(1-TrailingStopPct) on a LONG, or the mirror on a SHORT.
+	TrailingStopPct *float64 `json:"trailing_stop_pct,omitempty"`
+	HighWaterMark   *float64 `json:"high_water_mark,omitempty"`
+	LowWaterMark    *float64 `json:"low_water_mark,omitempty"`
+
+	// FundingPaid is the cumulative realized funding payment FundingService.
+	// AccrueDue has folded into this position for every 00:00/08:00/16:00 UTC
+	// window crossed since CreatedAt. Positive means the position paid
+	// funding (e.g. a LONG while the rate is positive); negative means it
+	// received funding. calculateNetPnL subtracts it alongside fees so
+	// NetPnL = Gross - Fees - FundingPaid.
+	FundingPaid float64 `json:"funding_paid"`
+
+	// LastFundingAccrualAt is the last funding window boundary already
+	// folded into FundingPaid, so a restart or a repeated CheckPositions
+	// tick can't double-accrue the same window. Nil means AccrueDue hasn't
+	// run for this position yet and should start from CreatedAt.
+	LastFundingAccrualAt *time.Time `json:"last_funding_accrual_at,omitempty"`
+
+	// PositionSide is the Binance Futures position side this position was
+	// opened under: PositionSideBoth for a user in User.PositionModeOneWay,
+	// or PositionSideLong/PositionSideShort for a user in
+	// User.PositionModeHedge. REAL-mode closes forward it as the order's
+	// position side, so a hedge-mode user's simultaneous LONG and SHORT
+	// positions on the same symbol close under their own sides.
+	// SignalRepository.UpsertPending dedups PENDING signals on (Symbol, Type),
+	// and VirtualBrokerService.HandleUserDataEvent matches a fill to the first
+	// open position on its Symbol; neither looks at PositionSide.
+	PositionSide string `json:"position_side"`
+}
+
+// PositionSide constants, mirroring Binance Futures' positionSide field
+const (
+	PositionSideBoth  = "BOTH"
+	PositionSideLong  = "LONG"
+	PositionSideShort = "SHORT"
+)
+
+// PositionSideForSide derives the PositionSide a new position should open
+// under from the user's mode and the signal's trade Side: PositionSideBoth
+// in one-way mode, or the side-matching LONG/SHORT in hedge mode.
+func PositionSideForSide(positionMode, side string) string {
+	if positionMode != PositionModeHedge {
+		return PositionSideBoth
+	}
+	if side == SideLong {
+		return PositionSideLong
+	}
+	return PositionSideShort
+}
+
+// TPLevel is one rung of a scaled take-profit: when price reaches Price,
+// SizeFraction of the position's current Size is closed and Filled is set so
+// the level doesn't fire again.
+type TPLevel struct {
+	Price        float64 `json:"price"`
+	SizeFraction float64 `json:"size_fraction"`
+	Filled       bool    `json:"filled"`
+}
+
+// PartialFill records one scaled take-profit exit, so calculateNetPnL can
+// reconstruct a position's full realized PnL/fees across every partial close
+// plus whatever size closed the position for good.
+type PartialFill struct {
+	ID         uuid.UUID `json:"id"`
+	PositionID uuid.UUID `json:"position_id"`
+	Price      float64   `json:"price"`
+	Size       float64   `json:"size"`
+	Fee        float64   `json:"fee"`
+	ClosedAt   time.Time `json:"closed_at"`
+}
+
+// TrailingConfig is an ordered activation-ratio / callback-rate ladder, e.g.
+// ActivationRatios=[0.0006, 0.0008, 0.0012] paired with CallbackRates at the
+// same index. Ratios must be sorted ascending; the two slices must be the
+// same length.
+type TrailingConfig struct {
+	ActivationRatios []float64 `json:"activation_ratios"`
+	CallbackRates    []float64 `json:"callback_rates"`
 }
 
 // PositionSide constants
@@ -46,10 +198,14 @@ const (
 
 // ClosedBy constants (how the position was closed)
 const (
-	ClosedByTP       = "TP"       // Take Profit hit
-	ClosedBySL       = "SL"       // Stop Loss hit
-	ClosedByTrailing = "TRAILING" // Trailing Stop hit
-	ClosedByManual   = "MANUAL"   // Manually closed by user
+	ClosedByTP            = "TP"        // Take Profit hit
+	ClosedBySL            = "SL"        // Stop Loss hit
+	ClosedByTrailing      = "TRAILING"  // Trailing Stop hit
+	ClosedByManual        = "MANUAL"    // Manually closed by user
+	ClosedByROIStopLoss   = "ROI_SL"    // ROI stop-loss threshold hit
+	ClosedByROITakeProfit = "ROI_TP"    // ROI take-profit threshold hit
+	ClosedByShadowTP      = "SHADOW_TP" // Lower/upper-shadow wick take-profit hit
+	ClosedByCumVolTP      = "CUMVOL_TP" // Cumulative quote-volume take-profit hit
 )
 
 // PositionRepository defines the interface for position operations
@@ -83,6 +239,69 @@ type PositionRepository interface {
 
 	// GetClosedPositions retrieves detailed closed positions
 	GetClosedPositions(ctx context.Context, userID uuid.UUID, limit int) ([]*Position, error)
+
+	// UpdateTrailingState persists the ladder's peak price, active step, and
+	// resulting SL price for a single tick without touching the rest of the row
+	UpdateTrailingState(ctx context.Context, positionID uuid.UUID, peak float64, step int, newSL float64) error
+
+	// GetPositionsWithTrailing retrieves all open positions that have a
+	// trailing-stop ladder configured, for a background worker to tick
+	GetPositionsWithTrailing(ctx context.Context) ([]*Position, error)
+
+	// UpdateTrailingTierState persists the flat ladder's high/low water price
+	// and active tier for a single CheckTrailing tick, without touching the
+	// rest of the row, so a worker restart doesn't reset the ratchet
+	UpdateTrailingTierState(ctx context.Context, positionID uuid.UUID, highWater, lowWater *float64, tier int) error
+
+	// GetPositionsWithTrailingTier retrieves all open positions that have a
+	// flat activation-ratio/callback-rate trailing ladder configured
+	GetPositionsWithTrailingTier(ctx context.Context) ([]*Position, error)
+
+	// GetByParentSignalID retrieves every scaled-entry layer for a signal,
+	// ordered by LayerIndex
+	GetByParentSignalID(ctx context.Context, parentSignalID uuid.UUID) ([]*Position, error)
+
+	// UpdateBatch updates multiple positions (e.g. every layer of a scaled
+	// entry being closed together) in a single transaction, so a failure
+	// partway through can't leave some layers closed and others open
+	UpdateBatch(ctx context.Context, positions []*Position) error
+
+	// PartialClose reduces an open position's Size by sizeDelta without
+	// closing it, e.g. trimming exposure during a portfolio rebalance
+	PartialClose(ctx context.Context, id uuid.UUID, sizeDelta float64) error
+
+	// UpdateBodyguardTrailingState persists the tier and resulting SL price
+	// BodyguardService.applyTrailingStop's env-configured activation/
+	// callback ladder ratcheted to, without touching any other column
+	UpdateBodyguardTrailingState(ctx context.Context, positionID uuid.UUID, tier int, newSL float64) error
+
+	// UpdateSLGraceCount persists EvaluateSLGraceBreach's consecutive-noise
+	// counter without touching any other column
+	UpdateSLGraceCount(ctx context.Context, positionID uuid.UUID, count int) error
+
+	// UpdateTPLevelState persists a scaled take-profit tick: the TPLevels
+	// slice (with the just-fired level marked Filled) and the reduced Size,
+	// without touching any other column
+	UpdateTPLevelState(ctx context.Context, positionID uuid.UUID, tpLevels []TPLevel, newSize float64) error
+
+	// UpdateTrailingMarkState persists UpdateTrailingStopPct's ratcheted SL
+	// price and high/low water mark, without touching any other column.
+	// Distinct from UpdateTrailingState/UpdateTrailingTierState, which back
+	// the two ladder-based trailing mechanisms.
+	UpdateTrailingMarkState(ctx context.Context, positionID uuid.UUID, newSL float64, highWaterMark, lowWaterMark *float64) error
+
+	// SavePartialFill records one scaled take-profit exit for
+	// calculateNetPnL to fold back into the position's final realized PnL
+	SavePartialFill(ctx context.Context, fill *PartialFill) error
+
+	// GetPartialFills retrieves every partial exit recorded against a
+	// position, in execution order
+	GetPartialFills(ctx context.Context, positionID uuid.UUID) ([]*PartialFill, error)
+
+	// UpdateFundingState persists FundingService.AccrueDue's running
+	// FundingPaid total and the last funding window folded into it, without
+	// touching any other column
+	UpdateFundingState(ctx context.Context, positionID uuid.UUID, fundingPaid float64, lastFundingAccrualAt time.Time) error
 }
 
 // MetricResult holds PnL and Percent data
@@ -97,6 +316,49 @@ type PnLHistoryEntry struct {
 	PnL      float64
 }
 
+// BuildScaledEntryLayers splits one signal into numLayers child Positions
+// spread by layerSpread around signalPrice, e.g. for a LONG with
+// numLayers=5, layerSpread=0.001 the entries are placed at
+// signalPrice*(1 - i*layerSpread) for i in 0..4 (mirrored for SHORT), each
+// sized totalSize/numLayers. Every layer shares parentSignalID and is
+// distinguished by LayerIndex; SLPrice/TPPrice are copied onto each layer
+// unchanged since they're relative to the signal, not the individual entry.
+func BuildScaledEntryLayers(parentSignalID uuid.UUID, userID uuid.UUID, symbol, side string, signalPrice, slPrice, tpPrice, totalSize, leverage float64, numLayers int, layerSpread float64) []*Position {
+	if numLayers < 1 {
+		numLayers = 1
+	}
+
+	layers := make([]*Position, 0, numLayers)
+	layerSize := totalSize / float64(numLayers)
+
+	for i := 0; i < numLayers; i++ {
+		offset := float64(i) * layerSpread
+		entryPrice := signalPrice * (1 - offset)
+		if side == SideShort {
+			entryPrice = signalPrice * (1 + offset)
+		}
+
+		layers = append(layers, &Position{
+			ID:             uuid.New(),
+			UserID:         userID,
+			SignalID:       &parentSignalID,
+			ParentSignalID: &parentSignalID,
+			LayerIndex:     i,
+			Symbol:         symbol,
+			Side:           side,
+			EntryPrice:     entryPrice,
+			SLPrice:        slPrice,
+			TPPrice:        tpPrice,
+			Size:           layerSize,
+			Leverage:       leverage,
+			Status:         StatusOpen,
+			CreatedAt:      time.Now(),
+		})
+	}
+
+	return layers
+}
+
 // IsLong checks if the position is a LONG position
 func (p *Position) IsLong() bool {
 	return p.Side == SideLong || p.Side == "BUY"
@@ -145,7 +407,8 @@ func (p *Position) CalculatePnLPercent(currentPrice float64) float64 {
 	return (pnl / initialMargin) * 100
 }
 
-// CheckSLTP checks if SL or TP is hit and returns how it was closed
+// CheckSLTP checks if the price-based SL/TP or the ROI-based SL/TP (whichever
+// fires first) is hit, and returns how it was closed
 func (p *Position) CheckSLTP(currentPrice float64) (shouldClose bool, status string, closedBy string) {
 	if p.IsLong() {
 		if currentPrice <= p.SLPrice {
@@ -162,5 +425,268 @@ func (p *Position) CheckSLTP(currentPrice float64) (shouldClose bool, status str
 			return true, StatusClosedWin, ClosedByTP
 		}
 	}
+
+	if p.ROIStopLossPercent != nil || p.ROITakeProfitPercent != nil {
+		roi := p.CalculatePnLPercent(currentPrice)
+		if p.ROIStopLossPercent != nil && roi <= -*p.ROIStopLossPercent {
+			return true, StatusClosedLoss, ClosedByROIStopLoss
+		}
+		if p.ROITakeProfitPercent != nil && roi >= *p.ROITakeProfitPercent {
+			return true, StatusClosedWin, ClosedByROITakeProfit
+		}
+	}
+
 	return false, StatusOpen, ""
 }
+
+// CheckTrailing checks the flat activation-ratio/callback-rate trailing
+// ladder: it updates HighWaterPrice (LONG) or LowWaterPrice (SHORT), advances
+// ActiveTrailingTier to the highest tier whose activation ratio has been
+// reached, and returns (true, ClosedByTrailing) once currentPrice crosses the
+// ratcheted stop for that tier. CheckSLTP must be consulted first so a hard
+// SL/TP still wins over the trailing ladder.
+func (p *Position) CheckTrailing(currentPrice float64) (shouldClose bool, closedBy string) {
+	if len(p.TrailingActivationRatios) == 0 || len(p.TrailingActivationRatios) != len(p.TrailingCallbackRates) {
+		return false, ""
+	}
+
+	var extreme float64
+	if p.IsLong() {
+		extreme = currentPrice
+		if p.HighWaterPrice != nil && *p.HighWaterPrice > extreme {
+			extreme = *p.HighWaterPrice
+		}
+		p.HighWaterPrice = &extreme
+	} else {
+		extreme = currentPrice
+		if p.LowWaterPrice != nil && *p.LowWaterPrice < extreme {
+			extreme = *p.LowWaterPrice
+		}
+		p.LowWaterPrice = &extreme
+	}
+
+	favorableRatio := (extreme - p.EntryPrice) / p.EntryPrice
+	if !p.IsLong() {
+		favorableRatio = (p.EntryPrice - extreme) / p.EntryPrice
+	}
+
+	tier := -1
+	for i, ratio := range p.TrailingActivationRatios {
+		if favorableRatio >= ratio {
+			tier = i
+		}
+	}
+	if tier < 0 {
+		return false, ""
+	}
+	if tier > p.ActiveTrailingTier {
+		p.ActiveTrailingTier = tier
+	}
+
+	callback := p.TrailingCallbackRates[p.ActiveTrailingTier]
+	if p.IsLong() {
+		stop := extreme * (1 - callback)
+		if currentPrice <= stop {
+			return true, ClosedByTrailing
+		}
+	} else {
+		stop := extreme * (1 + callback)
+		if currentPrice >= stop {
+			return true, ClosedByTrailing
+		}
+	}
+
+	return false, ""
+}
+
+// NextTPLevel returns the index of the first unfilled TPLevel that
+// currentPrice has reached (price >= level for a LONG, <= for a SHORT), or
+// ok=false if none has fired. Levels are checked in slice order, so callers
+// should keep TPLevels sorted in the order they expect to fill.
+func (p *Position) NextTPLevel(currentPrice float64) (index int, ok bool) {
+	for i, level := range p.TPLevels {
+		if level.Filled {
+			continue
+		}
+		if p.IsLong() {
+			if currentPrice >= level.Price {
+				return i, true
+			}
+		} else {
+			if currentPrice <= level.Price {
+				return i, true
+			}
+		}
+	}
+	return -1, false
+}
+
+// UpdateTrailingStopPct ratchets SLPrice off a flat percentage trail from
+// HighWaterMark (LONG) or LowWaterMark (SHORT): SLPrice only ever moves to
+// max(SLPrice, HighWaterMark*(1-TrailingStopPct)) on a LONG, mirrored for a
+// SHORT, so a price retrace can't widen the stop back out. No-op if
+// TrailingStopPct isn't configured. This is independent of the
+// TrailingConfig/UpdateTrailingStep and TrailingActivationRatios/
+// CheckTrailing ladders elsewhere in this file.
+func (p *Position) UpdateTrailingStopPct(currentPrice float64) (newSL float64, moved bool) {
+	if p.TrailingStopPct == nil || *p.TrailingStopPct <= 0 {
+		return p.SLPrice, false
+	}
+
+	pct := *p.TrailingStopPct
+	if p.IsLong() {
+		mark := currentPrice
+		if p.HighWaterMark != nil && *p.HighWaterMark > mark {
+			mark = *p.HighWaterMark
+		}
+		p.HighWaterMark = &mark
+
+		candidate := mark * (1 - pct)
+		if candidate > p.SLPrice {
+			p.SLPrice = candidate
+			return candidate, true
+		}
+	} else {
+		mark := currentPrice
+		if p.LowWaterMark != nil && *p.LowWaterMark < mark {
+			mark = *p.LowWaterMark
+		}
+		p.LowWaterMark = &mark
+
+		candidate := mark * (1 + pct)
+		if candidate < p.SLPrice {
+			p.SLPrice = candidate
+			return candidate, true
+		}
+	}
+
+	return p.SLPrice, false
+}
+
+// EvaluateSLGraceBreach decides whether an SL breach just reported by
+// CheckSLTP should be held as noise rather than closed, given the symbol's
+// current Bollinger Bands. For a LONG, the breach is treated as noise while
+// currentPrice is still above lowerBand; for a SHORT, while still below
+// upperBand. SLGraceCount counts consecutive noise ticks, resetting to 0 as
+// soon as a breach stops being noise -- either because price also traded
+// outside the band, or because graceTicks consecutive noise ticks were
+// already spent and this breach is forced through for good.
+func (p *Position) EvaluateSLGraceBreach(currentPrice, upperBand, lowerBand float64, graceTicks int) (hold bool) {
+	inBand := currentPrice > lowerBand
+	if !p.IsLong() {
+		inBand = currentPrice < upperBand
+	}
+
+	if !inBand || p.SLGraceCount >= graceTicks {
+		p.SLGraceCount = 0
+		return false
+	}
+
+	p.SLGraceCount++
+	return true
+}
+
+// UpdateTrailingStep advances the laddered trailing stop given the latest
+// price: it tracks PeakPrice (highest price for LONG, lowest for SHORT since
+// entry), finds the highest activation step crossed, and computes the new SL
+// for that step. newSL is only ever returned in the profit-locking direction
+// (moved reports whether it actually improved on the current SLPrice), so
+// callers can persist via UpdateTrailingState without risk of regressing SL.
+func (p *Position) UpdateTrailingStep(currentPrice float64) (newSL float64, moved bool) {
+	if p.TrailingConfig == nil || len(p.TrailingConfig.ActivationRatios) == 0 {
+		return p.SLPrice, false
+	}
+
+	peak := currentPrice
+	if p.PeakPrice != nil {
+		if p.IsLong() && *p.PeakPrice > peak {
+			peak = *p.PeakPrice
+		}
+		if !p.IsLong() && *p.PeakPrice < peak {
+			peak = *p.PeakPrice
+		}
+	}
+	p.PeakPrice = &peak
+
+	ratios := p.TrailingConfig.ActivationRatios
+	callbacks := p.TrailingConfig.CallbackRates
+
+	favorableRatio := (peak - p.EntryPrice) / p.EntryPrice
+	if !p.IsLong() {
+		favorableRatio = (p.EntryPrice - peak) / p.EntryPrice
+	}
+
+	step := -1
+	for i, ratio := range ratios {
+		if favorableRatio >= ratio {
+			step = i
+		}
+	}
+	if step < 0 {
+		return p.SLPrice, false
+	}
+	if step > p.ActiveTrailingStep {
+		p.ActiveTrailingStep = step
+	}
+
+	callback := callbacks[p.ActiveTrailingStep]
+	var candidate float64
+	if p.IsLong() {
+		candidate = peak * (1 - callback)
+		if candidate > p.SLPrice {
+			p.SLPrice = candidate
+			return candidate, true
+		}
+	} else {
+		candidate = peak * (1 + callback)
+		if candidate < p.SLPrice {
+			p.SLPrice = candidate
+			return candidate, true
+		}
+	}
+
+	return p.SLPrice, false
+}
+
+// CheckShadowTP closes at market when the latest closed candle's wick has
+// already given back most of the move: (close-low)/close > ratio for a LONG,
+// (high-close)/close > ratio for a SHORT. Catches a fast reversal wick that a
+// plain price-based TP would miss because price never traded back down to it.
+func (p *Position) CheckShadowTP(candle Kline, ratio float64) (shouldClose bool, closedBy string) {
+	if ratio <= 0 || candle.Close <= 0 {
+		return false, ""
+	}
+
+	if p.IsLong() {
+		if (candle.Close-candle.Low)/candle.Close > ratio {
+			return true, ClosedByShadowTP
+		}
+	} else {
+		if (candle.High-candle.Close)/candle.Close > ratio {
+			return true, ClosedByShadowTP
+		}
+	}
+
+	return false, ""
+}
+
+// CheckCumulativeVolumeTP closes a profitable position once the summed quote
+// volume across candles exceeds minQuoteVolume: a volume spike this large
+// often precedes a reversal, so lock in profit instead of risking giving it
+// back. Never fires while the position is flat or losing.
+func (p *Position) CheckCumulativeVolumeTP(candles []Kline, minQuoteVolume, currentPrice float64) (shouldClose bool, closedBy string) {
+	if minQuoteVolume <= 0 || p.CalculatePnLPercent(currentPrice) <= 0 {
+		return false, ""
+	}
+
+	var cumVolume float64
+	for _, candle := range candles {
+		cumVolume += candle.QuoteVolume
+	}
+
+	if cumVolume >= minQuoteVolume {
+		return true, ClosedByCumVolTP
+	}
+
+	return false, ""
+}
@@ -0,0 +1,134 @@
+package domain
+
+import "testing"
+
+func newTrailingPosition(side string, entry float64) *Position {
+	return &Position{
+		Side:       side,
+		EntryPrice: entry,
+		SLPrice:    entry * 0.98,
+		TrailingConfig: &TrailingConfig{
+			ActivationRatios: []float64{0.01, 0.02, 0.05},
+			CallbackRates:    []float64{0.01, 0.005, 0.002},
+		},
+	}
+}
+
+func TestUpdateTrailingStep_LongAdvancesSteps(t *testing.T) {
+	p := newTrailingPosition(SideLong, 100)
+
+	// Below first activation ratio: no change
+	if _, moved := p.UpdateTrailingStep(100.5); moved {
+		t.Fatalf("expected no trailing move below activation, position=%+v", p)
+	}
+
+	// Crosses the first tier (1%): SL should lock in at peak*(1-0.01)
+	sl, moved := p.UpdateTrailingStep(101.5)
+	if !moved {
+		t.Fatalf("expected trailing move at tier 0")
+	}
+	want := 101.5 * 0.99
+	if sl != want {
+		t.Fatalf("got SL %.6f, want %.6f", sl, want)
+	}
+	if p.ActiveTrailingStep != 0 {
+		t.Fatalf("expected active step 0, got %d", p.ActiveTrailingStep)
+	}
+
+	// Crosses the second tier (2%): tighter callback, SL moves further up
+	sl2, moved2 := p.UpdateTrailingStep(103.0)
+	if !moved2 {
+		t.Fatalf("expected trailing move at tier 1")
+	}
+	if sl2 <= sl {
+		t.Fatalf("expected SL to keep rising, got %.6f after %.6f", sl2, sl)
+	}
+	if p.ActiveTrailingStep != 1 {
+		t.Fatalf("expected active step 1, got %d", p.ActiveTrailingStep)
+	}
+}
+
+func TestUpdateTrailingStep_NeverRegresses(t *testing.T) {
+	p := newTrailingPosition(SideLong, 100)
+	sl, _ := p.UpdateTrailingStep(103) // tier 1 active, peak=103
+	prevSL := sl
+
+	// Price retraces: SL must not move down even though favorable ratio drops
+	sl2, moved := p.UpdateTrailingStep(102)
+	if moved {
+		t.Fatalf("SL should not move on a retrace")
+	}
+	if sl2 != prevSL {
+		t.Fatalf("SL regressed from %.6f to %.6f", prevSL, sl2)
+	}
+	if p.ActiveTrailingStep != 1 {
+		t.Fatalf("active step should never decrease on retrace, got %d", p.ActiveTrailingStep)
+	}
+}
+
+func TestUpdateTrailingStep_Short(t *testing.T) {
+	p := newTrailingPosition(SideShort, 100)
+	p.SLPrice = 102
+
+	sl, moved := p.UpdateTrailingStep(98) // 2% favorable move down
+	if !moved {
+		t.Fatalf("expected trailing move for SHORT")
+	}
+	if sl >= p.EntryPrice {
+		t.Fatalf("expected SL below entry for SHORT trail, got %.6f", sl)
+	}
+	if sl >= 102 {
+		t.Fatalf("expected SL to tighten below the original SL, got %.6f", sl)
+	}
+}
+
+func newFlatTrailingPosition(side string, entry float64) *Position {
+	return &Position{
+		Side:                     side,
+		EntryPrice:               entry,
+		SLPrice:                  entry * 0.98,
+		TrailingActivationRatios: []float64{0.0012, 0.01},
+		TrailingCallbackRates:    []float64{0.0006, 0.0049},
+	}
+}
+
+func TestCheckTrailing_Long(t *testing.T) {
+	p := newFlatTrailingPosition(SideLong, 100)
+
+	// Below the first activation ratio: ladder not armed yet
+	if shouldClose, _ := p.CheckTrailing(100.05); shouldClose {
+		t.Fatalf("expected no close below activation, position=%+v", p)
+	}
+
+	// Crosses tier 0 (0.12%), then retraces below the tier-0 stop
+	if shouldClose, _ := p.CheckTrailing(100.2); shouldClose {
+		t.Fatalf("expected no close right at the high water mark")
+	}
+	stop := 100.2 * (1 - 0.0006)
+	shouldClose, closedBy := p.CheckTrailing(stop - 0.001)
+	if !shouldClose || closedBy != ClosedByTrailing {
+		t.Fatalf("expected trailing close below tier-0 stop, got shouldClose=%v closedBy=%q", shouldClose, closedBy)
+	}
+}
+
+func TestCheckTrailing_NoLadderConfigured(t *testing.T) {
+	p := &Position{Side: SideLong, EntryPrice: 100}
+
+	if shouldClose, closedBy := p.CheckTrailing(110); shouldClose || closedBy != "" {
+		t.Fatalf("expected no-op without a configured ladder, got shouldClose=%v closedBy=%q", shouldClose, closedBy)
+	}
+}
+
+func TestCheckTrailing_Short(t *testing.T) {
+	p := newFlatTrailingPosition(SideShort, 100)
+
+	// 2% favorable move down arms tier 1 (callback 0.49%)
+	if shouldClose, _ := p.CheckTrailing(98); shouldClose {
+		t.Fatalf("expected no close at the low water mark")
+	}
+	stop := 98 * (1 + 0.0049)
+	shouldClose, closedBy := p.CheckTrailing(stop + 0.001)
+	if !shouldClose || closedBy != ClosedByTrailing {
+		t.Fatalf("expected trailing close above tier-1 stop, got shouldClose=%v closedBy=%q", shouldClose, closedBy)
+	}
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PeriodAllTime is the ProfitStats period key for the evergreen bucket that
+// never resets, as opposed to a daily bucket keyed by date
+// (see service.StatsAggregator).
+const PeriodAllTime = "all_time"
+
+// ProfitStats is a rolling summary of a user's realized trading performance
+// for one symbol ("" means all symbols combined) within one period bucket,
+// fed by StatsAggregator every time a position closes.
+type ProfitStats struct {
+	UserID         uuid.UUID `json:"user_id"`
+	Symbol         string    `json:"symbol"`
+	Period         string    `json:"period"`
+	Trades         int       `json:"trades"`
+	Wins           int       `json:"wins"`
+	Losses         int       `json:"losses"`
+	GrossProfit    float64   `json:"gross_profit"`
+	GrossLoss      float64   `json:"gross_loss"`
+	LargestWin     float64   `json:"largest_win"`
+	LargestLoss    float64   `json:"largest_loss"`
+	WinRate        float64   `json:"win_rate"`
+	ProfitFactor   float64   `json:"profit_factor"`
+	MaxDrawdown    float64   `json:"max_drawdown"`
+	SharpeRatio    float64   `json:"sharpe_ratio"`
+	StartBalance   float64   `json:"start_balance"`
+	CurrentBalance float64   `json:"current_balance"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ApplyTrade folds one closed trade's realized pnl into the bucket and
+// recomputes the derived ratios from the running totals. balanceAfter is
+// the user's balance once pnl has already been applied.
+func (s *ProfitStats) ApplyTrade(pnl, balanceAfter float64) {
+	if s.Trades == 0 {
+		s.StartBalance = balanceAfter - pnl
+	}
+	s.Trades++
+
+	if pnl >= 0 {
+		s.Wins++
+		s.GrossProfit += pnl
+		if pnl > s.LargestWin {
+			s.LargestWin = pnl
+		}
+	} else {
+		s.Losses++
+		s.GrossLoss += -pnl
+		if pnl < s.LargestLoss {
+			s.LargestLoss = pnl
+		}
+	}
+
+	s.CurrentBalance = balanceAfter
+	s.UpdatedAt = time.Now()
+
+	if s.StartBalance > 0 {
+		if drawdown := (s.StartBalance - balanceAfter) / s.StartBalance * 100; drawdown > s.MaxDrawdown {
+			s.MaxDrawdown = drawdown
+		}
+	}
+
+	s.recomputeRatios()
+}
+
+// Merge folds o's totals into s (s and o must share Symbol), for combining
+// several daily buckets into one rolling-window summary. o is assumed to
+// cover a later or equal time range than s's current contents.
+func (s *ProfitStats) Merge(o *ProfitStats) {
+	if o.Trades == 0 {
+		return
+	}
+
+	if s.Trades == 0 {
+		s.StartBalance = o.StartBalance
+	}
+
+	s.Trades += o.Trades
+	s.Wins += o.Wins
+	s.Losses += o.Losses
+	s.GrossProfit += o.GrossProfit
+	s.GrossLoss += o.GrossLoss
+	if o.LargestWin > s.LargestWin {
+		s.LargestWin = o.LargestWin
+	}
+	if o.LargestLoss < s.LargestLoss {
+		s.LargestLoss = o.LargestLoss
+	}
+	if o.MaxDrawdown > s.MaxDrawdown {
+		s.MaxDrawdown = o.MaxDrawdown
+	}
+	s.CurrentBalance = o.CurrentBalance
+	s.UpdatedAt = o.UpdatedAt
+
+	s.recomputeRatios()
+}
+
+func (s *ProfitStats) recomputeRatios() {
+	if s.Trades > 0 {
+		s.WinRate = float64(s.Wins) / float64(s.Trades) * 100
+	}
+	if s.GrossLoss > 0 {
+		s.ProfitFactor = s.GrossProfit / s.GrossLoss
+	} else if s.GrossProfit > 0 {
+		// No losing trades yet: there's no ratio to divide by, so report
+		// gross profit itself as a (very favorable) stand-in.
+		s.ProfitFactor = s.GrossProfit
+	}
+}
+
+// EquityPoint is a single day's balance snapshot for a user, charted as the
+// equity curve and used to compute Sharpe/drawdown over a period.
+type EquityPoint struct {
+	UserID  uuid.UUID `json:"user_id"`
+	Balance float64   `json:"balance"`
+	AsOf    time.Time `json:"as_of"`
+}
+
+// ProfitStatsRepository persists rolling per-user/symbol/period performance
+// buckets and daily equity snapshots.
+type ProfitStatsRepository interface {
+	// Get retrieves the bucket for (userID, symbol, period), returning a
+	// zero-value ProfitStats (not an error) if it doesn't exist yet.
+	Get(ctx context.Context, userID uuid.UUID, symbol, period string) (*ProfitStats, error)
+
+	// Upsert persists stats, replacing any existing row for the same
+	// (user_id, symbol, period).
+	Upsert(ctx context.Context, stats *ProfitStats) error
+
+	// GetEquityCurve retrieves a user's daily equity snapshots since since,
+	// oldest first. A zero since returns the full history.
+	GetEquityCurve(ctx context.Context, userID uuid.UUID, since time.Time) ([]*EquityPoint, error)
+
+	// SnapshotEquity records a user's balance as asOf's equity point,
+	// replacing any snapshot already recorded for that date.
+	SnapshotEquity(ctx context.Context, userID uuid.UUID, balance float64, asOf time.Time) error
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -46,8 +47,26 @@ type UserRepository interface {
 	// GetAll retrieves all users
 	GetAll(ctx context.Context) ([]*User, error)
 
+	// GetActiveTraders retrieves every user with auto-trading enabled, for
+	// ProcessMarketScan to fan signals out to instead of one defaultUserID
+	GetActiveTraders(ctx context.Context) ([]*User, error)
+
 	// UpdateAutoTradeStatus updates the auto-trade flag for a user
 	UpdateAutoTradeStatus(ctx context.Context, userID uuid.UUID, enabled bool) error
+
+	// SetTradeCooldownUntil records an exchange-throttling cooldown deadline
+	// so auto-trading can skip the user transparently until it elapses
+	SetTradeCooldownUntil(ctx context.Context, userID uuid.UUID, until time.Time) error
+
+	// RotateEncryptionKey re-encrypts every user's Binance credential columns
+	// from oldKey to newKey, one row per transaction, for master key rotation
+	RotateEncryptionKey(ctx context.Context, oldKey, newKey []byte) error
+
+	// UpdatePositionMode switches a user between User.PositionModeOneWay and
+	// User.PositionModeHedge. Callers must enforce Binance's own constraint
+	// that this only happens with no open positions -- see
+	// UserHandler.SetPositionMode.
+	UpdatePositionMode(ctx context.Context, userID uuid.UUID, mode string) error
 }
 
 // PaperPositionRepository defines the interface for paper position operations
@@ -66,4 +85,15 @@ type PaperPositionRepository interface {
 
 	// GetByID retrieves a position by ID
 	GetByID(ctx context.Context, id uuid.UUID) (*PaperPosition, error)
+
+	// UpdateTrailingTierState persists CheckTrailing's ratcheted high/low
+	// water price and active tier, without touching any other column
+	UpdateTrailingTierState(ctx context.Context, positionID uuid.UUID, highWater, lowWater *float64, tier int) error
+
+	// GetForReport retrieves userID's positions for a PnL report: every
+	// closed position with closed_at in [from, to], plus every currently
+	// open position regardless of closed_at (so unrealized PnL/average
+	// cost reflect the live book). symbol "" means every symbol. Returns
+	// oldest first, for service.PnLService's FIFO lot walk.
+	GetForReport(ctx context.Context, userID uuid.UUID, symbol string, from, to time.Time) ([]*PaperPosition, error)
 }
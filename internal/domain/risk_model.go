@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Kline is a single OHLC candle, used by RiskModel implementations to derive
+// volatility-based SL/TP, by the backtest engine to replay history, and by
+// BodyguardService's shadow/cumulative-volume take-profit exits.
+//
+// Volume, CloseTime and TradeCount are populated on a best-effort basis --
+// some exchange.Exchange implementations' candle endpoints don't expose all
+// three (see exchange package parsers), in which case they're left zero.
+type Kline struct {
+	OpenTime    time.Time
+	Open        float64
+	High        float64
+	Low         float64
+	Close       float64
+	QuoteVolume float64
+	Volume      float64
+	CloseTime   time.Time
+	TradeCount  int64
+}
+
+// KlineProvider fetches recent klines for a symbol/interval, oldest first.
+// Nil KlineProvider on a caller disables ATR-based SL/TP, falling back to
+// whatever SL/TP the signal already carries.
+type KlineProvider interface {
+	GetKlines(ctx context.Context, symbol, interval string, limit int) ([]Kline, error)
+}
+
+// RiskModel computes stop-loss/take-profit prices from recent volatility,
+// for signals that don't arrive with their own explicit SL/TP.
+type RiskModel interface {
+	// ComputeSLTP returns SL/TP prices around entryPrice for side (SideLong/
+	// SideShort) using klines. atr is the volatility measure the model used,
+	// for persisting alongside the position. ok is false when the resulting
+	// price range is too tight to be worth trading, in which case the signal
+	// should be skipped rather than opened with an unusably narrow stop.
+	ComputeSLTP(side string, entryPrice float64, klines []Kline) (sl, tp, atr float64, ok bool)
+}
+
+// ATRProvider fetches a symbol's current Average True Range, for callers
+// that need a raw volatility reading rather than a full SL/TP (e.g. an
+// ATR-scaled trailing-stop distance). Nil ATRProvider on a caller disables
+// ATR-aware behavior, falling back to whatever fixed distance it already uses.
+type ATRProvider interface {
+	// GetATR returns the ATR for symbol over window candles at interval
+	// (e.g. window=14, interval="15m" for the standard Wilder ATR).
+	GetATR(ctx context.Context, symbol, interval string, window int) (float64, error)
+}
+
+// BBProvider fetches a symbol's current Bollinger Bands, for callers that
+// need a volatility envelope around price rather than a single ATR reading
+// (e.g. treating a stop-loss breach as noise while price is still inside
+// the band). Nil BBProvider on a caller disables band-aware behavior.
+type BBProvider interface {
+	// GetBands returns the upper/mid/lower Bollinger Bands for symbol: an
+	// SMA over window candles at interval, offset by k standard deviations.
+	GetBands(ctx context.Context, symbol, interval string, window int, k float64) (upper, mid, lower float64, err error)
+}
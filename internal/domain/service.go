@@ -4,6 +4,29 @@ import "context"
 
 // AIService defines the interface for AI analysis operations
 type AIService interface {
-	// AnalyzeMarket calls the Python Engine to analyze market and generate signals
-	AnalyzeMarket(ctx context.Context, balance float64) ([]*AISignalResponse, error)
+	// AnalyzeMarket calls the Python Engine to analyze market and generate
+	// signals. mode is "SCALPER" for M15 aggressive trading or "INVESTOR"
+	// for H1 trend following.
+	AnalyzeMarket(ctx context.Context, balance float64, mode string) ([]*AISignalResponse, error)
+
+	// GetWebSocketPrices returns the latest prices for symbols from the
+	// Python engine's WebSocket feed.
+	GetWebSocketPrices(ctx context.Context, symbols []string) (map[string]float64, error)
+
+	// ExecuteClose executes a real close order via the Python Engine.
+	ExecuteClose(ctx context.Context, params *CloseParams) (*ExecutionResult, error)
+
+	// SendFeedback sends a trade outcome to the Python ML engine for learning
+	SendFeedback(ctx context.Context, feedback *FeedbackData) error
+}
+
+// ExecutionResult reports a real order's fill and, when the Python Engine
+// forwarded them, Binance's rate-limit usage headers for that call -
+// UsedWeight1m/OrderCount1m are 0 when unavailable, in which case a caller
+// throttling off them (e.g. ExecutionGateway) just keeps its steady-state
+// budget.
+type ExecutionResult struct {
+	AvgPrice     float64 `json:"avg_price"`
+	UsedWeight1m int     `json:"-"`
+	OrderCount1m int     `json:"-"`
 }
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is one refresh token issued to a user. The raw token never
+// touches the database -- only RefreshTokenHash does -- so a DB leak alone
+// can't be used to mint new access tokens. Sessions are rotated on every
+// refresh (the old row is revoked, a new one inserted) rather than updated
+// in place, so AuthHandler.Refresh can detect reuse of an already-rotated
+// token.
+type Session struct {
+	ID               uuid.UUID
+	UserID           uuid.UUID
+	RefreshTokenHash string
+	UserAgent        string
+	IP               string
+	ExpiresAt        time.Time
+	RevokedAt        *time.Time
+	CreatedAt        time.Time
+}
+
+// SessionRepository persists refresh-token sessions for AuthHandler's
+// login/refresh/logout/logout-all flow.
+type SessionRepository interface {
+	// Create inserts a new session row.
+	Create(ctx context.Context, session *Session) error
+
+	// GetByTokenHash looks up the session for a hashed refresh token value.
+	GetByTokenHash(ctx context.Context, tokenHash string) (*Session, error)
+
+	// Revoke marks a single session revoked, either because it was just
+	// rotated (one-time-use) or because the user logged out.
+	Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+
+	// RevokeAllForUser marks every session belonging to userID revoked, for
+	// logout-all.
+	RevokeAllForUser(ctx context.Context, userID uuid.UUID, revokedAt time.Time) error
+}
+
+// IsActive reports whether the session can still be used to refresh: not
+// revoked and not past ExpiresAt.
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
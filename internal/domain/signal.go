@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Signal represents a trading signal produced by the AI engine's market
+// scan and persisted via SignalRepository.
+type Signal struct {
+	ID           uuid.UUID `json:"id"`
+	Symbol       string    `json:"symbol"`
+	Type         string    `json:"type"` // LONG or SHORT
+	EntryPrice   float64   `json:"entry_price"`
+	SLPrice      float64   `json:"sl_price"`
+	TPPrice      float64   `json:"tp_price"`
+	Confidence   int       `json:"confidence"`
+	Reasoning    string    `json:"reasoning"`
+	Status       string    `json:"status"`
+	ReviewResult *string   `json:"review_result,omitempty"`
+	ReviewPnL    *float64  `json:"review_pnl,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	// ScreenerMetrics carries the market-screener indicators the AI engine
+	// scored this signal on. BodyguardService forwards it unchanged to
+	// SendFeedback once the signal's position closes, so the ML model can
+	// learn from the outcome. Nil for a signal the engine didn't attach any
+	// to.
+	ScreenerMetrics *ScreenerMetrics `json:"screener_metrics,omitempty"`
+}
+
+// StatusPending marks a signal that hasn't been reviewed (or auto-opened
+// into a position) yet.
+const StatusPending = "PENDING"
+
+// AISignalResponse is one signal as returned by the Python AI engine's
+// market analysis -- either from the one-shot /analyze/market response, or
+// decoded off a "signal" event on /analyze/market/stream (see
+// adapter.decodeAnalysisEvent).
+type AISignalResponse struct {
+	Symbol             string       `json:"symbol"`
+	FinalSignal        string       `json:"final_signal"` // LONG, SHORT, or WAIT
+	CombinedConfidence int          `json:"combined_confidence"`
+	LogicReasoning     string       `json:"logic_reasoning"`
+	VisionAnalysis     string       `json:"vision_analysis"`
+	TradeParams        *TradeParams `json:"trade_params,omitempty"`
+}
+
+// TradeParams is the AI engine's suggested entry/exit prices and position
+// size for one AISignalResponse.
+type TradeParams struct {
+	EntryPrice       float64 `json:"entry_price"`
+	StopLoss         float64 `json:"stop_loss"`
+	TakeProfit       float64 `json:"take_profit"`
+	PositionSizeUSDT float64 `json:"position_size_usdt"`
+}
+
+// ScreenerMetrics are the market-screener indicators behind one signal's
+// score, round-tripped to the Python engine via FeedbackData.Metrics once
+// the signal's position closes.
+type ScreenerMetrics struct {
+	ADX        float64
+	VolZScore  float64
+	KER        float64
+	IsSqueeze  bool
+	Score      float64
+	VolRatio   float64
+	ATRPercent float64
+}
+
+// FeedbackData is one trade outcome reported back to the Python ML engine
+// via AIService.SendFeedback, so it can learn from real results.
+type FeedbackData struct {
+	Symbol  string
+	Outcome string // WIN or LOSS
+	PnL     float64
+	Metrics *ScreenerMetrics
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SignalSubscription is a user's opt-in to a specific symbol and/or
+// strategy (e.g. SCALPER/INVESTOR, see SystemSettingsRepository). A nil
+// Symbol/Strategy matches any value for that column.
+type SignalSubscription struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Symbol    *string   `json:"symbol,omitempty"`
+	Strategy  *string   `json:"strategy,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Matches reports whether this subscription covers symbol/strategy
+func (s *SignalSubscription) Matches(symbol, strategy string) bool {
+	if s.Symbol != nil && *s.Symbol != symbol {
+		return false
+	}
+	if s.Strategy != nil && *s.Strategy != strategy {
+		return false
+	}
+	return true
+}
+
+// SignalSubscriptionRepository defines the interface for per-user signal
+// opt-in operations
+type SignalSubscriptionRepository interface {
+	// GetForUser retrieves every subscription a user has opted into
+	GetForUser(ctx context.Context, userID uuid.UUID) ([]*SignalSubscription, error)
+}
+
+// AllowsSignal reports whether a user with the given subscriptions should
+// receive a signal for symbol/strategy. A user with no subscriptions at all
+// is treated as subscribed to everything, preserving today's behavior for
+// anyone who hasn't opted into anything specific.
+func AllowsSignal(subs []*SignalSubscription, symbol, strategy string) bool {
+	if len(subs) == 0 {
+		return true
+	}
+	for _, sub := range subs {
+		if sub.Matches(symbol, strategy) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// TickerPriceService is the real-time counterpart to MarketPriceService: a
+// single-symbol mark price plus a cached last-tick map fed by a websocket
+// stream, for callers that need a fresh exit price (ClosePosition) rather
+// than a periodic bulk snapshot (review/rebalance).
+type TickerPriceService interface {
+	// GetMarkPrice fetches the current mark price for symbol directly from
+	// the exchange (rate-limited REST), bypassing the cache.
+	GetMarkPrice(ctx context.Context, symbol string) (float64, error)
+
+	// GetLastPrice returns the most recent cached price for symbol and how
+	// long ago it was received. ok is false if symbol has never ticked.
+	GetLastPrice(symbol string) (price float64, age time.Duration, ok bool)
+
+	// SubscribeTicker opens a websocket ticker stream for symbols, keeping
+	// GetLastPrice's cache warm until stop is called.
+	SubscribeTicker(symbols []string) (stop func(), err error)
+}
@@ -17,8 +17,65 @@ type User struct {
 	RealBalanceCache   *float64  `json:"real_balance_cache,omitempty"`
 	MaxDailyLoss       float64   `json:"max_daily_loss"`
 	IsAutoTradeEnabled bool      `json:"is_auto_trade_enabled"`
+	FixedOrderSize     float64   `json:"fixed_order_size"`
+	Leverage           float64   `json:"leverage"`
 	CreatedAt          time.Time `json:"created_at"`
 	UpdatedAt          time.Time `json:"updated_at"`
+
+	// BinanceAPIKey/Secret are the user's live Binance credentials, used in
+	// ModeReal (and ModePaper for read-only market data)
+	BinanceAPIKey    string `json:"-"`
+	BinanceAPISecret string `json:"-"`
+
+	// DefaultTrailingConfig seeds new positions' TrailingConfig when the
+	// signal/request doesn't specify its own ladder
+	DefaultTrailingConfig *TrailingConfig `json:"default_trailing_config,omitempty"`
+
+	// BinanceTestnetAPIKey/Secret are a separate credential pair from the live
+	// BinanceAPIKey/Secret so a user can hold both at once and switch freely
+	// between ModeTestnet and ModeReal without re-entering keys.
+	BinanceTestnetAPIKey    string   `json:"-"`
+	BinanceTestnetAPISecret string   `json:"-"`
+	TestnetBalanceCache     *float64 `json:"testnet_balance_cache,omitempty"`
+
+	// TradeCooldownUntil is set after the exchange throttles this user
+	// (429/418). While in the future, auto-trading silently skips them
+	// without touching IsAutoTradeEnabled.
+	TradeCooldownUntil *time.Time `json:"trade_cooldown_until,omitempty"`
+
+	// DefaultROIStopLossPercent/TakeProfitPercent seed new positions' ROI-based
+	// SL/TP when the signal/request doesn't specify its own thresholds
+	DefaultROIStopLossPercent   *float64 `json:"default_roi_sl_percent,omitempty"`
+	DefaultROITakeProfitPercent *float64 `json:"default_roi_tp_percent,omitempty"`
+
+	// MinConfidence/PositionSizePercent/MaxConcurrentPositions are this
+	// user's per-user market scan thresholds (see
+	// usecase.TradingService.ProcessMarketScan): a signal below
+	// MinConfidence is skipped for them, position size is
+	// PositionSizePercent of their balance, and no new position opens past
+	// MaxConcurrentPositions already-open ones.
+	MinConfidence          int     `json:"min_confidence"`
+	PositionSizePercent    float64 `json:"position_size_percent"`
+	MaxConcurrentPositions int     `json:"max_concurrent_positions"`
+
+	// HedgeEnabled opts this user into cross-exchange hedge mode: every
+	// paper position they open also opens a smaller opposite live position
+	// on the hedge exchange (see service.HedgeService).
+	HedgeEnabled bool `json:"hedge_enabled"`
+
+	// PositionMode is this user's Binance Futures position mode
+	// (PositionModeOneWay/PositionModeHedge). Via PositionSideForSide it
+	// decides whether a new position opens under PositionSideBoth or the
+	// side-matching LONG/SHORT -- see Position.PositionSide.
+	// Switching modes is only allowed with no open positions, mirroring
+	// Binance's own constraint.
+	PositionMode string `json:"position_mode"`
+}
+
+// InCooldown reports whether the user is currently serving an exchange
+// throttling cooldown
+func (u *User) InCooldown(now time.Time) bool {
+	return u.TradeCooldownUntil != nil && now.Before(*u.TradeCooldownUntil)
 }
 
 // UserRole constants
@@ -29,6 +86,13 @@ const (
 
 // TradingMode constants
 const (
-	ModePaper = "PAPER"
-	ModeReal  = "REAL"
+	ModePaper   = "PAPER"
+	ModeReal    = "REAL"
+	ModeTestnet = "TESTNET" // Binance testnet: real exchange plumbing, no live funds
+)
+
+// PositionMode constants, mirroring Binance Futures' dualSidePosition setting
+const (
+	PositionModeOneWay = "ONE_WAY"
+	PositionModeHedge  = "HEDGE"
 )
@@ -0,0 +1,228 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"neurotrade/internal/domain"
+)
+
+const defaultBinanceBaseURL = "https://fapi.binance.com"
+
+// binanceKlinePeriods maps normalized KlinePeriod to Binance Futures'
+// interval tokens, which happen to already match our normalized spelling --
+// kept as an explicit map anyway so an unsupported period is a clear error
+// rather than a silently-wrong request.
+var binanceKlinePeriods = map[KlinePeriod]string{
+	Kline1Min:  "1m",
+	Kline5Min:  "5m",
+	Kline15Min: "15m",
+	Kline30Min: "30m",
+	Kline1Hour: "1h",
+	Kline4Hour: "4h",
+	Kline1Day:  "1d",
+}
+
+// BinanceExchange implements Exchange against Binance Futures' public REST
+// API (no API key required for market data).
+type BinanceExchange struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewBinanceExchange creates a new BinanceExchange. baseURL overrides the
+// default host (BINANCE_EXCHANGE_BASE_URL env var), mainly for pointing at
+// a test double.
+func NewBinanceExchange() *BinanceExchange {
+	baseURL := envOrDefault("BINANCE_EXCHANGE_BASE_URL", defaultBinanceBaseURL)
+	return &BinanceExchange{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		baseURL:    baseURL,
+	}
+}
+
+// GetTicker implements Exchange.
+func (e *BinanceExchange) GetTicker(ctx context.Context, pair CurrencyPair) (*Ticker, error) {
+	reqURL := e.baseURL + "/fapi/v1/ticker/price?" + url.Values{"symbol": {pair.ToSymbol()}}.Encode()
+
+	var parsed struct {
+		Price string `json:"price"`
+	}
+	if err := e.getJSON(ctx, reqURL, &parsed); err != nil {
+		return nil, fmt.Errorf("binance: GetTicker: %w", err)
+	}
+
+	price, err := strconv.ParseFloat(parsed.Price, 64)
+	if err != nil {
+		return nil, fmt.Errorf("binance: GetTicker: failed to parse price %q: %w", parsed.Price, err)
+	}
+
+	return &Ticker{Pair: pair, Last: price, Timestamp: time.Now()}, nil
+}
+
+// GetKlineRecords implements Exchange.
+func (e *BinanceExchange) GetKlineRecords(ctx context.Context, pair CurrencyPair, period KlinePeriod, size int, opts ...KlineOption) ([]domain.Kline, error) {
+	interval, ok := binanceKlinePeriods[period]
+	if !ok {
+		return nil, fmt.Errorf("binance: unsupported kline period: %s", period)
+	}
+	o := applyKlineOptions(opts...)
+
+	values := url.Values{
+		"symbol":   {pair.ToSymbol()},
+		"interval": {interval},
+		"limit":    {strconv.Itoa(size)},
+	}
+	if o.startTime != nil {
+		values.Set("startTime", strconv.FormatInt(o.startTime.UnixMilli(), 10))
+	}
+	if o.endTime != nil {
+		values.Set("endTime", strconv.FormatInt(o.endTime.UnixMilli(), 10))
+	}
+
+	var raw [][]interface{}
+	if err := e.getJSON(ctx, e.baseURL+"/fapi/v1/klines?"+values.Encode(), &raw); err != nil {
+		return nil, fmt.Errorf("binance: GetKlineRecords: %w", err)
+	}
+
+	klines := make([]domain.Kline, 0, len(raw))
+	for _, candle := range raw {
+		k, ok := parseBinanceKline(candle)
+		if ok {
+			klines = append(klines, k)
+		}
+	}
+	return klines, nil
+}
+
+// parseBinanceKline parses one candle of the form
+// [openTime, open, high, low, close, volume, closeTime, quoteAssetVolume,
+// numberOfTrades, ...].
+func parseBinanceKline(candle []interface{}) (domain.Kline, bool) {
+	if len(candle) < 9 {
+		return domain.Kline{}, false
+	}
+
+	openTimeMs, ok := candle[0].(float64)
+	if !ok {
+		return domain.Kline{}, false
+	}
+	closeTimeMs, ok := candle[6].(float64)
+	if !ok {
+		return domain.Kline{}, false
+	}
+
+	open, err1 := strconv.ParseFloat(asString(candle[1]), 64)
+	high, err2 := strconv.ParseFloat(asString(candle[2]), 64)
+	low, err3 := strconv.ParseFloat(asString(candle[3]), 64)
+	closePrice, err4 := strconv.ParseFloat(asString(candle[4]), 64)
+	volume, err5 := strconv.ParseFloat(asString(candle[5]), 64)
+	quoteVolume, err6 := strconv.ParseFloat(asString(candle[7]), 64)
+	tradeCount, err7 := strconv.ParseInt(asString(candle[8]), 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil || err5 != nil || err6 != nil || err7 != nil {
+		return domain.Kline{}, false
+	}
+
+	return domain.Kline{
+		OpenTime:    time.UnixMilli(int64(openTimeMs)),
+		Open:        open,
+		High:        high,
+		Low:         low,
+		Close:       closePrice,
+		QuoteVolume: quoteVolume,
+		Volume:      volume,
+		CloseTime:   time.UnixMilli(int64(closeTimeMs)),
+		TradeCount:  tradeCount,
+	}, true
+}
+
+// GetDepth implements Exchange.
+func (e *BinanceExchange) GetDepth(ctx context.Context, pair CurrencyPair, size int) (*Depth, error) {
+	values := url.Values{"symbol": {pair.ToSymbol()}, "limit": {strconv.Itoa(size)}}
+
+	var parsed struct {
+		Bids [][2]string `json:"bids"`
+		Asks [][2]string `json:"asks"`
+	}
+	if err := e.getJSON(ctx, e.baseURL+"/fapi/v1/depth?"+values.Encode(), &parsed); err != nil {
+		return nil, fmt.Errorf("binance: GetDepth: %w", err)
+	}
+
+	return &Depth{
+		Pair: pair,
+		Bids: parseDepthSide(parsed.Bids),
+		Asks: parseDepthSide(parsed.Asks),
+	}, nil
+}
+
+func parseDepthSide(levels [][2]string) []DepthItem {
+	out := make([]DepthItem, 0, len(levels))
+	for _, level := range levels {
+		price, err := strconv.ParseFloat(level[0], 64)
+		if err != nil {
+			continue
+		}
+		amount, err := strconv.ParseFloat(level[1], 64)
+		if err != nil {
+			continue
+		}
+		out = append(out, DepthItem{Price: price, Amount: amount})
+	}
+	return out
+}
+
+// GetTrades implements Exchange.
+func (e *BinanceExchange) GetTrades(ctx context.Context, pair CurrencyPair, since int64) ([]Trade, error) {
+	values := url.Values{"symbol": {pair.ToSymbol()}, "limit": {"500"}}
+
+	var raw []struct {
+		ID           int64  `json:"id"`
+		Price        string `json:"price"`
+		Qty          string `json:"qty"`
+		Time         int64  `json:"time"`
+		IsBuyerMaker bool   `json:"isBuyerMaker"`
+	}
+	if err := e.getJSON(ctx, e.baseURL+"/fapi/v1/trades?"+values.Encode(), &raw); err != nil {
+		return nil, fmt.Errorf("binance: GetTrades: %w", err)
+	}
+
+	trades := make([]Trade, 0, len(raw))
+	for _, t := range raw {
+		if t.Time < since {
+			continue
+		}
+		price, err := strconv.ParseFloat(t.Price, 64)
+		if err != nil {
+			continue
+		}
+		amount, err := strconv.ParseFloat(t.Qty, 64)
+		if err != nil {
+			continue
+		}
+
+		// isBuyerMaker=true means the buyer was resting (maker), so the
+		// aggressor (taker) that defines the trade's side was the seller.
+		side := domain.SideLong
+		if t.IsBuyerMaker {
+			side = domain.SideShort
+		}
+
+		trades = append(trades, Trade{
+			Pair:      pair,
+			TradeID:   strconv.FormatInt(t.ID, 10),
+			Price:     price,
+			Amount:    amount,
+			Side:      side,
+			Timestamp: time.UnixMilli(t.Time),
+		})
+	}
+	return trades, nil
+}
+
+func (e *BinanceExchange) getJSON(ctx context.Context, reqURL string, dst interface{}) error {
+	return getJSON(ctx, e.httpClient, reqURL, dst)
+}
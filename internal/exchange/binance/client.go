@@ -0,0 +1,214 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/adshao/go-binance/v2/futures"
+
+	"neurotrade/internal/domain"
+)
+
+// Client implements FuturesExchange against the real Binance USDT-M Futures
+// API via the go-binance SDK.
+type Client struct {
+	futures *futures.Client
+}
+
+// NewClient creates a Client for a single user's Binance API credentials.
+func NewClient(apiKey, apiSecret string) *Client {
+	return &Client{futures: futures.NewClient(apiKey, apiSecret)}
+}
+
+// SetLeverage sets account leverage for symbol before an entry order.
+func (c *Client) SetLeverage(ctx context.Context, symbol string, leverage int) error {
+	if err := orderRateLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("binance: rate limiter: %w", err)
+	}
+
+	_, err := c.futures.NewChangeLeverageService().
+		Symbol(symbol).
+		Leverage(leverage).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("binance: failed to set leverage for %s: %w", symbol, err)
+	}
+
+	return nil
+}
+
+// OpenPosition places the entry market order, then registers reduce-only
+// STOP_MARKET/TAKE_PROFIT_MARKET orders for the position's SL/TP so Binance
+// enforces the exits instead of our own polling loop.
+func (c *Client) OpenPosition(ctx context.Context, req OpenPositionRequest) (*OpenPositionResult, error) {
+	if err := c.SetLeverage(ctx, req.Symbol, int(req.Leverage)); err != nil {
+		return nil, err
+	}
+
+	entrySide, exitSide := futures.SideTypeBuy, futures.SideTypeSell
+	if req.Side == domain.SideShort {
+		entrySide, exitSide = futures.SideTypeSell, futures.SideTypeBuy
+	}
+
+	if err := orderRateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("binance: rate limiter: %w", err)
+	}
+	entryOrder, err := c.futures.NewCreateOrderService().
+		Symbol(req.Symbol).
+		Side(entrySide).
+		Type(futures.OrderTypeMarket).
+		Quantity(formatFloat(req.Size)).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("binance: failed to place entry order for %s: %w", req.Symbol, err)
+	}
+
+	if err := orderRateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("binance: rate limiter: %w", err)
+	}
+	slOrder, err := c.futures.NewCreateOrderService().
+		Symbol(req.Symbol).
+		Side(exitSide).
+		Type(futures.OrderTypeStopMarket).
+		StopPrice(formatFloat(req.SLPrice)).
+		Quantity(formatFloat(req.Size)).
+		ReduceOnly(true).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("binance: failed to place SL order for %s: %w", req.Symbol, err)
+	}
+
+	if err := orderRateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("binance: rate limiter: %w", err)
+	}
+	tpOrder, err := c.futures.NewCreateOrderService().
+		Symbol(req.Symbol).
+		Side(exitSide).
+		Type(futures.OrderTypeTakeProfitMarket).
+		StopPrice(formatFloat(req.TPPrice)).
+		Quantity(formatFloat(req.Size)).
+		ReduceOnly(true).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("binance: failed to place TP order for %s: %w", req.Symbol, err)
+	}
+
+	entryPrice, err := strconv.ParseFloat(entryOrder.AvgPrice, 64)
+	if err != nil {
+		return nil, fmt.Errorf("binance: failed to parse entry fill price for %s: %w", req.Symbol, err)
+	}
+
+	return &OpenPositionResult{
+		EntryPrice: entryPrice,
+		SLOrderID:  slOrder.OrderID,
+		TPOrderID:  tpOrder.OrderID,
+	}, nil
+}
+
+// ClosePosition places a market order closing quantity on the opposite side
+// of side, returning the average fill price. positionSide is
+// domain.PositionSideBoth for a one-way-mode account or
+// domain.PositionSideLong/Short for a hedge-mode account; Binance rejects
+// the reduceOnly flag on hedge-mode orders (closing there is implied by
+// placing the opposite-direction order under the same positionSide), so
+// ReduceOnly is only set in one-way mode.
+func (c *Client) ClosePosition(ctx context.Context, symbol, side, positionSide string, quantity float64) (*ClosePositionResult, error) {
+	closeSide := futures.SideTypeSell
+	if side == domain.SideShort {
+		closeSide = futures.SideTypeBuy
+	}
+
+	if err := orderRateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("binance: rate limiter: %w", err)
+	}
+	order, err := buildClosePositionOrder(c.futures, symbol, closeSide, positionSide, quantity).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("binance: failed to close position for %s: %w", symbol, err)
+	}
+
+	exitPrice, err := strconv.ParseFloat(order.AvgPrice, 64)
+	if err != nil {
+		return nil, fmt.Errorf("binance: failed to parse exit fill price for %s: %w", symbol, err)
+	}
+
+	return &ClosePositionResult{ExitPrice: exitPrice}, nil
+}
+
+// buildClosePositionOrder constructs the market order that closes a
+// position, setting PositionSide for a hedge-mode account instead of
+// ReduceOnly (which Binance rejects once dualSidePosition is enabled).
+func buildClosePositionOrder(client *futures.Client, symbol string, closeSide futures.SideType, positionSide string, quantity float64) *futures.CreateOrderService {
+	svc := client.NewCreateOrderService().
+		Symbol(symbol).
+		Side(closeSide).
+		Type(futures.OrderTypeMarket).
+		Quantity(formatFloat(quantity))
+
+	if positionSide == domain.PositionSideLong || positionSide == domain.PositionSideShort {
+		return svc.PositionSide(futures.PositionSideType(positionSide))
+	}
+
+	return svc.ReduceOnly(true)
+}
+
+// QueryOpenPositions lists every open futures position on the account, for
+// startup reconciliation against DB StatusOpen rows.
+func (c *Client) QueryOpenPositions(ctx context.Context) ([]ExchangePosition, error) {
+	risks, err := c.futures.NewGetPositionRiskService().Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("binance: failed to query open positions: %w", err)
+	}
+
+	var positions []ExchangePosition
+	for _, risk := range risks {
+		size, err := strconv.ParseFloat(risk.PositionAmt, 64)
+		if err != nil || size == 0 {
+			continue
+		}
+
+		entryPrice, err := strconv.ParseFloat(risk.EntryPrice, 64)
+		if err != nil {
+			continue
+		}
+
+		side := domain.SideLong
+		if size < 0 {
+			side = domain.SideShort
+			size = -size
+		}
+
+		positions = append(positions, ExchangePosition{
+			Symbol:     risk.Symbol,
+			Side:       side,
+			Size:       size,
+			EntryPrice: entryPrice,
+		})
+	}
+
+	return positions, nil
+}
+
+// QueryTicker fetches the latest mark price for symbol.
+func (c *Client) QueryTicker(ctx context.Context, symbol string) (float64, error) {
+	prices, err := c.futures.NewPremiumIndexService().Symbol(symbol).Do(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("binance: failed to query ticker for %s: %w", symbol, err)
+	}
+	if len(prices) == 0 {
+		return 0, fmt.Errorf("binance: no mark price returned for %s", symbol)
+	}
+
+	price, err := strconv.ParseFloat(prices[0].MarkPrice, 64)
+	if err != nil {
+		return 0, fmt.Errorf("binance: failed to parse mark price for %s: %w", symbol, err)
+	}
+
+	return price, nil
+}
+
+// formatFloat renders a quantity/price the way the Binance SDK expects:
+// plain decimal, no scientific notation.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,101 @@
+// Package binance wraps github.com/adshao/go-binance/v2/futures behind a
+// narrow FuturesExchange interface, so the REAL-mode Position lifecycle
+// depends on a handful of domain-shaped methods instead of the SDK's own
+// request-builder types.
+package binance
+
+import (
+	"context"
+	"time"
+)
+
+// OpenPositionRequest carries everything FuturesExchange needs to enter a
+// position and register its protective exits in one call.
+type OpenPositionRequest struct {
+	Symbol   string
+	Side     string // domain.SideLong / domain.SideShort
+	Size     float64
+	Leverage float64
+	SLPrice  float64
+	TPPrice  float64
+}
+
+// OpenPositionResult reports what the exchange actually did, since market
+// order fills and protective order IDs only exist after the call returns.
+type OpenPositionResult struct {
+	EntryPrice float64
+	SLOrderID  int64
+	TPOrderID  int64
+}
+
+// ClosePositionResult reports the average fill price of a reduce-only close.
+type ClosePositionResult struct {
+	ExitPrice float64
+}
+
+// ExchangePosition is one row of QueryOpenPositions, used to reconcile
+// against the local DB's OPEN positions on startup.
+type ExchangePosition struct {
+	Symbol     string
+	Side       string
+	Size       float64
+	EntryPrice float64
+}
+
+// UserDataEventType identifies the Binance USER_DATA stream event that
+// produced a UserDataEvent.
+type UserDataEventType string
+
+const (
+	UserDataEventOrderTradeUpdate UserDataEventType = "ORDER_TRADE_UPDATE"
+	UserDataEventAccountUpdate    UserDataEventType = "ACCOUNT_UPDATE"
+)
+
+// UserDataEvent is the normalized shape StreamUserData delivers to its
+// handler after parsing a raw ORDER_TRADE_UPDATE/ACCOUNT_UPDATE message.
+// Symbol/ExitPrice/PnL/ClosedBy are only populated for fill events that
+// closed a position (order status FILLED on a reduce-only SL/TP/market
+// close order).
+type UserDataEvent struct {
+	Type      UserDataEventType
+	Symbol    string
+	ExitPrice float64
+	PnL       float64
+	ClosedBy  string // domain.ClosedByTP / ClosedBySL / ClosedByManual
+	EventTime time.Time
+}
+
+// UserDataHandler receives normalized USER_DATA stream events.
+type UserDataHandler func(event UserDataEvent)
+
+// FuturesExchange is the subset of Binance USDT-M Futures operations the
+// Position lifecycle needs: leverage, entry + protective exits, a read path
+// for reconciliation, ticker polling, and the user-data fill stream.
+type FuturesExchange interface {
+	// SetLeverage sets account leverage for symbol. Must be called before
+	// OpenPosition's entry order so the fill uses the intended margin.
+	SetLeverage(ctx context.Context, symbol string, leverage int) error
+
+	// OpenPosition places the entry market order, then registers the
+	// position's SL/TP as reduce-only STOP_MARKET/TAKE_PROFIT_MARKET orders
+	// so Binance enforces the exits rather than our own polling loop.
+	OpenPosition(ctx context.Context, req OpenPositionRequest) (*OpenPositionResult, error)
+
+	// ClosePosition places a market order for quantity on the opposite side,
+	// returning the average fill price. positionSide is
+	// domain.PositionSideBoth/Long/Short; see Client.ClosePosition for how
+	// it changes whether the order carries ReduceOnly or PositionSide.
+	ClosePosition(ctx context.Context, symbol, side, positionSide string, quantity float64) (*ClosePositionResult, error)
+
+	// QueryOpenPositions lists every open futures position currently held
+	// on the account, for reconciliation against DB StatusOpen rows.
+	QueryOpenPositions(ctx context.Context) ([]ExchangePosition, error)
+
+	// QueryTicker fetches the latest mark price for symbol.
+	QueryTicker(ctx context.Context, symbol string) (float64, error)
+
+	// StreamUserData subscribes to the account's USER_DATA websocket and
+	// invokes handler for every ORDER_TRADE_UPDATE/ACCOUNT_UPDATE event.
+	// The returned stop func closes the stream.
+	StreamUserData(ctx context.Context, handler UserDataHandler) (stop func(), err error)
+}
@@ -0,0 +1,10 @@
+package binance
+
+import "golang.org/x/time/rate"
+
+// orderRateLimiter throttles outbound order-placing calls across every
+// Client in the process (5 rps, burst 2), so many REAL-mode users trading
+// concurrently can't collectively trip Binance's -1003 TOO_MANY_REQUESTS
+// weight ban. Shared rather than per-Client since Binance enforces the limit
+// per API key family, not per connection.
+var orderRateLimiter = rate.NewLimiter(rate.Limit(5), 2)
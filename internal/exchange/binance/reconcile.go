@@ -0,0 +1,70 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"neurotrade/internal/domain"
+)
+
+// DriftReport describes a mismatch found between the exchange's live
+// futures positions and the local DB's StatusOpen rows for the same user.
+type DriftReport struct {
+	Symbol string
+	Reason string // e.g. "open on exchange but not in DB", "size mismatch"
+}
+
+// ReconcileOpenPositions lists the exchange's open futures positions and
+// compares them against the DB's StatusOpen rows for userID, logging any
+// drift rather than attempting to auto-correct it: a mismatch here usually
+// means a fill was missed while the process was down, and auto-correcting
+// blind could double-close or orphan a still-open position.
+func ReconcileOpenPositions(ctx context.Context, exchange FuturesExchange, positionRepo domain.PositionRepository, userID string) ([]DriftReport, error) {
+	exchangePositions, err := exchange.QueryOpenPositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("binance: reconcile: failed to query exchange positions: %w", err)
+	}
+
+	openPositions, err := positionRepo.GetOpenPositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("binance: reconcile: failed to query DB open positions: %w", err)
+	}
+
+	dbBySymbol := make(map[string]*domain.Position, len(openPositions))
+	for _, pos := range openPositions {
+		if pos.UserID.String() == userID {
+			dbBySymbol[pos.Symbol] = pos
+		}
+	}
+
+	var drift []DriftReport
+	seen := make(map[string]bool, len(exchangePositions))
+	for _, exPos := range exchangePositions {
+		seen[exPos.Symbol] = true
+
+		dbPos, ok := dbBySymbol[exPos.Symbol]
+		if !ok {
+			drift = append(drift, DriftReport{Symbol: exPos.Symbol, Reason: "open on exchange but not in DB"})
+			continue
+		}
+		if dbPos.Side != exPos.Side {
+			drift = append(drift, DriftReport{Symbol: exPos.Symbol, Reason: "side mismatch between exchange and DB"})
+		}
+		if dbPos.Size != exPos.Size {
+			drift = append(drift, DriftReport{Symbol: exPos.Symbol, Reason: "size mismatch between exchange and DB"})
+		}
+	}
+
+	for symbol := range dbBySymbol {
+		if !seen[symbol] {
+			drift = append(drift, DriftReport{Symbol: symbol, Reason: "open in DB but not on exchange"})
+		}
+	}
+
+	for _, d := range drift {
+		log.Printf("[WARN] binance reconcile: user %s %s: %s", userID, d.Symbol, d.Reason)
+	}
+
+	return drift, nil
+}
@@ -0,0 +1,91 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+
+	"neurotrade/internal/domain"
+)
+
+// StreamUserData subscribes to the account's USER_DATA websocket and
+// normalizes ORDER_TRADE_UPDATE/ACCOUNT_UPDATE events for handler. A fill
+// event is only reported as closing a position when the filled order is a
+// reduce-only SL/TP/close order (status FILLED); closedBy is derived from
+// the order type.
+func (c *Client) StreamUserData(ctx context.Context, handler UserDataHandler) (stop func(), err error) {
+	listenKey, err := c.futures.NewStartUserStreamService().Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("binance: failed to start user data stream: %w", err)
+	}
+
+	wsHandler := func(event *futures.WsUserDataEvent) {
+		switch event.Event {
+		case futures.UserDataEventTypeOrderTradeUpdate:
+			handler(orderTradeUpdateToEvent(event))
+		case futures.UserDataEventTypeAccountUpdate:
+			handler(UserDataEvent{
+				Type:      UserDataEventAccountUpdate,
+				EventTime: time.UnixMilli(event.Time),
+			})
+		}
+	}
+
+	errHandler := func(err error) {
+		// Best-effort: the caller's handler has no channel to receive stream
+		// errors on, so log-and-continue is the only option short of a
+		// reconnect loop, which the caller is expected to drive via ctx.
+		fmt.Printf("[WARN] binance user data stream error: %v\n", err)
+	}
+
+	doneC, stopC, err := futures.WsUserDataServe(listenKey, wsHandler, errHandler)
+	if err != nil {
+		return nil, fmt.Errorf("binance: failed to open user data websocket: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		stopC <- struct{}{}
+	}()
+
+	return func() {
+		stopC <- struct{}{}
+		<-doneC
+	}, nil
+}
+
+// orderTradeUpdateToEvent maps a filled reduce-only order into the
+// normalized fill shape the Position lifecycle reconciles against.
+func orderTradeUpdateToEvent(event *futures.WsUserDataEvent) UserDataEvent {
+	order := event.OrderTradeUpdate
+	out := UserDataEvent{
+		Type:      UserDataEventOrderTradeUpdate,
+		Symbol:    order.Symbol,
+		EventTime: time.UnixMilli(event.Time),
+	}
+
+	if order.Status != futures.OrderStatusTypeFilled || !order.IsReduceOnly {
+		return out
+	}
+
+	if exitPrice, err := strconv.ParseFloat(order.LastFilledPrice, 64); err == nil {
+		out.ExitPrice = exitPrice
+	}
+	if pnl, err := strconv.ParseFloat(order.RealizedPnL, 64); err == nil {
+		out.PnL = pnl
+	}
+
+	switch order.OriginalType {
+	case futures.OrderTypeStopMarket, futures.OrderTypeStop:
+		out.ClosedBy = domain.ClosedBySL
+	case futures.OrderTypeTakeProfitMarket, futures.OrderTypeTakeProfit:
+		out.ClosedBy = domain.ClosedByTP
+	default:
+		out.ClosedBy = domain.ClosedByManual
+	}
+
+	return out
+}
@@ -0,0 +1,221 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"neurotrade/internal/domain"
+)
+
+const defaultBybitBaseURL = "https://api.bybit.com"
+
+// bybitKlinePeriods maps normalized KlinePeriod to Bybit v5's interval
+// tokens (bare minute counts, or "D" for a day).
+var bybitKlinePeriods = map[KlinePeriod]string{
+	Kline1Min:  "1",
+	Kline5Min:  "5",
+	Kline15Min: "15",
+	Kline30Min: "30",
+	Kline1Hour: "60",
+	Kline4Hour: "240",
+	Kline1Day:  "D",
+}
+
+// BybitExchange implements Exchange against Bybit v5's public REST API
+// (linear USDT perpetuals), no API key required for market data.
+type BybitExchange struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewBybitExchange creates a new BybitExchange. baseURL overrides the
+// default host (BYBIT_EXCHANGE_BASE_URL env var).
+func NewBybitExchange() *BybitExchange {
+	return &BybitExchange{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		baseURL:    envOrDefault("BYBIT_EXCHANGE_BASE_URL", defaultBybitBaseURL),
+	}
+}
+
+// bybitEnvelope wraps every Bybit v5 response.
+type bybitEnvelope struct {
+	RetCode int             `json:"retCode"`
+	RetMsg  string          `json:"retMsg"`
+	Result  json.RawMessage `json:"result"`
+}
+
+// GetTicker implements Exchange.
+func (e *BybitExchange) GetTicker(ctx context.Context, pair CurrencyPair) (*Ticker, error) {
+	values := url.Values{"category": {"linear"}, "symbol": {pair.ToSymbol()}}
+
+	var result struct {
+		List []struct {
+			LastPrice string `json:"lastPrice"`
+		} `json:"list"`
+	}
+	if err := e.getJSON(ctx, "/v5/market/tickers?"+values.Encode(), &result); err != nil {
+		return nil, fmt.Errorf("bybit: GetTicker: %w", err)
+	}
+	if len(result.List) == 0 {
+		return nil, fmt.Errorf("bybit: GetTicker: no ticker data for %s", pair.ToSymbol())
+	}
+
+	price, err := strconv.ParseFloat(result.List[0].LastPrice, 64)
+	if err != nil {
+		return nil, fmt.Errorf("bybit: GetTicker: failed to parse price: %w", err)
+	}
+
+	return &Ticker{Pair: pair, Last: price, Timestamp: time.Now()}, nil
+}
+
+// GetKlineRecords implements Exchange.
+func (e *BybitExchange) GetKlineRecords(ctx context.Context, pair CurrencyPair, period KlinePeriod, size int, opts ...KlineOption) ([]domain.Kline, error) {
+	interval, ok := bybitKlinePeriods[period]
+	if !ok {
+		return nil, fmt.Errorf("bybit: unsupported kline period: %s", period)
+	}
+	o := applyKlineOptions(opts...)
+
+	values := url.Values{
+		"category": {"linear"},
+		"symbol":   {pair.ToSymbol()},
+		"interval": {interval},
+		"limit":    {strconv.Itoa(size)},
+	}
+	if o.startTime != nil {
+		values.Set("start", strconv.FormatInt(o.startTime.UnixMilli(), 10))
+	}
+	if o.endTime != nil {
+		values.Set("end", strconv.FormatInt(o.endTime.UnixMilli(), 10))
+	}
+
+	var result struct {
+		// Each entry: [startMs, open, high, low, close, volume, turnover]
+		List [][]string `json:"list"`
+	}
+	if err := e.getJSON(ctx, "/v5/market/kline?"+values.Encode(), &result); err != nil {
+		return nil, fmt.Errorf("bybit: GetKlineRecords: %w", err)
+	}
+
+	// Bybit returns newest first; reverse to oldest-first like the rest of
+	// this codebase expects (see MarketPriceService.GetKlines).
+	klines := make([]domain.Kline, 0, len(result.List))
+	for i := len(result.List) - 1; i >= 0; i-- {
+		k, ok := parseBybitKline(result.List[i])
+		if ok {
+			klines = append(klines, k)
+		}
+	}
+	return klines, nil
+}
+
+// parseBybitKline parses one row of the form [startMs, open, high, low,
+// close, volume, turnover]. Bybit's kline endpoint doesn't report a candle
+// close time or trade count, so domain.Kline.CloseTime/TradeCount are left
+// zero-valued.
+func parseBybitKline(row []string) (domain.Kline, bool) {
+	if len(row) < 7 {
+		return domain.Kline{}, false
+	}
+
+	startMs, err0 := strconv.ParseInt(row[0], 10, 64)
+	open, err1 := strconv.ParseFloat(row[1], 64)
+	high, err2 := strconv.ParseFloat(row[2], 64)
+	low, err3 := strconv.ParseFloat(row[3], 64)
+	closePrice, err4 := strconv.ParseFloat(row[4], 64)
+	volume, err5 := strconv.ParseFloat(row[5], 64)
+	turnover, err6 := strconv.ParseFloat(row[6], 64)
+	if err0 != nil || err1 != nil || err2 != nil || err3 != nil || err4 != nil || err5 != nil || err6 != nil {
+		return domain.Kline{}, false
+	}
+
+	return domain.Kline{
+		OpenTime:    time.UnixMilli(startMs),
+		Open:        open,
+		High:        high,
+		Low:         low,
+		Close:       closePrice,
+		QuoteVolume: turnover,
+		Volume:      volume,
+	}, true
+}
+
+// GetDepth implements Exchange.
+func (e *BybitExchange) GetDepth(ctx context.Context, pair CurrencyPair, size int) (*Depth, error) {
+	values := url.Values{"category": {"linear"}, "symbol": {pair.ToSymbol()}, "limit": {strconv.Itoa(size)}}
+
+	var result struct {
+		Bids [][2]string `json:"b"`
+		Asks [][2]string `json:"a"`
+	}
+	if err := e.getJSON(ctx, "/v5/market/orderbook?"+values.Encode(), &result); err != nil {
+		return nil, fmt.Errorf("bybit: GetDepth: %w", err)
+	}
+
+	return &Depth{Pair: pair, Bids: parseDepthSide(result.Bids), Asks: parseDepthSide(result.Asks)}, nil
+}
+
+// GetTrades implements Exchange.
+func (e *BybitExchange) GetTrades(ctx context.Context, pair CurrencyPair, since int64) ([]Trade, error) {
+	values := url.Values{"category": {"linear"}, "symbol": {pair.ToSymbol()}, "limit": {"60"}}
+
+	var result struct {
+		List []struct {
+			ExecID string `json:"execId"`
+			Price  string `json:"price"`
+			Size   string `json:"size"`
+			Side   string `json:"side"` // "Buy" or "Sell"
+			Time   string `json:"time"` // unix millis, as a string
+		} `json:"list"`
+	}
+	if err := e.getJSON(ctx, "/v5/market/recent-trade?"+values.Encode(), &result); err != nil {
+		return nil, fmt.Errorf("bybit: GetTrades: %w", err)
+	}
+
+	trades := make([]Trade, 0, len(result.List))
+	for _, t := range result.List {
+		timeMs, err := strconv.ParseInt(t.Time, 10, 64)
+		if err != nil || timeMs < since {
+			continue
+		}
+		price, err := strconv.ParseFloat(t.Price, 64)
+		if err != nil {
+			continue
+		}
+		amount, err := strconv.ParseFloat(t.Size, 64)
+		if err != nil {
+			continue
+		}
+
+		side := domain.SideShort
+		if t.Side == "Buy" {
+			side = domain.SideLong
+		}
+
+		trades = append(trades, Trade{
+			Pair:      pair,
+			TradeID:   t.ExecID,
+			Price:     price,
+			Amount:    amount,
+			Side:      side,
+			Timestamp: time.UnixMilli(timeMs),
+		})
+	}
+	return trades, nil
+}
+
+func (e *BybitExchange) getJSON(ctx context.Context, path string, dst interface{}) error {
+	var env bybitEnvelope
+	if err := getJSON(ctx, e.httpClient, e.baseURL+path, &env); err != nil {
+		return err
+	}
+	if env.RetCode != 0 {
+		return fmt.Errorf("bybit API error: retCode=%d retMsg=%s", env.RetCode, env.RetMsg)
+	}
+	return json.Unmarshal(env.Result, dst)
+}
@@ -0,0 +1,147 @@
+// Package exchange provides a venue-agnostic market-data abstraction
+// (ticker/klines/depth/trades), modeled after goex-style unified exchange
+// APIs. It is deliberately separate from internal/exchange/binance's
+// FuturesExchange, which is an execution-oriented interface (order
+// placement, position management) for Binance specifically; this package
+// only reads public market data and is meant to be swappable via the
+// EXCHANGE env var (see New/NewFromEnv).
+package exchange
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"neurotrade/internal/domain"
+)
+
+// CurrencyPair normalizes the two symbol spellings used across this
+// codebase -- "BTC/USDT" (user-facing/display) and "BTCUSDT" (exchange
+// wire format) -- into a single representation, so every Exchange
+// implementation converts to/from its own wire format in one place instead
+// of each call site re-deriving it.
+type CurrencyPair struct {
+	Base  string
+	Quote string
+}
+
+// NewCurrencyPair parses either spelling of symbol. Symbols without a "/"
+// separator are assumed USDT-quoted, matching the convention already used
+// throughout this codebase (MarketPriceService, VirtualBrokerService, risk
+// models all deal exclusively in *USDT pairs).
+func NewCurrencyPair(symbol string) CurrencyPair {
+	symbol = strings.ToUpper(symbol)
+
+	if base, quote, ok := strings.Cut(symbol, "/"); ok {
+		return CurrencyPair{Base: base, Quote: quote}
+	}
+
+	const defaultQuote = "USDT"
+	if strings.HasSuffix(symbol, defaultQuote) && len(symbol) > len(defaultQuote) {
+		return CurrencyPair{Base: strings.TrimSuffix(symbol, defaultQuote), Quote: defaultQuote}
+	}
+
+	return CurrencyPair{Base: symbol, Quote: defaultQuote}
+}
+
+// ToSymbol renders the pair in exchange wire format, e.g. "BTCUSDT".
+func (p CurrencyPair) ToSymbol() string {
+	return p.Base + p.Quote
+}
+
+// String renders the pair in display format, e.g. "BTC/USDT".
+func (p CurrencyPair) String() string {
+	return p.Base + "/" + p.Quote
+}
+
+// KlinePeriod is a normalized candle interval; each Exchange implementation
+// maps these to its own wire format (e.g. Bybit's "15" vs OKX's "15m").
+type KlinePeriod string
+
+const (
+	Kline1Min  KlinePeriod = "1m"
+	Kline5Min  KlinePeriod = "5m"
+	Kline15Min KlinePeriod = "15m"
+	Kline30Min KlinePeriod = "30m"
+	Kline1Hour KlinePeriod = "1h"
+	Kline4Hour KlinePeriod = "4h"
+	Kline1Day  KlinePeriod = "1d"
+)
+
+// Ticker is the latest traded/mark price for a pair.
+type Ticker struct {
+	Pair      CurrencyPair
+	Last      float64
+	Timestamp time.Time
+}
+
+// DepthItem is one price level of an order book side.
+type DepthItem struct {
+	Price  float64
+	Amount float64
+}
+
+// Depth is an order book snapshot, best price first on each side.
+type Depth struct {
+	Pair CurrencyPair
+	Bids []DepthItem
+	Asks []DepthItem
+}
+
+// Trade is a single executed trade in the public tape.
+type Trade struct {
+	Pair      CurrencyPair
+	TradeID   string
+	Price     float64
+	Amount    float64
+	Side      string // domain.SideLong ("LONG"/buy) or domain.SideShort ("SHORT"/sell)
+	Timestamp time.Time
+}
+
+// klineOptions carries GetKlineRecords' optional parameters. Construct via
+// the With* functions below rather than directly.
+type klineOptions struct {
+	startTime *time.Time
+	endTime   *time.Time
+}
+
+// KlineOption customizes a GetKlineRecords call.
+type KlineOption func(*klineOptions)
+
+// WithStartTime restricts GetKlineRecords to candles at or after t.
+func WithStartTime(t time.Time) KlineOption {
+	return func(o *klineOptions) { o.startTime = &t }
+}
+
+// WithEndTime restricts GetKlineRecords to candles at or before t.
+func WithEndTime(t time.Time) KlineOption {
+	return func(o *klineOptions) { o.endTime = &t }
+}
+
+func applyKlineOptions(opts ...KlineOption) klineOptions {
+	var o klineOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Exchange is the venue-agnostic market-data surface MarketPriceService
+// (and anything else that only needs prices/candles/book/tape) depends on,
+// so switching venues is an EXCHANGE env var change rather than a code
+// change. Implementations: BinanceExchange, BybitExchange, OKXExchange.
+type Exchange interface {
+	// GetTicker fetches the latest price for pair.
+	GetTicker(ctx context.Context, pair CurrencyPair) (*Ticker, error)
+
+	// GetKlineRecords fetches up to size recent candles for pair at period,
+	// oldest first.
+	GetKlineRecords(ctx context.Context, pair CurrencyPair, period KlinePeriod, size int, opts ...KlineOption) ([]domain.Kline, error)
+
+	// GetDepth fetches an order book snapshot of up to size levels per side.
+	GetDepth(ctx context.Context, pair CurrencyPair, size int) (*Depth, error)
+
+	// GetTrades fetches recent public trades for pair at or after since
+	// (unix millis; 0 for "most recent, no lower bound").
+	GetTrades(ctx context.Context, pair CurrencyPair, since int64) ([]Trade, error)
+}
@@ -0,0 +1,37 @@
+package exchange
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Exchange name identifiers accepted by the EXCHANGE env var / New.
+const (
+	NameBinance = "BINANCE"
+	NameBybit   = "BYBIT"
+	NameOKX     = "OKX"
+)
+
+// New constructs the Exchange registered under name (case-insensitive). An
+// empty name defaults to Binance, the venue this codebase has always
+// talked to.
+func New(name string) (Exchange, error) {
+	switch strings.ToUpper(strings.TrimSpace(name)) {
+	case NameBinance, "":
+		return NewBinanceExchange(), nil
+	case NameBybit:
+		return NewBybitExchange(), nil
+	case NameOKX:
+		return NewOKXExchange(), nil
+	default:
+		return nil, fmt.Errorf("exchange: unknown venue %q (want one of %s/%s/%s)", name, NameBinance, NameBybit, NameOKX)
+	}
+}
+
+// NewFromEnv constructs the Exchange named by the EXCHANGE env var, so
+// switching venues is a deploy-time config change rather than a code
+// change.
+func NewFromEnv() (Exchange, error) {
+	return New(os.Getenv("EXCHANGE"))
+}
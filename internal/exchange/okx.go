@@ -0,0 +1,238 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"neurotrade/internal/domain"
+)
+
+const defaultOKXBaseURL = "https://www.okx.com"
+
+// okxKlinePeriods maps normalized KlinePeriod to OKX's "bar" tokens.
+var okxKlinePeriods = map[KlinePeriod]string{
+	Kline1Min:  "1m",
+	Kline5Min:  "5m",
+	Kline15Min: "15m",
+	Kline30Min: "30m",
+	Kline1Hour: "1H",
+	Kline4Hour: "4H",
+	Kline1Day:  "1D",
+}
+
+// OKXExchange implements Exchange against OKX's public REST API (spot
+// instruments; this package only reads market data, not futures
+// execution), no API key required.
+type OKXExchange struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewOKXExchange creates a new OKXExchange. baseURL overrides the default
+// host (OKX_EXCHANGE_BASE_URL env var).
+func NewOKXExchange() *OKXExchange {
+	return &OKXExchange{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		baseURL:    envOrDefault("OKX_EXCHANGE_BASE_URL", defaultOKXBaseURL),
+	}
+}
+
+// instID renders pair as OKX's dash-separated instrument ID, e.g. "BTC-USDT".
+func instID(pair CurrencyPair) string {
+	return pair.Base + "-" + pair.Quote
+}
+
+// okxEnvelope wraps every OKX v5 response.
+type okxEnvelope struct {
+	Code string          `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
+}
+
+func (e *OKXExchange) getJSON(ctx context.Context, path string, dst interface{}) error {
+	var env okxEnvelope
+	if err := getJSON(ctx, e.httpClient, e.baseURL+path, &env); err != nil {
+		return err
+	}
+	if env.Code != "0" {
+		return fmt.Errorf("okx API error: code=%s msg=%s", env.Code, env.Msg)
+	}
+	return json.Unmarshal(env.Data, dst)
+}
+
+// GetTicker implements Exchange.
+func (e *OKXExchange) GetTicker(ctx context.Context, pair CurrencyPair) (*Ticker, error) {
+	values := url.Values{"instId": {instID(pair)}}
+
+	var data []struct {
+		Last string `json:"last"`
+	}
+	if err := e.getJSON(ctx, "/api/v5/market/ticker?"+values.Encode(), &data); err != nil {
+		return nil, fmt.Errorf("okx: GetTicker: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("okx: GetTicker: no ticker data for %s", instID(pair))
+	}
+
+	price, err := strconv.ParseFloat(data[0].Last, 64)
+	if err != nil {
+		return nil, fmt.Errorf("okx: GetTicker: failed to parse price: %w", err)
+	}
+
+	return &Ticker{Pair: pair, Last: price, Timestamp: time.Now()}, nil
+}
+
+// GetKlineRecords implements Exchange.
+func (e *OKXExchange) GetKlineRecords(ctx context.Context, pair CurrencyPair, period KlinePeriod, size int, opts ...KlineOption) ([]domain.Kline, error) {
+	bar, ok := okxKlinePeriods[period]
+	if !ok {
+		return nil, fmt.Errorf("okx: unsupported kline period: %s", period)
+	}
+	o := applyKlineOptions(opts...)
+
+	values := url.Values{"instId": {instID(pair)}, "bar": {bar}, "limit": {strconv.Itoa(size)}}
+	if o.startTime != nil {
+		values.Set("before", strconv.FormatInt(o.startTime.UnixMilli(), 10))
+	}
+	if o.endTime != nil {
+		values.Set("after", strconv.FormatInt(o.endTime.UnixMilli(), 10))
+	}
+
+	// Each row: [ts, open, high, low, close, vol, volCcy, volCcyQuote, confirm]
+	var rows [][]string
+	if err := e.getJSON(ctx, "/api/v5/market/candles?"+values.Encode(), &rows); err != nil {
+		return nil, fmt.Errorf("okx: GetKlineRecords: %w", err)
+	}
+
+	// OKX returns newest first; reverse to oldest-first.
+	klines := make([]domain.Kline, 0, len(rows))
+	for i := len(rows) - 1; i >= 0; i-- {
+		k, ok := parseOKXKline(rows[i])
+		if ok {
+			klines = append(klines, k)
+		}
+	}
+	return klines, nil
+}
+
+// parseOKXKline parses one row of the form [ts, open, high, low, close,
+// vol, volCcy, volCcyQuote, confirm]. OKX's candle endpoint doesn't report a
+// candle close time or trade count, so domain.Kline.CloseTime/TradeCount
+// are left zero-valued.
+func parseOKXKline(row []string) (domain.Kline, bool) {
+	if len(row) < 8 {
+		return domain.Kline{}, false
+	}
+
+	tsMs, err0 := strconv.ParseInt(row[0], 10, 64)
+	open, err1 := strconv.ParseFloat(row[1], 64)
+	high, err2 := strconv.ParseFloat(row[2], 64)
+	low, err3 := strconv.ParseFloat(row[3], 64)
+	closePrice, err4 := strconv.ParseFloat(row[4], 64)
+	volume, err5 := strconv.ParseFloat(row[5], 64)
+	quoteVolume, err6 := strconv.ParseFloat(row[7], 64)
+	if err0 != nil || err1 != nil || err2 != nil || err3 != nil || err4 != nil || err5 != nil || err6 != nil {
+		return domain.Kline{}, false
+	}
+
+	return domain.Kline{
+		OpenTime:    time.UnixMilli(tsMs),
+		Open:        open,
+		High:        high,
+		Low:         low,
+		Close:       closePrice,
+		QuoteVolume: quoteVolume,
+		Volume:      volume,
+	}, true
+}
+
+// GetDepth implements Exchange.
+func (e *OKXExchange) GetDepth(ctx context.Context, pair CurrencyPair, size int) (*Depth, error) {
+	values := url.Values{"instId": {instID(pair)}, "sz": {strconv.Itoa(size)}}
+
+	var data []struct {
+		Bids [][4]string `json:"bids"`
+		Asks [][4]string `json:"asks"`
+	}
+	if err := e.getJSON(ctx, "/api/v5/market/books?"+values.Encode(), &data); err != nil {
+		return nil, fmt.Errorf("okx: GetDepth: %w", err)
+	}
+	if len(data) == 0 {
+		return &Depth{Pair: pair}, nil
+	}
+
+	return &Depth{
+		Pair: pair,
+		Bids: parseOKXDepthSide(data[0].Bids),
+		Asks: parseOKXDepthSide(data[0].Asks),
+	}, nil
+}
+
+// parseOKXDepthSide parses OKX's [price, size, deprecated, numOrders] levels.
+func parseOKXDepthSide(levels [][4]string) []DepthItem {
+	out := make([]DepthItem, 0, len(levels))
+	for _, level := range levels {
+		price, err := strconv.ParseFloat(level[0], 64)
+		if err != nil {
+			continue
+		}
+		amount, err := strconv.ParseFloat(level[1], 64)
+		if err != nil {
+			continue
+		}
+		out = append(out, DepthItem{Price: price, Amount: amount})
+	}
+	return out
+}
+
+// GetTrades implements Exchange.
+func (e *OKXExchange) GetTrades(ctx context.Context, pair CurrencyPair, since int64) ([]Trade, error) {
+	values := url.Values{"instId": {instID(pair)}, "limit": {"100"}}
+
+	var data []struct {
+		TradeID string `json:"tradeId"`
+		Px      string `json:"px"`
+		Sz      string `json:"sz"`
+		Side    string `json:"side"` // "buy" or "sell"
+		Ts      string `json:"ts"`
+	}
+	if err := e.getJSON(ctx, "/api/v5/market/trades?"+values.Encode(), &data); err != nil {
+		return nil, fmt.Errorf("okx: GetTrades: %w", err)
+	}
+
+	trades := make([]Trade, 0, len(data))
+	for _, t := range data {
+		tsMs, err := strconv.ParseInt(t.Ts, 10, 64)
+		if err != nil || tsMs < since {
+			continue
+		}
+		price, err := strconv.ParseFloat(t.Px, 64)
+		if err != nil {
+			continue
+		}
+		amount, err := strconv.ParseFloat(t.Sz, 64)
+		if err != nil {
+			continue
+		}
+
+		side := domain.SideShort
+		if t.Side == "buy" {
+			side = domain.SideLong
+		}
+
+		trades = append(trades, Trade{
+			Pair:      pair,
+			TradeID:   t.TradeID,
+			Price:     price,
+			Amount:    amount,
+			Side:      side,
+			Timestamp: time.UnixMilli(tsMs),
+		})
+	}
+	return trades, nil
+}
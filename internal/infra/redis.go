@@ -0,0 +1,32 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// NewRedisClient creates a Redis client for redisURL, verifying
+// connectivity with a PING. Returns a nil client (and a nil error) when
+// redisURL is empty, so callers can treat a missing REDIS_URL as "Redis-
+// backed features disabled" rather than a startup failure.
+func NewRedisClient(ctx context.Context, redisURL string) (*redis.Client, error) {
+	if redisURL == "" {
+		return nil, nil
+	}
+
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REDIS_URL: %w", err)
+	}
+
+	client := redis.NewClient(opts)
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to ping redis: %w", err)
+	}
+
+	log.Println("✓ Redis connected successfully")
+	return client, nil
+}
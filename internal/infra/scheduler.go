@@ -2,65 +2,226 @@ package infra
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
+	"sync"
+	"time"
 
 	"github.com/robfig/cron/v3"
 
-	"neurotrade/internal/usecase"
+	"neurotrade/internal/repository"
 )
 
-// Scheduler manages scheduled tasks
+// MarketScanner runs one AI market scan across every eligible user. Satisfied
+// by *usecase.TradingService.
+type MarketScanner interface {
+	ProcessMarketScan(ctx context.Context, balance float64) error
+}
+
+// SignalReviewer audits past signals and marks them WIN/LOSS/FLOATING.
+// Satisfied by *service.ReviewService.
+type SignalReviewer interface {
+	ReviewPastSignals(ctx context.Context, olderThanMinutes int) error
+}
+
+// StalePositionCloser force-closes open paper positions older than maxAge.
+// Satisfied by *usecase.TradingService.
+type StalePositionCloser interface {
+	CloseStalePositions(ctx context.Context, maxAge time.Duration) error
+}
+
+// RollingBacktester replays a trailing lookback window through a backtest
+// Engine and persists the result. Satisfied by *backtest.RollingRunner.
+type RollingBacktester interface {
+	RunRolling(ctx context.Context, paramsJSON []byte) error
+}
+
+// marketScanParams is the params_json shape for a MARKET_SCAN job.
+type marketScanParams struct {
+	Balance float64 `json:"balance"`
+}
+
+// reviewSignalsParams is the params_json shape for a REVIEW_SIGNALS job.
+type reviewSignalsParams struct {
+	OlderThanMinutes int `json:"older_than_minutes"`
+}
+
+// closeStalePositionsParams is the params_json shape for a
+// CLOSE_STALE_POSITIONS job.
+type closeStalePositionsParams struct {
+	MaxAgeHours int `json:"max_age_hours"`
+}
+
+// Scheduler runs scheduled_jobs rows on their configured cron expressions.
+// Unlike the Phase 3 cron jobs wired directly in cmd/app/main.go, every job
+// here is DB-driven: adding, pausing or retiming a job is an admin API call
+// (see AdminHandler's scheduled-jobs endpoints) rather than a redeploy.
 type Scheduler struct {
-	cron           *cron.Cron
-	tradingService *usecase.TradingService
-	balance        float64
+	jobRepo           *repository.ScheduledJobRepository
+	marketScanner     MarketScanner
+	signalReviewer    SignalReviewer
+	stalePositions    StalePositionCloser
+	rollingBacktester RollingBacktester
+	defaultBalance    float64
+
+	mu   sync.Mutex
+	cron *cron.Cron
 }
 
-// NewScheduler creates a new scheduler
-func NewScheduler(tradingService *usecase.TradingService, balance float64) *Scheduler {
+// NewScheduler creates a new Scheduler
+func NewScheduler(
+	jobRepo *repository.ScheduledJobRepository,
+	marketScanner MarketScanner,
+	signalReviewer SignalReviewer,
+	stalePositions StalePositionCloser,
+	rollingBacktester RollingBacktester,
+	defaultBalance float64,
+) *Scheduler {
 	return &Scheduler{
-		cron:           cron.New(),
-		tradingService: tradingService,
-		balance:        balance,
+		jobRepo:           jobRepo,
+		marketScanner:     marketScanner,
+		signalReviewer:    signalReviewer,
+		stalePositions:    stalePositions,
+		rollingBacktester: rollingBacktester,
+		defaultBalance:    defaultBalance,
 	}
 }
 
-// Start starts the scheduler
-func (s *Scheduler) Start() error {
+// Start loads every enabled scheduled_jobs row and begins running them
+func (s *Scheduler) Start(ctx context.Context) error {
 	log.Println("Starting scheduler...")
 
-	// Schedule market scan at minute 59 of every hour (59 * * * *)
-	_, err := s.cron.AddFunc("59 * * * *", func() {
-		ctx := context.Background()
-		log.Println("⏰ Cron Triggered: Starting scheduled market scan...")
+	if err := s.Reload(ctx); err != nil {
+		return err
+	}
 
-		if err := s.tradingService.ProcessMarketScan(ctx, s.balance); err != nil {
-			log.Printf("ERROR: Scheduled market scan failed: %v", err)
-		}
-	})
+	log.Println("[OK] Scheduler started successfully")
+	return nil
+}
 
+// Reload stops the current cron instance (if any) and rebuilds it from the
+// scheduled_jobs table, under mu so concurrent admin updates can't race a
+// running tick. Call this after any Create/Update/SetEnabled so the change
+// takes effect without a restart.
+func (s *Scheduler) Reload(ctx context.Context) error {
+	jobs, err := s.jobRepo.GetEnabled(ctx)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to load scheduled jobs: %w", err)
 	}
 
-	// Start the cron scheduler
-	s.cron.Start()
-	log.Println("✓ Scheduler started successfully")
-	log.Println("✓ Market scan scheduled at minute 59 of every hour (59 * * * *)")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cron != nil {
+		s.cron.Stop()
+	}
+	s.cron = cron.New()
 
+	for _, job := range jobs {
+		job := job
+		_, err := s.cron.AddFunc(job.CronExpr, func() {
+			if err := s.runJob(context.Background(), job); err != nil {
+				log.Printf("ERROR: Scheduled job %q (%s) failed: %v", job.Name, job.JobType, err)
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("failed to schedule job %q (%s): %w", job.Name, job.CronExpr, err)
+		}
+		log.Printf("[OK] Scheduled job %q: %s on %q", job.Name, job.JobType, job.CronExpr)
+	}
+
+	s.cron.Start()
 	return nil
 }
 
 // Stop stops the scheduler gracefully
 func (s *Scheduler) Stop() {
 	log.Println("Stopping scheduler...")
-	s.cron.Stop()
-	log.Println("✓ Scheduler stopped")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cron != nil {
+		s.cron.Stop()
+	}
+
+	log.Println("[OK] Scheduler stopped")
+}
+
+// RunNow runs job immediately, outside its cron schedule (used by the admin
+// manual-trigger endpoint and by RunNowByID below)
+func (s *Scheduler) RunNow(ctx context.Context, job *repository.ScheduledJob) error {
+	return s.runJob(ctx, job)
+}
+
+// RunNowByID loads job by id and runs it immediately
+func (s *Scheduler) RunNowByID(ctx context.Context, id int) error {
+	job, err := s.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.runJob(ctx, job)
+}
+
+// NextRun computes when cronExpr will next fire after now, for the admin
+// "next fire time" preview. Uses cron.ParseStandard so the preview matches
+// exactly what AddFunc above would schedule.
+func NextRun(cronExpr string, now time.Time) (time.Time, error) {
+	schedule, err := cron.ParseStandard(cronExpr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid cron expression %q: %w", cronExpr, err)
+	}
+	return schedule.Next(now), nil
 }
 
-// RunNow triggers an immediate market scan (useful for testing)
-func (s *Scheduler) RunNow() error {
-	ctx := context.Background()
-	log.Println("🚀 Manual Trigger: Starting immediate market scan...")
-	return s.tradingService.ProcessMarketScan(ctx, s.balance)
+// runJob dispatches job to the service matching its JobType
+func (s *Scheduler) runJob(ctx context.Context, job *repository.ScheduledJob) error {
+	log.Printf("Cron triggered: %q (%s)", job.Name, job.JobType)
+
+	switch job.JobType {
+	case repository.JobTypeMarketScan:
+		var params marketScanParams
+		decodeParams(job.ParamsJSON, &params)
+		balance := params.Balance
+		if balance <= 0 {
+			balance = s.defaultBalance
+		}
+		return s.marketScanner.ProcessMarketScan(ctx, balance)
+
+	case repository.JobTypeReviewSignals:
+		var params reviewSignalsParams
+		decodeParams(job.ParamsJSON, &params)
+		olderThanMinutes := params.OlderThanMinutes
+		if olderThanMinutes <= 0 {
+			olderThanMinutes = 60
+		}
+		return s.signalReviewer.ReviewPastSignals(ctx, olderThanMinutes)
+
+	case repository.JobTypeCloseStalePositions:
+		var params closeStalePositionsParams
+		decodeParams(job.ParamsJSON, &params)
+		maxAgeHours := params.MaxAgeHours
+		if maxAgeHours <= 0 {
+			maxAgeHours = 24
+		}
+		return s.stalePositions.CloseStalePositions(ctx, time.Duration(maxAgeHours)*time.Hour)
+
+	case repository.JobTypeBacktestRolling:
+		return s.rollingBacktester.RunRolling(ctx, job.ParamsJSON)
+
+	default:
+		return fmt.Errorf("unknown job type: %s", job.JobType)
+	}
+}
+
+// decodeParams unmarshals raw into dst, logging (not failing) on a malformed
+// params_json so one bad row can't crash a tick -- the job just runs with
+// whatever zero-value defaults its case above falls back to.
+func decodeParams(raw []byte, dst interface{}) {
+	if len(raw) == 0 {
+		return
+	}
+	if err := json.Unmarshal(raw, dst); err != nil {
+		log.Printf("WARNING: Scheduler: failed to parse params_json, using defaults: %v", err)
+	}
 }
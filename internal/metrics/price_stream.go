@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// PriceStreamMessagesTotal counts every mark-price tick BinanceTickerPriceService
+// decodes off its websocket streams, across all subscribed symbols.
+var PriceStreamMessagesTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "price_stream_messages_total",
+	Help: "Total mark-price ticks received over Binance websocket streams",
+})
+
+// PriceStreamReconnectsTotal counts every time a symbol's mark-price stream
+// had to be re-opened after disconnecting.
+var PriceStreamReconnectsTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "price_stream_reconnects_total",
+	Help: "Total websocket reconnects across all subscribed mark-price streams",
+})
+
+// PriceStreamStaleFallbacksTotal counts every symbol service.PriceStream
+// served from the REST poller because its websocket-cached price was
+// missing or older than the staleness threshold.
+var PriceStreamStaleFallbacksTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "price_stream_stale_fallbacks_total",
+	Help: "Total symbols falling back to REST price fetches due to a stale or missing websocket price",
+})
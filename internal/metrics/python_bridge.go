@@ -0,0 +1,108 @@
+// Package metrics holds the Prometheus instrumentation shared across the
+// Go<->Python link, structured the way client libraries like the Prometheus
+// HTTP API bindings wrap a transport: collectors are registered once here,
+// and Wrap hands back an *http.Client whose RoundTripper records them, so
+// adapter.PythonBridge doesn't need every method touched individually.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// PythonBridgeRequestDuration tracks round-trip latency for every call the
+// Go side makes to the Python engine, by endpoint/method/status.
+var PythonBridgeRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "python_bridge_request_duration_seconds",
+	Help:    "PythonBridge HTTP request duration in seconds",
+	Buckets: prometheus.DefBuckets,
+}, []string{"endpoint", "method", "status"})
+
+// PythonBridgeRequestsTotal counts every PythonBridge HTTP request by the
+// same labels as PythonBridgeRequestDuration, for rate() queries.
+var PythonBridgeRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "python_bridge_requests_total",
+	Help: "Total PythonBridge HTTP requests",
+}, []string{"endpoint", "method", "status"})
+
+// PythonBridgeErrorsTotal counts PythonBridge failures by kind: "timeout" and
+// "http" are recorded by the wrapped RoundTripper; "decode" is recorded by
+// PythonBridge's own methods when a 200 response fails to unmarshal.
+var PythonBridgeErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "python_bridge_errors_total",
+	Help: "Total PythonBridge failures by kind (timeout|http|decode)",
+}, []string{"kind"})
+
+// PythonBridgeWSConnected mirrors the Python engine's own report of whether
+// its Binance WebSocket price cache is connected (1) or not (0), read off
+// GetWebSocketPrices' REST response. Once EnablePriceStream is on,
+// adapter.PriceStream sets it from its own connection state instead.
+var PythonBridgeWSConnected = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "python_bridge_ws_connected",
+	Help: "Whether the Python engine's WebSocket price feed is connected (1) or not (0)",
+})
+
+// PythonBridgeLastAnalysisSignalCount is the number of valid signals returned
+// by the most recent AnalyzeMarket call.
+var PythonBridgeLastAnalysisSignalCount = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "python_bridge_last_analysis_signal_count",
+	Help: "Number of valid signals returned by the most recent AnalyzeMarket call",
+})
+
+// RecordDecodeError increments PythonBridgeErrorsTotal for a response body
+// that returned 200 but failed to unmarshal, since that's invisible to the
+// RoundTripper (which only sees the status code, not the body).
+func RecordDecodeError() {
+	PythonBridgeErrorsTotal.WithLabelValues("decode").Inc()
+}
+
+// roundTripper wraps an http.RoundTripper, recording PythonBridgeRequestDuration/
+// PythonBridgeRequestsTotal/PythonBridgeErrorsTotal around every call it makes.
+type roundTripper struct {
+	next http.RoundTripper
+}
+
+// Wrap returns a copy of client instrumented to record PythonBridge metrics
+// around every request, via a RoundTripper -- so NewPythonBridge can opt in
+// without every method needing to record metrics itself.
+func Wrap(client *http.Client) *http.Client {
+	next := client.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	wrapped := *client
+	wrapped.Transport = &roundTripper{next: next}
+	return &wrapped
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := req.URL.Path
+	method := req.Method
+
+	start := time.Now()
+	resp, err := rt.next.RoundTrip(req)
+	duration := time.Since(start).Seconds()
+
+	status := "error"
+	if err != nil {
+		kind := "http"
+		if os.IsTimeout(err) || req.Context().Err() == context.DeadlineExceeded {
+			kind = "timeout"
+		}
+		PythonBridgeErrorsTotal.WithLabelValues(kind).Inc()
+	} else {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+
+	PythonBridgeRequestDuration.WithLabelValues(endpoint, method, status).Observe(duration)
+	PythonBridgeRequestsTotal.WithLabelValues(endpoint, method, status).Inc()
+
+	return resp, err
+}
@@ -1,21 +1,55 @@
 package middleware
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
 )
 
-// JWTClaims represents the JWT token claims
+// AccessTokenTTL is how long an access JWT is valid. Short-lived by design
+// -- a stolen access token is only ever useful for this long, unlike the
+// old single 24h JWT with no revocation path. RefreshTokenTTL is how long a
+// refresh token (and the Session row backing it) stays usable before the
+// user has to log in again.
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// TokenType values for JWTClaims.TokenType. Only access tokens are ever
+// minted as JWTs today (refresh tokens stay opaque random values, see
+// GenerateRefreshToken) but the claim documents the field for whichever
+// comes next.
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+// JWTClaims represents the JWT token claims. ID (the standard "jti" claim)
+// uniquely identifies this access token so it can be killed before its
+// natural expiry via RevokeJTI -- AuthMiddleware rejects any token whose
+// jti is in the revoked set. SessionID ties the token back to the Session
+// row it was issued alongside, so a handler can revoke that session (or
+// force-logout every token issued under it) without needing the raw
+// refresh-token cookie to look the session up by hash.
 type JWTClaims struct {
-	UserID uuid.UUID `json:"user_id"`
-	Role   string    `json:"role"`
+	UserID    uuid.UUID `json:"user_id"`
+	Role      string    `json:"role"`
+	TokenType string    `json:"token_type"`
+	SessionID uuid.UUID `json:"session_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -28,19 +62,239 @@ func GetJWTSecret() string {
 	return secret
 }
 
-// GenerateJWT generates a new JWT token for a user
-func GenerateJWT(userID uuid.UUID, role string) (string, error) {
+// KeySet resolves which secret to sign new access tokens with, and which
+// secret verifies an older token whose kid no longer matches the current
+// signing key -- so rotating JWT_SECRET doesn't log out every existing
+// session, only ones that outlive every retained previous key.
+type KeySet interface {
+	// CurrentKID and SigningKey are used for new tokens.
+	CurrentKID() string
+	SigningKey() []byte
+
+	// VerificationKey returns the secret for kid, including retired keys
+	// kept only for verification, or false if kid is unrecognized.
+	VerificationKey(kid string) ([]byte, bool)
+}
+
+// envKeySet is the default KeySet, loaded once from environment variables:
+// JWT_KID/JWT_SECRET for the current signing key, plus JWT_PREVIOUS_KEYS (a
+// comma-separated list of "kid:secret" pairs) for keys retired by a
+// rotation but still needed to verify tokens minted before it.
+type envKeySet struct {
+	currentKID string
+	current    []byte
+	previous   map[string][]byte
+}
+
+func loadKeySetFromEnv() *envKeySet {
+	kid := os.Getenv("JWT_KID")
+	if kid == "" {
+		kid = "v1"
+	}
+
+	ks := &envKeySet{
+		currentKID: kid,
+		current:    []byte(GetJWTSecret()),
+		previous:   make(map[string][]byte),
+	}
+
+	for _, pair := range strings.Split(os.Getenv("JWT_PREVIOUS_KEYS"), ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kidSecret := strings.SplitN(pair, ":", 2)
+		if len(kidSecret) != 2 || kidSecret[0] == "" || kidSecret[1] == "" {
+			continue
+		}
+		ks.previous[kidSecret[0]] = []byte(kidSecret[1])
+	}
+
+	return ks
+}
+
+func (ks *envKeySet) CurrentKID() string { return ks.currentKID }
+func (ks *envKeySet) SigningKey() []byte { return ks.current }
+
+func (ks *envKeySet) VerificationKey(kid string) ([]byte, bool) {
+	if kid == ks.currentKID {
+		return ks.current, true
+	}
+	key, ok := ks.previous[kid]
+	return key, ok
+}
+
+// keySet is the process-wide KeySet access tokens are signed and verified
+// against, accessed only via getActiveKeySet. Resolved lazily (on first
+// use, via keySetOnce) rather
+// than at package-init time, since package-level var initializers run
+// before main()'s godotenv.Load() populates JWT_SECRET/JWT_KID/
+// JWT_PREVIOUS_KEYS -- resolving eagerly here would silently sign every
+// token with the hardcoded GetJWTSecret fallback for the process's entire
+// lifetime on any .env-only deployment.
+var (
+	keySetOnce sync.Once
+	keySet     KeySet
+)
+
+func getActiveKeySet() KeySet {
+	keySetOnce.Do(func() {
+		keySet = loadKeySetFromEnv()
+	})
+	return keySet
+}
+
+// GenerateAccessToken issues a short-lived (AccessTokenTTL) JWT for a user
+// under sessionID (the Session row its paired refresh token is backed by),
+// returning the signed token alongside its claims so the caller can read
+// back ExpiresAt/ID (jti) for setting a matching cookie and for revocation.
+func GenerateAccessToken(userID uuid.UUID, role string, sessionID uuid.UUID) (string, *JWTClaims, error) {
 	claims := &JWTClaims{
-		UserID: userID,
-		Role:   role,
+		UserID:    userID,
+		Role:      role,
+		TokenType: TokenTypeAccess,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(GetJWTSecret()))
+	ks := getActiveKeySet()
+	token.Header["kid"] = ks.CurrentKID()
+
+	signed, err := token.SignedString(ks.SigningKey())
+	if err != nil {
+		return "", nil, err
+	}
+
+	return signed, claims, nil
+}
+
+// GenerateRefreshToken returns a new opaque 32-byte random refresh token,
+// hex-encoded. The raw value is only ever handed to the client (as a
+// cookie/response field); HashRefreshToken's output is what's persisted.
+func GenerateRefreshToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// HashRefreshToken returns the SHA-256 hex digest of a raw refresh token,
+// for storage/lookup so the raw value never touches the database.
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// revokedJTIs tracks access-token jtis killed before their natural expiry
+// (via RevokeJTI, called on logout/logout-all/refresh-rotation) so
+// AuthMiddleware can reject them even though the JWT signature still
+// verifies. Modeled on CooldownTracker in internal/service/order_rate_limiter.go:
+// a mutex-guarded map, with no persistence -- a revoked jti is only ever
+// checked against tokens that are already short-lived (AccessTokenTTL), so
+// losing the set on restart just means previously-revoked tokens become
+// valid again for at most that window.
+var revokedJTIs = newJTIRevocationSet()
+
+type jtiRevocationSet struct {
+	mu   sync.Mutex
+	jtis map[string]time.Time
+}
+
+func newJTIRevocationSet() *jtiRevocationSet {
+	return &jtiRevocationSet{jtis: make(map[string]time.Time)}
+}
+
+// revocationRedis is the optional Redis-backed layer RevokeJTI/IsJTIRevoked
+// sync through, so a jti revoked on one instance is honored by every other
+// instance immediately instead of relying on each instance's own in-memory
+// set alone. Nil (the default) leaves revocation local-only, same as before
+// Redis support existed.
+var revocationRedis *redis.Client
+
+// SetRevocationRedisClient wires a Redis client into the jti revocation
+// path. Call once at startup; passing nil disables the Redis-backed layer.
+func SetRevocationRedisClient(client *redis.Client) {
+	revocationRedis = client
+}
+
+// revokedJTIRedisKey namespaces jti revocation entries in Redis.
+func revokedJTIRedisKey(jti string) string {
+	return "revoked_jti:" + jti
+}
+
+// RevokeJTI marks an access token's jti as revoked until its expiresAt,
+// after which AuthMiddleware would reject it anyway so it's safe to forget.
+// Also pushed to Redis (best-effort) when SetRevocationRedisClient has been
+// called, so the revocation is visible to every other instance right away.
+func RevokeJTI(jti string, expiresAt time.Time) {
+	revokedJTIs.mu.Lock()
+	revokedJTIs.jtis[jti] = expiresAt
+	pruneExpiredJTIsLocked(revokedJTIs)
+	revokedJTIs.mu.Unlock()
+
+	if revocationRedis == nil {
+		return
+	}
+
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := revocationRedis.Set(ctx, revokedJTIRedisKey(jti), "1", ttl).Err(); err != nil {
+		log.Printf("[WARN] middleware: failed to sync jti revocation to Redis: %v", err)
+	}
+}
+
+// IsJTIRevoked reports whether jti has been revoked and not yet expired,
+// checking the local set first and falling back to Redis (when configured)
+// so a revocation issued on another instance is still honored here. A
+// Redis error fails open (logged) rather than rejecting every request on a
+// Redis blip -- the local set and the token's own short TTL are the load-
+// bearing protections; Redis only closes the cross-instance gap.
+func IsJTIRevoked(jti string) bool {
+	revokedJTIs.mu.Lock()
+	expiresAt, ok := revokedJTIs.jtis[jti]
+	if ok && time.Now().After(expiresAt) {
+		delete(revokedJTIs.jtis, jti)
+		ok = false
+	}
+	revokedJTIs.mu.Unlock()
+
+	if ok {
+		return true
+	}
+	if revocationRedis == nil {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	n, err := revocationRedis.Exists(ctx, revokedJTIRedisKey(jti)).Result()
+	if err != nil {
+		log.Printf("[WARN] middleware: failed to check Redis jti revocation: %v", err)
+		return false
+	}
+	return n > 0
+}
+
+// pruneExpiredJTIsLocked drops entries past their expiry so the set doesn't
+// grow unbounded between revocations. Caller must hold s.mu.
+func pruneExpiredJTIsLocked(s *jtiRevocationSet) {
+	now := time.Now()
+	for jti, expiresAt := range s.jtis {
+		if now.After(expiresAt) {
+			delete(s.jtis, jti)
+		}
+	}
 }
 
 // AuthMiddleware validates JWT token and sets user context
@@ -52,6 +306,7 @@ func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 			// Try to get from cookie
 			cookie, err := c.Cookie("token")
 			if err != nil {
+				AuthFailureTotal.WithLabelValues("missing_token").Inc()
 				return echo.NewHTTPError(http.StatusUnauthorized, "Missing authentication token")
 			}
 			authHeader = "Bearer " + cookie.Value
@@ -60,6 +315,7 @@ func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		// Extract token from Bearer scheme
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
+			AuthFailureTotal.WithLabelValues("bad_header_format").Inc()
 			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid authorization header format")
 		}
 
@@ -71,22 +327,46 @@ func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 			}
-			return []byte(GetJWTSecret()), nil
+
+			// Tokens minted before kid existed have no header claim; treat
+			// them as signed by the current key so rollout doesn't log
+			// everyone out.
+			ks := getActiveKeySet()
+			kid, _ := token.Header["kid"].(string)
+			if kid == "" {
+				kid = ks.CurrentKID()
+			}
+
+			key, ok := ks.VerificationKey(kid)
+			if !ok {
+				return nil, fmt.Errorf("unknown signing key id: %s", kid)
+			}
+			return key, nil
 		})
 
 		if err != nil {
+			JWTParseErrorTotal.Inc()
+			AuthFailureTotal.WithLabelValues("invalid_token").Inc()
 			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired token")
 		}
 
 		// Extract claims
 		claims, ok := token.Claims.(*JWTClaims)
 		if !ok || !token.Valid {
+			AuthFailureTotal.WithLabelValues("invalid_claims").Inc()
 			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token claims")
 		}
 
+		if IsJTIRevoked(claims.ID) {
+			AuthFailureTotal.WithLabelValues("revoked_token").Inc()
+			return echo.NewHTTPError(http.StatusUnauthorized, "Token has been revoked")
+		}
+
 		// Set user context
 		c.Set("user_id", claims.UserID)
 		c.Set("role", claims.Role)
+		c.Set("jti", claims.ID)
+		c.Set("session_id", claims.SessionID)
 
 		return next(c)
 	}
@@ -127,3 +407,23 @@ func GetUserRole(c echo.Context) (string, error) {
 	}
 	return role, nil
 }
+
+// GetJTI extracts the access token's jti from echo context
+func GetJTI(c echo.Context) (string, error) {
+	jti, ok := c.Get("jti").(string)
+	if !ok {
+		return "", fmt.Errorf("jti not found in context")
+	}
+	return jti, nil
+}
+
+// GetSessionID extracts the Session row backing the current access token
+// from echo context, so a handler like Logout can revoke it directly
+// without needing the raw refresh-token cookie to look it up by hash.
+func GetSessionID(c echo.Context) (uuid.UUID, error) {
+	sessionID, ok := c.Get("session_id").(uuid.UUID)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("session_id not found in context")
+	}
+	return sessionID, nil
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// AuthFailureTotal counts AuthMiddleware rejections by reason, so abuse
+// (credential stuffing, replayed revoked tokens) shows up as a rate spike
+// well before it reaches PanicButton/cooldown territory.
+var AuthFailureTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "neurotrade_auth_failure_total",
+	Help: "Total AuthMiddleware rejections by reason",
+}, []string{"reason"})
+
+// JWTParseErrorTotal counts jwt.ParseWithClaims failures, a narrower signal
+// than AuthFailureTotal{reason="invalid_token"} for distinguishing a bad
+// signing key rollout from ordinary expired/garbage tokens.
+var JWTParseErrorTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "neurotrade_jwt_parse_error_total",
+	Help: "Total JWT parse/validation errors in AuthMiddleware",
+})
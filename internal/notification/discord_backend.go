@@ -0,0 +1,88 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"neurotrade/internal/reliability"
+)
+
+// DiscordBackend posts already-rendered text to a Discord incoming webhook
+type DiscordBackend struct {
+	webhookURL string
+	enabled    bool
+	httpClient *http.Client
+	breaker    *reliability.CircuitBreaker
+}
+
+type discordMessage struct {
+	Content string `json:"content"`
+}
+
+// NewDiscordBackend creates a DiscordBackend. Send silently no-ops when
+// webhookURL is empty.
+func NewDiscordBackend(webhookURL string) *DiscordBackend {
+	return &DiscordBackend{
+		webhookURL: webhookURL,
+		enabled:    webhookURL != "",
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		breaker: reliability.NewCircuitBreaker("discord", reliability.DefaultBreakerConfig()),
+	}
+}
+
+// Name identifies this backend for Router's failure logs
+func (b *DiscordBackend) Name() string {
+	return "discord"
+}
+
+// Send posts text to the configured Discord webhook
+func (b *DiscordBackend) Send(ctx context.Context, topic Topic, severity Severity, text string) error {
+	if !b.enabled {
+		return nil
+	}
+
+	if err := b.breaker.Allow(); err != nil {
+		return nil
+	}
+
+	if err := b.doSend(ctx, text); err != nil {
+		b.breaker.RecordFailure(err)
+		return err
+	}
+
+	b.breaker.RecordSuccess()
+	return nil
+}
+
+func (b *DiscordBackend) doSend(ctx context.Context, text string) error {
+	jsonData, err := json.Marshal(discordMessage{Content: text})
+	if err != nil {
+		return fmt.Errorf("failed to marshal discord message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.webhookURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to build discord request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send discord message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("discord webhook error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
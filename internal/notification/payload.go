@@ -0,0 +1,29 @@
+package notification
+
+import "neurotrade/internal/domain"
+
+// Payload carries whatever a topic's renderer needs. Callers only populate
+// the fields relevant to the topic they're emitting -- Signal is nil for
+// the execution-oriented topics that don't carry a full signal.
+type Payload struct {
+	// Signal backs TopicNewSignal/TopicSignalReview, reusing
+	// Signal.ReviewResult the same way the old telegram adapter did.
+	Signal *domain.Signal
+	PnL    *float64
+
+	// FundingPaid backs TopicPositionClosedWin/TopicPositionClosedLoss,
+	// surfacing VirtualBrokerService's accrued funding cost separately from
+	// PnL so a "winning" trade that still lost money isn't a mystery.
+	FundingPaid *float64
+
+	// Symbol/Side/Price back the execution-oriented topics
+	// (TopicPositionOpened/TopicRealTradeExecuted/TopicRealTradeFailed/
+	// TopicBrokerError) that don't necessarily carry a Signal.
+	Symbol string
+	Side   string
+	Price  float64
+
+	// Detail is a free-form message for topics without a structured
+	// renderer, e.g. the error text for TopicBrokerError/TopicRealTradeFailed.
+	Detail string
+}
@@ -0,0 +1,211 @@
+package notification
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// location is loaded once from TZ (default Asia/Jakarta, matching the old
+// telegram adapter's behavior), falling back to UTC if TZ is invalid.
+var location = loadLocation()
+
+func loadLocation() *time.Location {
+	tz := os.Getenv("TZ")
+	if tz == "" {
+		tz = "Asia/Jakarta"
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// render builds the human-readable text for topic/payload, shared by every
+// backend so formatting isn't duplicated per backend.
+func render(topic Topic, payload Payload) string {
+	switch topic {
+	case TopicNewSignal:
+		return renderNewSignal(payload)
+	case TopicSignalReview:
+		return renderSignalReview(payload)
+	case TopicPositionOpened:
+		return renderPositionOpened(payload)
+	case TopicPositionClosedWin, TopicPositionClosedLoss:
+		return renderPositionClosed(payload)
+	case TopicRealTradeExecuted:
+		return renderRealTradeExecuted(payload)
+	case TopicRealTradeFailed:
+		return renderRealTradeFailed(payload)
+	case TopicBrokerError:
+		return renderBrokerError(payload)
+	default:
+		return payload.Detail
+	}
+}
+
+// renderNewSignal matches the old telegram.NotificationService.SendSignal text
+func renderNewSignal(payload Payload) string {
+	signal := payload.Signal
+	if signal == nil {
+		return payload.Detail
+	}
+
+	sideEmoji := "🟢"
+	if signal.Type == "SHORT" {
+		sideEmoji = "🔴"
+	}
+
+	return fmt.Sprintf(
+		"🚀 *NEW TRADING SIGNAL*\n\n"+
+			"%s *%s %s*\n"+
+			"━━━━━━━━━━━━━━━━━\n"+
+			"📊 Entry: `$%.4f`\n"+
+			"🛑 Stop Loss: `$%.4f`\n"+
+			"🎯 Take Profit: `$%.4f`\n"+
+			"📈 Confidence: `%d%%`\n"+
+			"🕒 Time: `%s`\n\n"+
+			"💡 *Reasoning:*\n%s",
+		sideEmoji,
+		signal.Type,
+		signal.Symbol,
+		signal.EntryPrice,
+		signal.SLPrice,
+		signal.TPPrice,
+		signal.Confidence,
+		signal.CreatedAt.In(location).Format("2006-01-02 15:04:05"),
+		signal.Reasoning,
+	)
+}
+
+// renderSignalReview matches the old telegram.NotificationService.SendReview
+// text, plus the realized PnL when payload.PnL is set
+func renderSignalReview(payload Payload) string {
+	signal := payload.Signal
+	if signal == nil {
+		return payload.Detail
+	}
+
+	var statusEmoji, statusText string
+	if signal.ReviewResult != nil {
+		switch *signal.ReviewResult {
+		case "WIN":
+			statusEmoji, statusText = "✅", "WIN"
+		case "LOSS":
+			statusEmoji, statusText = "❌", "LOSS"
+		case "FLOATING_WIN":
+			statusEmoji, statusText = "🟢", "FLOATING WIN"
+		case "FLOATING_LOSS":
+			statusEmoji, statusText = "🔴", "FLOATING LOSS"
+		case "FLOATING":
+			statusEmoji, statusText = "⚖️", "FLOATING"
+		default:
+			statusEmoji, statusText = "⏳", *signal.ReviewResult
+		}
+	} else {
+		statusEmoji, statusText = "⏳", "PENDING"
+	}
+
+	text := fmt.Sprintf(
+		"%s *SIGNAL REVIEW: %s*\n\n"+
+			"📊 Symbol: `%s`\n"+
+			"📈 Type: `%s`\n"+
+			"━━━━━━━━━━━━━━━━━\n"+
+			"🔵 Entry: `$%.4f`\n"+
+			"🛑 Stop Loss: `$%.4f`\n"+
+			"🎯 Take Profit: `$%.4f`\n"+
+			"📈 Confidence: `%d%%`\n"+
+			"🕒 Generated: `%s`\n"+
+			"🏁 Reviewed: `%s`",
+		statusEmoji,
+		statusText,
+		signal.Symbol,
+		signal.Type,
+		signal.EntryPrice,
+		signal.SLPrice,
+		signal.TPPrice,
+		signal.Confidence,
+		signal.CreatedAt.In(location).Format("2006-01-02 15:04"),
+		time.Now().In(location).Format("2006-01-02 15:04"),
+	)
+
+	if payload.PnL != nil {
+		text += fmt.Sprintf("\n💰 PnL: `$%.2f`", *payload.PnL)
+	}
+
+	return text
+}
+
+// renderPositionOpened is new with the router: there was no Telegram
+// notification for a position opening before this chunk
+func renderPositionOpened(payload Payload) string {
+	sideEmoji := "🟢"
+	if payload.Side == "SHORT" {
+		sideEmoji = "🔴"
+	}
+
+	return fmt.Sprintf(
+		"📂 *POSITION OPENED*\n\n"+
+			"%s *%s %s*\n"+
+			"📊 Entry: `$%.4f`",
+		sideEmoji, payload.Side, payload.Symbol, payload.Price,
+	)
+}
+
+// renderPositionClosed is new with the router, for BodyguardService/
+// VirtualBrokerService's own position closes (distinct from
+// TopicSignalReview, which covers the signal-level review audit)
+func renderPositionClosed(payload Payload) string {
+	statusEmoji, statusText := "✅", "CLOSED WIN"
+	if payload.PnL != nil && *payload.PnL < 0 {
+		statusEmoji, statusText = "❌", "CLOSED LOSS"
+	}
+
+	text := fmt.Sprintf(
+		"%s *%s*\n\n"+
+			"📊 Symbol: `%s`\n"+
+			"📈 Side: `%s`\n"+
+			"📍 Exit: `$%.4f`",
+		statusEmoji, statusText, payload.Symbol, payload.Side, payload.Price,
+	)
+
+	if payload.PnL != nil {
+		text += fmt.Sprintf("\n💰 PnL: `$%.2f`", *payload.PnL)
+	}
+	if payload.FundingPaid != nil && *payload.FundingPaid != 0 {
+		text += fmt.Sprintf("\n🕰️ Funding: `$%.2f`", *payload.FundingPaid)
+	}
+
+	return text
+}
+
+// renderRealTradeExecuted is new with the router, for REAL-mode execution
+// confirmations that previously only reached a log line
+func renderRealTradeExecuted(payload Payload) string {
+	return fmt.Sprintf(
+		"✅ *REAL TRADE EXECUTED*\n\n"+
+			"📊 Symbol: `%s`\n"+
+			"📈 Side: `%s`\n"+
+			"📍 Price: `$%.4f`",
+		payload.Symbol, payload.Side, payload.Price,
+	)
+}
+
+// renderRealTradeFailed is new with the router, for REAL-mode execution
+// failures that previously only reached a log line
+func renderRealTradeFailed(payload Payload) string {
+	return fmt.Sprintf(
+		"🚨 *REAL TRADE FAILED*\n\n"+
+			"📊 Symbol: `%s`\n"+
+			"📈 Side: `%s`\n"+
+			"⚠️ %s",
+		payload.Symbol, payload.Side, payload.Detail,
+	)
+}
+
+// renderBrokerError is new with the router, for broker-level failures not
+// tied to a single symbol/trade (e.g. a repository or exchange-wide error)
+func renderBrokerError(payload Payload) string {
+	return fmt.Sprintf("🚨 *BROKER ERROR*\n\n⚠️ %s", payload.Detail)
+}
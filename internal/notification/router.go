@@ -0,0 +1,72 @@
+package notification
+
+import (
+	"context"
+	"log"
+)
+
+// Backend delivers one already-rendered message for a topic/severity. A
+// backend decides for itself how to wrap the text further (e.g.
+// DiscordBackend nests it in a JSON "content" field); Router only decides
+// WHETHER to call Send, via BackendConfig's filter.
+type Backend interface {
+	Name() string
+	Send(ctx context.Context, topic Topic, severity Severity, text string) error
+}
+
+// BackendConfig filters which topics/severities reach a Backend. Topics, if
+// non-empty, restricts delivery to exactly those topics; MinSeverity is
+// always enforced on top of that, e.g. MinSeverity=SeverityError with no
+// Topics sends every ERROR-severity topic and nothing else.
+type BackendConfig struct {
+	Backend     Backend
+	MinSeverity Severity
+	Topics      []Topic // empty = every topic, subject to MinSeverity
+}
+
+func (c BackendConfig) allows(topic Topic, severity Severity) bool {
+	if severity < c.MinSeverity {
+		return false
+	}
+	if len(c.Topics) == 0 {
+		return true
+	}
+	for _, t := range c.Topics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
+
+// Router fans a single Emit out to every registered backend whose
+// BackendConfig allows the topic/severity, rendering the message once and
+// reusing it across backends.
+type Router struct {
+	backends []BackendConfig
+}
+
+// NewRouter creates a Router over the given backend configs
+func NewRouter(backends ...BackendConfig) *Router {
+	return &Router{backends: backends}
+}
+
+// Emit renders payload for topic and sends it to every backend whose filter
+// allows this topic/severity. A single backend failing is logged and
+// doesn't stop delivery to the others; like the old SendSignal/SendReview,
+// Emit itself always returns nil so callers can fire-and-forget it.
+func (r *Router) Emit(ctx context.Context, topic Topic, payload Payload) error {
+	severity := severityFor(topic)
+	text := render(topic, payload)
+
+	for _, cfg := range r.backends {
+		if !cfg.allows(topic, severity) {
+			continue
+		}
+		if err := cfg.Backend.Send(ctx, topic, severity, text); err != nil {
+			log.Printf("[WARN] notification: %s backend failed for topic %s: %v", cfg.Backend.Name(), topic, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+
+	"neurotrade/internal/reliability"
+)
+
+// SMTPBackend emails already-rendered text through a standard SMTP relay,
+// for operators who want notifications in their inbox rather than a chat
+// app. Uses only net/smtp, no external mail library.
+type SMTPBackend struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+	to       string
+	enabled  bool
+	breaker  *reliability.CircuitBreaker
+}
+
+// NewSMTPBackend creates an SMTPBackend. Send silently no-ops when host, to,
+// or from is empty.
+func NewSMTPBackend(host, port, username, password, from, to string) *SMTPBackend {
+	return &SMTPBackend{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+		to:       to,
+		enabled:  host != "" && from != "" && to != "",
+		breaker:  reliability.NewCircuitBreaker("smtp", reliability.DefaultBreakerConfig()),
+	}
+}
+
+// Name identifies this backend for Router's failure logs
+func (b *SMTPBackend) Name() string {
+	return "smtp"
+}
+
+// Send emails text as the body of a plain-text message, subject set to
+// topic/severity so a mail client's subject line is useful without opening
+// the message.
+func (b *SMTPBackend) Send(ctx context.Context, topic Topic, severity Severity, text string) error {
+	if !b.enabled {
+		return nil
+	}
+
+	if err := b.breaker.Allow(); err != nil {
+		return nil
+	}
+
+	if err := b.doSend(topic, severity, text); err != nil {
+		b.breaker.RecordFailure(err)
+		return err
+	}
+
+	b.breaker.RecordSuccess()
+	return nil
+}
+
+func (b *SMTPBackend) doSend(topic Topic, severity Severity, text string) error {
+	subject := fmt.Sprintf("[%s] neurotrade: %s", severity, topic)
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n", b.from, b.to, subject, text)
+
+	var auth smtp.Auth
+	if b.username != "" {
+		auth = smtp.PlainAuth("", b.username, b.password, b.host)
+	}
+
+	addr := fmt.Sprintf("%s:%s", b.host, b.port)
+	if err := smtp.SendMail(addr, auth, b.from, []string{b.to}, []byte(msg)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"neurotrade/internal/reliability"
+)
+
+// TelegramBackend sends already-rendered text to a Telegram chat via the Bot
+// API. Migrated from the old adapter/telegram.NotificationService, which
+// formatted its own messages; formatting now lives in render.go so every
+// backend shares it.
+type TelegramBackend struct {
+	botToken   string
+	chatID     string
+	enabled    bool
+	httpClient *http.Client
+	breaker    *reliability.CircuitBreaker
+}
+
+type telegramMessage struct {
+	ChatID    string `json:"chat_id"`
+	Text      string `json:"text"`
+	ParseMode string `json:"parse_mode"`
+}
+
+// NewTelegramBackend creates a TelegramBackend. Send silently no-ops when
+// botToken or chatID is empty, matching the old adapter's "Telegram not
+// configured" posture.
+func NewTelegramBackend(botToken, chatID string) *TelegramBackend {
+	return &TelegramBackend{
+		botToken: botToken,
+		chatID:   chatID,
+		enabled:  botToken != "" && chatID != "",
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		breaker: reliability.NewCircuitBreaker("telegram", reliability.DefaultBreakerConfig()),
+	}
+}
+
+// Name identifies this backend for Router's failure logs
+func (b *TelegramBackend) Name() string {
+	return "telegram"
+}
+
+// Send posts text to the configured Telegram chat. Once the circuit breaker
+// is open it returns nil without attempting the call -- a Telegram outage
+// shouldn't turn into a warning log on every single Emit.
+func (b *TelegramBackend) Send(ctx context.Context, topic Topic, severity Severity, text string) error {
+	if !b.enabled {
+		return nil
+	}
+
+	if err := b.breaker.Allow(); err != nil {
+		return nil
+	}
+
+	if err := b.doSend(ctx, text); err != nil {
+		b.breaker.RecordFailure(err)
+		return err
+	}
+
+	b.breaker.RecordSuccess()
+	return nil
+}
+
+// doSend makes a single, unretried call to the Telegram Bot API
+func (b *TelegramBackend) doSend(ctx context.Context, text string) error {
+	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", b.botToken)
+
+	payload := telegramMessage{
+		ChatID:    b.chatID,
+		Text:      text,
+		ParseMode: "Markdown",
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal telegram message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to build telegram request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send telegram message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("telegram API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
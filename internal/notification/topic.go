@@ -0,0 +1,71 @@
+// Package notification fans a single event out to multiple backends
+// (Telegram, Discord, a generic webhook, SMTP), each filtered by topic and
+// severity, so e.g. only ERROR-severity topics reach a PagerDuty-style
+// webhook while Telegram still gets every signal. Callers call Router.Emit
+// with a stable Topic key alongside the payload rather than formatting and
+// sending a message themselves.
+package notification
+
+// Topic is a stable key identifying what kind of event a notification
+// describes, so a backend can route/filter on it without parsing the
+// rendered message text.
+type Topic string
+
+const (
+	TopicNewSignal          Topic = "new_signal"
+	TopicSignalReview       Topic = "signal_review"
+	TopicPositionOpened     Topic = "position_opened"
+	TopicPositionClosedWin  Topic = "position_closed_win"
+	TopicPositionClosedLoss Topic = "position_closed_loss"
+	TopicRealTradeExecuted  Topic = "real_trade_executed"
+	TopicRealTradeFailed    Topic = "real_trade_failed"
+	TopicBrokerError        Topic = "broker_error"
+)
+
+// Severity is how urgently a Topic's notifications should be treated, so a
+// backend like a PagerDuty webhook can filter down to ERROR-only while
+// Telegram keeps getting everything.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarn
+	SeverityError
+)
+
+// String renders Severity the way a backend would want it in a subject
+// line or payload field
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "ERROR"
+	case SeverityWarn:
+		return "WARN"
+	default:
+		return "INFO"
+	}
+}
+
+// severityByTopic is each Topic's default severity. A losing close and any
+// real-trading failure are surfaced louder than routine signal/review
+// chatter, so a filtered backend (e.g. a webhook only wired for errors)
+// still sees the events that actually need attention.
+var severityByTopic = map[Topic]Severity{
+	TopicNewSignal:          SeverityInfo,
+	TopicSignalReview:       SeverityInfo,
+	TopicPositionOpened:     SeverityInfo,
+	TopicPositionClosedWin:  SeverityInfo,
+	TopicPositionClosedLoss: SeverityWarn,
+	TopicRealTradeExecuted:  SeverityInfo,
+	TopicRealTradeFailed:    SeverityError,
+	TopicBrokerError:        SeverityError,
+}
+
+// severityFor returns topic's configured severity, defaulting to
+// SeverityInfo for an unrecognized topic rather than failing Emit
+func severityFor(topic Topic) Severity {
+	if sev, ok := severityByTopic[topic]; ok {
+		return sev
+	}
+	return SeverityInfo
+}
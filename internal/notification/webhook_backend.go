@@ -0,0 +1,96 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"neurotrade/internal/reliability"
+)
+
+// WebhookBackend posts a generic JSON envelope (topic, severity, text) to
+// any HTTP endpoint expecting JSON, e.g. a PagerDuty-style ingestion URL
+// that doesn't speak Telegram/Discord's own formats.
+type WebhookBackend struct {
+	url        string
+	enabled    bool
+	httpClient *http.Client
+	breaker    *reliability.CircuitBreaker
+}
+
+type webhookMessage struct {
+	Topic    string `json:"topic"`
+	Severity string `json:"severity"`
+	Text     string `json:"text"`
+}
+
+// NewWebhookBackend creates a WebhookBackend. Send silently no-ops when url
+// is empty.
+func NewWebhookBackend(url string) *WebhookBackend {
+	return &WebhookBackend{
+		url:     url,
+		enabled: url != "",
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		breaker: reliability.NewCircuitBreaker("webhook", reliability.DefaultBreakerConfig()),
+	}
+}
+
+// Name identifies this backend for Router's failure logs
+func (b *WebhookBackend) Name() string {
+	return "webhook"
+}
+
+// Send posts the topic/severity/text envelope to the configured URL
+func (b *WebhookBackend) Send(ctx context.Context, topic Topic, severity Severity, text string) error {
+	if !b.enabled {
+		return nil
+	}
+
+	if err := b.breaker.Allow(); err != nil {
+		return nil
+	}
+
+	if err := b.doSend(ctx, topic, severity, text); err != nil {
+		b.breaker.RecordFailure(err)
+		return err
+	}
+
+	b.breaker.RecordSuccess()
+	return nil
+}
+
+func (b *WebhookBackend) doSend(ctx context.Context, topic Topic, severity Severity, text string) error {
+	jsonData, err := json.Marshal(webhookMessage{
+		Topic:    string(topic),
+		Severity: severity.String(),
+		Text:     text,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("webhook error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
@@ -0,0 +1,273 @@
+// Package portfolioguard enforces per-user exposure caps across open
+// positions, trimming the newest offending position back to the cap via
+// PositionRepository.PartialClose -- the same primitive rebalance.Service
+// uses to trim over-weight symbols.
+package portfolioguard
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"neurotrade/internal/domain"
+)
+
+// getEnvFloat gets an environment variable as float64 or returns a default value
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatVal
+		}
+	}
+	return defaultValue
+}
+
+// CorrelationGroups maps a symbol to its static correlation group, e.g.
+// BTCUSDT and ETHUSDT both belong to "majors". A symbol absent from the map
+// belongs to no group and is exempt from Config.MaxCorrelatedExposurePct.
+var CorrelationGroups = map[string]string{
+	"BTCUSDT": "majors",
+	"ETHUSDT": "majors",
+}
+
+// Config holds the per-user exposure caps, each a percent of equity
+// (user.PaperBalance + open unrealized PnL). A cap <= 0 disables that check.
+type Config struct {
+	MaxSymbolExposurePct     float64
+	MaxSideExposurePct       float64
+	MaxCorrelatedExposurePct float64
+}
+
+// ConfigFromEnv reads MAX_SYMBOL_EXPOSURE_PCT/MAX_SIDE_EXPOSURE_PCT/
+// MAX_CORRELATED_EXPOSURE_PCT, defaulting every cap to 0 (disabled)
+func ConfigFromEnv() Config {
+	return Config{
+		MaxSymbolExposurePct:     getEnvFloat("MAX_SYMBOL_EXPOSURE_PCT", 0),
+		MaxSideExposurePct:       getEnvFloat("MAX_SIDE_EXPOSURE_PCT", 0),
+		MaxCorrelatedExposurePct: getEnvFloat("MAX_CORRELATED_EXPOSURE_PCT", 0),
+	}
+}
+
+// Bucket is one exposure grouping's current utilization against its cap, for
+// reporting (see Service.Snapshot) as well as internal cap checking.
+type Bucket struct {
+	Kind     string  `json:"kind"` // "symbol", "side", or "correlation_group"
+	Label    string  `json:"label"`
+	Notional float64 `json:"notional"`
+	CapPct   float64 `json:"cap_pct"` // 0 means uncapped
+	UsedPct  float64 `json:"used_pct"`
+}
+
+// Service enforces Config's exposure caps across a user's open positions
+type Service struct {
+	positionRepo domain.PositionRepository
+	userRepo     domain.UserRepository
+	config       Config
+}
+
+// NewService creates a new portfolioguard.Service
+func NewService(positionRepo domain.PositionRepository, userRepo domain.UserRepository, config Config) *Service {
+	return &Service{positionRepo: positionRepo, userRepo: userRepo, config: config}
+}
+
+// Check aggregates positions already fetched by the caller's tick (shared
+// with BodyguardService.CheckPositionsFast, so this doesn't re-query open
+// positions) per user, and trims whichever exposure bucket first breaches
+// its cap. prices is the mark-price map already fetched for this tick; a
+// symbol missing from it falls back to the position's entry price.
+func (s *Service) Check(ctx context.Context, positions []*domain.Position, prices map[string]float64) {
+	if s.config.MaxSymbolExposurePct <= 0 && s.config.MaxSideExposurePct <= 0 && s.config.MaxCorrelatedExposurePct <= 0 {
+		return
+	}
+
+	byUser := make(map[uuid.UUID][]*domain.Position)
+	for _, pos := range positions {
+		byUser[pos.UserID] = append(byUser[pos.UserID], pos)
+	}
+
+	for userID, userPositions := range byUser {
+		s.checkUser(ctx, userID, userPositions, prices)
+	}
+}
+
+// Snapshot reports userID's current utilization of every configured cap,
+// without trimming anything. Used by the admin exposure dashboard.
+func (s *Service) Snapshot(ctx context.Context, userID uuid.UUID) ([]Bucket, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := s.positionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	open := make([]*domain.Position, 0, len(positions))
+	for _, pos := range positions {
+		if pos.Status == domain.StatusOpen {
+			open = append(open, pos)
+		}
+	}
+
+	agg := aggregate(open, nil)
+	equity := user.PaperBalance + agg.unrealizedPnL
+
+	var buckets []Bucket
+	for symbol, notional := range agg.symbolNotional {
+		buckets = append(buckets, bucketFor("symbol", symbol, notional, equity, s.config.MaxSymbolExposurePct))
+	}
+	for side, notional := range agg.sideNotional {
+		buckets = append(buckets, bucketFor("side", side, notional, equity, s.config.MaxSideExposurePct))
+	}
+	for group, notional := range agg.groupNotional {
+		buckets = append(buckets, bucketFor("correlation_group", group, notional, equity, s.config.MaxCorrelatedExposurePct))
+	}
+
+	return buckets, nil
+}
+
+func bucketFor(kind, label string, notional, equity, capPct float64) Bucket {
+	usedPct := 0.0
+	if equity > 0 {
+		usedPct = notional / equity * 100
+	}
+	return Bucket{Kind: kind, Label: label, Notional: notional, CapPct: capPct, UsedPct: usedPct}
+}
+
+type aggregation struct {
+	symbolNotional map[string]float64
+	sideNotional   map[string]float64
+	groupNotional  map[string]float64
+	unrealizedPnL  float64
+}
+
+// aggregate sums notional (Size*price) per symbol, per side, and per
+// correlation group across positions, plus total unrealized PnL. prices may
+// be nil, in which case every position falls back to its entry price.
+func aggregate(positions []*domain.Position, prices map[string]float64) aggregation {
+	agg := aggregation{
+		symbolNotional: make(map[string]float64),
+		sideNotional:   make(map[string]float64),
+		groupNotional:  make(map[string]float64),
+	}
+
+	for _, pos := range positions {
+		price := pos.EntryPrice
+		if prices != nil {
+			if p, ok := prices[pos.Symbol]; ok {
+				price = p
+			}
+		}
+
+		notional := pos.Size * price
+		agg.symbolNotional[pos.Symbol] += notional
+
+		side := domain.SideShort
+		if pos.IsLong() {
+			side = domain.SideLong
+		}
+		agg.sideNotional[side] += notional
+
+		if group, ok := CorrelationGroups[pos.Symbol]; ok {
+			agg.groupNotional[group] += notional
+		}
+
+		agg.unrealizedPnL += pos.CalculateGrossPnL(price)
+	}
+
+	return agg
+}
+
+func (s *Service) checkUser(ctx context.Context, userID uuid.UUID, positions []*domain.Position, prices map[string]float64) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		log.Printf("[PortfolioGuard] failed to load user %s: %v", userID, err)
+		return
+	}
+
+	agg := aggregate(positions, prices)
+	equity := user.PaperBalance + agg.unrealizedPnL
+	if equity <= 0 {
+		return
+	}
+
+	if s.config.MaxSymbolExposurePct > 0 {
+		for symbol, notional := range agg.symbolNotional {
+			if notional/equity*100 <= s.config.MaxSymbolExposurePct {
+				continue
+			}
+			excess := notional - equity*s.config.MaxSymbolExposurePct/100
+			s.trimToCap(ctx, positions, prices, excess, "symbol "+symbol, func(pos *domain.Position) bool {
+				return pos.Symbol == symbol
+			})
+		}
+	}
+
+	if s.config.MaxSideExposurePct > 0 {
+		for side, notional := range agg.sideNotional {
+			if notional/equity*100 <= s.config.MaxSideExposurePct {
+				continue
+			}
+			excess := notional - equity*s.config.MaxSideExposurePct/100
+			s.trimToCap(ctx, positions, prices, excess, "side "+side, func(pos *domain.Position) bool {
+				return (pos.IsLong() && side == domain.SideLong) || (!pos.IsLong() && side == domain.SideShort)
+			})
+		}
+	}
+
+	if s.config.MaxCorrelatedExposurePct > 0 {
+		for group, notional := range agg.groupNotional {
+			if notional/equity*100 <= s.config.MaxCorrelatedExposurePct {
+				continue
+			}
+			excess := notional - equity*s.config.MaxCorrelatedExposurePct/100
+			s.trimToCap(ctx, positions, prices, excess, "correlation group "+group, func(pos *domain.Position) bool {
+				return CorrelationGroups[pos.Symbol] == group
+			})
+		}
+	}
+}
+
+// trimToCap partially closes the most-recently-opened position matching
+// match, down by excessNotional converted to size at its mark price, so the
+// bucket falls back to (approximately) the cap. If one position's Size isn't
+// enough to absorb the whole excess, the remainder is left for the next
+// tick rather than cascading into other positions.
+func (s *Service) trimToCap(ctx context.Context, positions []*domain.Position, prices map[string]float64, excessNotional float64, reason string, match func(*domain.Position) bool) {
+	var newest *domain.Position
+	for _, pos := range positions {
+		if !match(pos) {
+			continue
+		}
+		if newest == nil || pos.CreatedAt.After(newest.CreatedAt) {
+			newest = pos
+		}
+	}
+	if newest == nil || excessNotional <= 0 {
+		return
+	}
+
+	price := newest.EntryPrice
+	if p, ok := prices[newest.Symbol]; ok && p > 0 {
+		price = p
+	}
+	if price <= 0 {
+		return
+	}
+
+	sizeToTrim := excessNotional / price
+	if sizeToTrim > newest.Size {
+		sizeToTrim = newest.Size
+	}
+
+	if err := s.positionRepo.PartialClose(ctx, newest.ID, sizeToTrim); err != nil {
+		log.Printf("[PortfolioGuard] failed to trim %s (%s) for user %s: %v", newest.Symbol, reason, newest.UserID, err)
+		return
+	}
+
+	log.Printf("[PortfolioGuard] PORTFOLIO_GUARD trimmed %.6f %s for user %s: %s exposure breached cap", sizeToTrim, newest.Symbol, newest.UserID, reason)
+}
@@ -0,0 +1,241 @@
+// Package rebalance periodically nudges a user's open exposure toward
+// configured target weights per symbol, trimming over-weight symbols and
+// topping up under-weight ones via PositionRepository.
+package rebalance
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/google/uuid"
+
+	"neurotrade/internal/domain"
+)
+
+// Action kind constants
+const (
+	ActionTrim  = "TRIM"   // partially close an over-weight symbol
+	ActionTopUp = "TOP_UP" // open more exposure in an under-weight symbol
+)
+
+// TargetWeights maps symbol to its target fraction of total open notional,
+// e.g. {"BTCUSDT": 0.4, "ETHUSDT": 0.3, "SOLUSDT": 0.3}. Weights need not
+// sum to 1; any remainder is simply left unallocated.
+type TargetWeights map[string]float64
+
+// Action is a single rebalance step for one symbol.
+type Action struct {
+	Symbol          string  `json:"symbol"`
+	Kind            string  `json:"kind"`
+	CurrentNotional float64 `json:"current_notional"`
+	TargetNotional  float64 `json:"target_notional"`
+	DeltaNotional   float64 `json:"delta_notional"` // signed: negative = trim, positive = top-up
+}
+
+// Service computes and executes rebalance plans that nudge a user's open
+// exposure toward TargetWeights
+type Service struct {
+	positionRepo domain.PositionRepository
+	priceService domain.MarketPriceService
+	targets      TargetWeights
+	minDrift     float64 // fraction of total notional; drift below this is ignored
+	dryRun       bool    // when true, Execute only logs the plan and doesn't trade
+}
+
+// NewService creates a new rebalance Service
+func NewService(positionRepo domain.PositionRepository, priceService domain.MarketPriceService, targets TargetWeights, minDrift float64, dryRun bool) *Service {
+	return &Service{
+		positionRepo: positionRepo,
+		priceService: priceService,
+		targets:      targets,
+		minDrift:     minDrift,
+		dryRun:       dryRun,
+	}
+}
+
+// Plan computes the rebalance actions for a user's current open positions
+// against the target weights, without executing anything. Symbols whose
+// drift is below MinDrift are omitted.
+func (s *Service) Plan(ctx context.Context, userID uuid.UUID) ([]Action, error) {
+	positions, err := s.positionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get positions for user %s: %w", userID, err)
+	}
+
+	open := make([]*domain.Position, 0, len(positions))
+	symbolSet := make(map[string]bool)
+	for _, pos := range positions {
+		if pos.Status != domain.StatusOpen {
+			continue
+		}
+		open = append(open, pos)
+		symbolSet[pos.Symbol] = true
+	}
+	for symbol := range s.targets {
+		symbolSet[symbol] = true
+	}
+
+	symbols := make([]string, 0, len(symbolSet))
+	for symbol := range symbolSet {
+		symbols = append(symbols, symbol)
+	}
+
+	prices, err := s.priceService.FetchRealTimePrices(ctx, symbols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch mark prices: %w", err)
+	}
+
+	currentNotional := make(map[string]float64)
+	var totalNotional float64
+	for _, pos := range open {
+		price, ok := prices[pos.Symbol]
+		if !ok {
+			price = pos.EntryPrice
+		}
+		notional := pos.Size * price
+		currentNotional[pos.Symbol] += notional
+		totalNotional += notional
+	}
+
+	if totalNotional == 0 {
+		return nil, nil
+	}
+
+	actions := make([]Action, 0, len(s.targets))
+	for symbol, weight := range s.targets {
+		target := totalNotional * weight
+		current := currentNotional[symbol]
+		delta := target - current
+
+		driftPct := math.Abs(delta) / totalNotional
+		if driftPct < s.minDrift {
+			continue
+		}
+
+		kind := ActionTopUp
+		if delta < 0 {
+			kind = ActionTrim
+		}
+
+		actions = append(actions, Action{
+			Symbol:          symbol,
+			Kind:            kind,
+			CurrentNotional: current,
+			TargetNotional:  target,
+			DeltaNotional:   delta,
+		})
+	}
+
+	return actions, nil
+}
+
+// Execute computes the rebalance plan and, unless DryRun is set, applies it:
+// trims over-weight symbols via PositionRepository.PartialClose and tops up
+// under-weight symbols by opening a new scaled-entry layer. It always
+// returns the plan that was computed (or would have been applied).
+func (s *Service) Execute(ctx context.Context, userID uuid.UUID) ([]Action, error) {
+	plan, err := s.Plan(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.dryRun {
+		for _, action := range plan {
+			log.Printf("[Rebalance] DRY RUN user=%s %s %s delta=%.2f USDT", userID, action.Kind, action.Symbol, action.DeltaNotional)
+		}
+		return plan, nil
+	}
+
+	for _, action := range plan {
+		switch action.Kind {
+		case ActionTrim:
+			if err := s.trim(ctx, userID, action); err != nil {
+				log.Printf("[Rebalance] ERROR trimming %s for user %s: %v", action.Symbol, userID, err)
+			}
+		case ActionTopUp:
+			if err := s.topUp(ctx, userID, action); err != nil {
+				log.Printf("[Rebalance] ERROR topping up %s for user %s: %v", action.Symbol, userID, err)
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// trim partially closes enough of the user's open size in action.Symbol to
+// bring its notional down by |action.DeltaNotional|, spread proportionally
+// across every open position in that symbol.
+func (s *Service) trim(ctx context.Context, userID uuid.UUID, action Action) error {
+	positions, err := s.positionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get positions for user %s: %w", userID, err)
+	}
+
+	var symbolPositions []*domain.Position
+	var symbolSize float64
+	for _, pos := range positions {
+		if pos.Status != domain.StatusOpen || pos.Symbol != action.Symbol {
+			continue
+		}
+		symbolPositions = append(symbolPositions, pos)
+		symbolSize += pos.Size
+	}
+	if symbolSize == 0 {
+		return nil
+	}
+
+	price, err := s.priceService.GetPrice(ctx, action.Symbol)
+	if err != nil {
+		return fmt.Errorf("failed to get mark price for %s: %w", action.Symbol, err)
+	}
+	if price == 0 {
+		return fmt.Errorf("zero mark price for %s", action.Symbol)
+	}
+
+	sizeToTrim := math.Abs(action.DeltaNotional) / price
+
+	for _, pos := range symbolPositions {
+		share := sizeToTrim * (pos.Size / symbolSize)
+		if share <= 0 {
+			continue
+		}
+		if err := s.positionRepo.PartialClose(ctx, pos.ID, share); err != nil {
+			return fmt.Errorf("failed to partial-close position %s: %w", pos.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// topUp opens a new single-layer scaled-entry position in action.Symbol
+// sized to bring its notional up by action.DeltaNotional.
+func (s *Service) topUp(ctx context.Context, userID uuid.UUID, action Action) error {
+	price, err := s.priceService.GetPrice(ctx, action.Symbol)
+	if err != nil {
+		return fmt.Errorf("failed to get mark price for %s: %w", action.Symbol, err)
+	}
+	if price == 0 {
+		return fmt.Errorf("zero mark price for %s", action.Symbol)
+	}
+
+	size := action.DeltaNotional / price
+	parentSignalID := uuid.New()
+
+	// No per-symbol strategy SL/TP is available for a rebalance top-up, so
+	// fall back to a wide +/-5% safety net rather than leaving SL/TP at 0
+	// (which CheckSLTP would treat as an immediately-hit TP).
+	slPrice := price * 0.95
+	tpPrice := price * 1.05
+
+	layers := domain.BuildScaledEntryLayers(parentSignalID, userID, action.Symbol, domain.SideLong, price, slPrice, tpPrice, size, 1, 1, 0)
+
+	for _, layer := range layers {
+		if err := s.positionRepo.Save(ctx, layer); err != nil {
+			return fmt.Errorf("failed to save top-up position: %w", err)
+		}
+	}
+
+	return nil
+}
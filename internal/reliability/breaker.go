@@ -0,0 +1,254 @@
+package reliability
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// BreakerState is a circuit breaker's current state.
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+// ErrCircuitOpen is returned by CircuitBreaker.Allow (and bubbles up through
+// Do) while the breaker is open and OpenTimeout hasn't elapsed yet.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerConfig controls when a CircuitBreaker trips and how long it stays
+// open before probing the dependency again.
+type BreakerConfig struct {
+	// Window is the rolling period failure rate is measured over.
+	Window time.Duration
+
+	// MinRequests is the minimum number of requests in Window before
+	// FailureThreshold is evaluated, so a handful of cold-start failures
+	// can't trip the breaker on their own.
+	MinRequests int
+
+	// FailureThreshold trips the breaker once the failure rate within
+	// Window reaches this fraction (0-1).
+	FailureThreshold float64
+
+	// OpenTimeout is how long the breaker stays open before letting a
+	// single half-open probe request through.
+	OpenTimeout time.Duration
+}
+
+// DefaultBreakerConfig trips once at least 5 requests in a rolling 30s
+// window have a >=50% failure rate, then waits 30s before probing again.
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		Window:           30 * time.Second,
+		MinRequests:      5,
+		FailureThreshold: 0.5,
+		OpenTimeout:      30 * time.Second,
+	}
+}
+
+type outcome struct {
+	at      time.Time
+	success bool
+}
+
+// CircuitBreaker is a per-dependency closed->open->half-open breaker with a
+// rolling failure-rate window. Once open it fails Allow() immediately
+// instead of letting every caller hammer a struggling dependency (and log
+// an error doing it), then lets exactly one probe through per OpenTimeout
+// to check whether it has recovered.
+type CircuitBreaker struct {
+	name string
+	cfg  BreakerConfig
+
+	mu      sync.Mutex
+	state   BreakerState
+	opened  time.Time
+	probing bool
+	history []outcome
+	lastErr error
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []*CircuitBreaker
+)
+
+// NewCircuitBreaker creates a named, closed circuit breaker and registers
+// it so AllStatuses (and the /health/deps handler) can report on it. name
+// should be stable and unique per dependency, e.g. "python_engine" or
+// "telegram".
+func NewCircuitBreaker(name string, cfg BreakerConfig) *CircuitBreaker {
+	b := &CircuitBreaker{
+		name:  name,
+		cfg:   cfg,
+		state: BreakerClosed,
+	}
+
+	registryMu.Lock()
+	registry = append(registry, b)
+	registryMu.Unlock()
+
+	return b
+}
+
+// Allow reports whether a call should proceed. It returns ErrCircuitOpen
+// when the breaker is open and OpenTimeout hasn't elapsed, and allows
+// exactly one half-open probe through once it has.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.opened) < b.cfg.OpenTimeout {
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// RecordSuccess reports a successful call, closing the breaker (and
+// resetting its failure history) if it was half-open.
+func (b *CircuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.record(true)
+	b.probing = false
+	if b.state == BreakerHalfOpen {
+		b.state = BreakerClosed
+		b.history = nil
+	}
+
+	breakerStateGauge.WithLabelValues(b.name).Set(stateValue(b.state))
+}
+
+// RecordFailure reports a failed call, tripping the breaker open if the
+// rolling failure rate crosses FailureThreshold, or immediately re-opening
+// on a failed half-open probe.
+func (b *CircuitBreaker) RecordFailure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastErr = err
+	b.record(false)
+	b.probing = false
+
+	if b.state == BreakerHalfOpen || b.shouldTrip() {
+		b.state = BreakerOpen
+		b.opened = time.Now()
+	}
+
+	breakerStateGauge.WithLabelValues(b.name).Set(stateValue(b.state))
+}
+
+// record appends outcome and drops everything older than Window.
+func (b *CircuitBreaker) record(success bool) {
+	now := time.Now()
+	b.history = append(b.history, outcome{at: now, success: success})
+
+	cutoff := now.Add(-b.cfg.Window)
+	kept := b.history[:0]
+	for _, o := range b.history {
+		if o.at.After(cutoff) {
+			kept = append(kept, o)
+		}
+	}
+	b.history = kept
+}
+
+func (b *CircuitBreaker) shouldTrip() bool {
+	if len(b.history) < b.cfg.MinRequests {
+		return false
+	}
+
+	failures := 0
+	for _, o := range b.history {
+		if !o.success {
+			failures++
+		}
+	}
+
+	return float64(failures)/float64(len(b.history)) >= b.cfg.FailureThreshold
+}
+
+// Status is a point-in-time snapshot of a CircuitBreaker, for the
+// /health/deps endpoint.
+type Status struct {
+	Name        string       `json:"name"`
+	State       BreakerState `json:"state"`
+	LastError   string       `json:"last_error,omitempty"`
+	SuccessRate float64      `json:"success_rate"`
+	Requests    int          `json:"requests_in_window"`
+}
+
+// Status snapshots the breaker's current state, last error, and rolling
+// success rate.
+func (b *CircuitBreaker) Status() Status {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	successes := 0
+	for _, o := range b.history {
+		if o.success {
+			successes++
+		}
+	}
+
+	rate := 1.0
+	if len(b.history) > 0 {
+		rate = float64(successes) / float64(len(b.history))
+	}
+
+	var lastErrStr string
+	if b.lastErr != nil {
+		lastErrStr = b.lastErr.Error()
+	}
+
+	return Status{
+		Name:        b.name,
+		State:       b.state,
+		LastError:   lastErrStr,
+		SuccessRate: rate,
+		Requests:    len(b.history),
+	}
+}
+
+// AllStatuses snapshots every breaker created via NewCircuitBreaker, in
+// creation order, for the /health/deps handler.
+func AllStatuses() []Status {
+	registryMu.Lock()
+	breakers := append([]*CircuitBreaker(nil), registry...)
+	registryMu.Unlock()
+
+	statuses := make([]Status, 0, len(breakers))
+	for _, b := range breakers {
+		statuses = append(statuses, b.Status())
+	}
+	return statuses
+}
+
+func stateValue(s BreakerState) float64 {
+	switch s {
+	case BreakerOpen:
+		return 1
+	case BreakerHalfOpen:
+		return 0.5
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,27 @@
+package reliability
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// AICallTotal counts Python AI engine calls by result (success, circuit_open,
+// error), so a retry storm is visible well before it trips python_engine's
+// breaker.
+var AICallTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "neurotrade_ai_call_total",
+	Help: "Total AI engine calls by result",
+}, []string{"result"})
+
+// SignalSavedTotal counts signalRepo.Save outcomes from ProcessMarketScan.
+var SignalSavedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "neurotrade_signal_saved_total",
+	Help: "Total signal save attempts by result",
+}, []string{"result"})
+
+// breakerStateGauge mirrors every CircuitBreaker's current state
+// (0=closed, 0.5=half_open, 1=open), updated from RecordSuccess/RecordFailure.
+var breakerStateGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "neurotrade_circuit_breaker_state",
+	Help: "Circuit breaker state: 0=closed, 0.5=half_open, 1=open",
+}, []string{"name"})
@@ -0,0 +1,79 @@
+package reliability
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// RetryConfig controls Do's backoff schedule for a single call site.
+type RetryConfig struct {
+	// MaxAttempts is the total number of tries, including the first. Values
+	// <= 0 are treated as 1 (no retry).
+	MaxAttempts int
+
+	// BaseDelay is the wait before the second attempt; each subsequent
+	// attempt doubles it, capped at MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// Retryable reports whether err is worth retrying. nil means retry
+	// every non-nil error.
+	Retryable func(err error) bool
+}
+
+// DefaultRetryConfig retries three times total with exponential backoff
+// starting at 500ms and capped at 5s, full-jittered so many auto-trading
+// users hitting the same dependency don't all retry in lockstep.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+	}
+}
+
+// Do calls fn, retrying on failure per cfg until MaxAttempts is exhausted,
+// ctx is cancelled, or cfg.Retryable rejects the error as non-transient.
+func Do(ctx context.Context, cfg RetryConfig, fn func() error) error {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < cfg.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(jitteredBackoff(cfg, attempt)):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		lastErr = fn()
+		if lastErr == nil {
+			return nil
+		}
+
+		if cfg.Retryable != nil && !cfg.Retryable(lastErr) {
+			return lastErr
+		}
+	}
+
+	return lastErr
+}
+
+// jitteredBackoff returns a random delay between 0 and the exponential
+// backoff ceiling for attempt (full jitter), so concurrent retries spread
+// out instead of syncing up.
+func jitteredBackoff(cfg RetryConfig, attempt int) time.Duration {
+	ceiling := cfg.BaseDelay << uint(attempt-1)
+	if ceiling <= 0 || ceiling > cfg.MaxDelay {
+		ceiling = cfg.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"neurotrade/internal/domain"
+)
+
+// DepositRepositoryImpl implements the DepositRepository interface
+type DepositRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewDepositRepository creates a new DepositRepository
+func NewDepositRepository(db *pgxpool.Pool) domain.DepositRepository {
+	return &DepositRepositoryImpl{db: db}
+}
+
+// Upsert inserts a deposit, or is a no-op if (exchange, txn_id) already exists
+func (r *DepositRepositoryImpl) Upsert(ctx context.Context, deposit *domain.Deposit) error {
+	query := `
+		INSERT INTO deposits (
+			id, user_id, exchange, asset, address, network, amount,
+			txn_id, txn_fee, txn_fee_currency, time, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+		)
+		ON CONFLICT (exchange, txn_id) DO NOTHING
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		deposit.ID, deposit.UserID, deposit.Exchange, deposit.Asset,
+		deposit.Address, deposit.Network, deposit.Amount, deposit.TxnID,
+		deposit.TxnFee, deposit.TxnFeeCurrency, deposit.Time, deposit.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert deposit: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUserSince retrieves deposits for a user since a given time
+func (r *DepositRepositoryImpl) GetByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]*domain.Deposit, error) {
+	query := `
+		SELECT id, user_id, exchange, asset, address, network, amount,
+		       txn_id, txn_fee, txn_fee_currency, time, created_at
+		FROM deposits
+		WHERE user_id = $1 AND time >= $2
+		ORDER BY time DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deposits: %w", err)
+	}
+	defer rows.Close()
+
+	var deposits []*domain.Deposit
+	for rows.Next() {
+		d := &domain.Deposit{}
+		if err := rows.Scan(
+			&d.ID, &d.UserID, &d.Exchange, &d.Asset, &d.Address, &d.Network,
+			&d.Amount, &d.TxnID, &d.TxnFee, &d.TxnFeeCurrency, &d.Time, &d.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan deposit: %w", err)
+		}
+		deposits = append(deposits, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deposits: %w", err)
+	}
+
+	return deposits, nil
+}
+
+// SumByUserSince sums deposit amounts for a user since a given time
+func (r *DepositRepositoryImpl) SumByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (float64, error) {
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM deposits
+		WHERE user_id = $1 AND time >= $2
+	`
+
+	var total float64
+	if err := r.db.QueryRow(ctx, query, userID, since).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to sum deposits: %w", err)
+	}
+
+	return total, nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"neurotrade/internal/domain"
+)
+
+// ExecutionIdempotencyRepositoryImpl implements the
+// ExecutionIdempotencyRepository interface
+type ExecutionIdempotencyRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewExecutionIdempotencyRepository creates a new ExecutionIdempotencyRepository
+func NewExecutionIdempotencyRepository(db *pgxpool.Pool) domain.ExecutionIdempotencyRepository {
+	return &ExecutionIdempotencyRepositoryImpl{db: db}
+}
+
+// Get returns the previously persisted result for key, or nil if no attempt
+// under key has completed yet
+func (r *ExecutionIdempotencyRepositoryImpl) Get(ctx context.Context, key string) (*domain.ExecutionResult, error) {
+	query := `SELECT result FROM execution_idempotency_keys WHERE idempotency_key = $1`
+
+	var raw []byte
+	err := r.db.QueryRow(ctx, query, key).Scan(&raw)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get idempotency result for %s: %w", key, err)
+	}
+
+	var result domain.ExecutionResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal idempotency result for %s: %w", key, err)
+	}
+
+	return &result, nil
+}
+
+// Save persists result under key, ignoring a conflict from a concurrent
+// retry that already saved the same key
+func (r *ExecutionIdempotencyRepositoryImpl) Save(ctx context.Context, key string, result *domain.ExecutionResult) error {
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal idempotency result for %s: %w", key, err)
+	}
+
+	query := `
+		INSERT INTO execution_idempotency_keys (idempotency_key, result)
+		VALUES ($1, $2)
+		ON CONFLICT (idempotency_key) DO NOTHING
+	`
+
+	_, err = r.db.Exec(ctx, query, key, raw)
+	if err != nil {
+		return fmt.Errorf("failed to save idempotency result for %s: %w", key, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"neurotrade/internal/domain"
+)
+
+// HedgePositionRepositoryImpl implements domain.HedgePositionRepository
+type HedgePositionRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewHedgePositionRepository creates a new HedgePositionRepository
+func NewHedgePositionRepository(db *pgxpool.Pool) domain.HedgePositionRepository {
+	return &HedgePositionRepositoryImpl{db: db}
+}
+
+// Save creates a new hedge leg
+func (r *HedgePositionRepositoryImpl) Save(ctx context.Context, hedge *domain.HedgePosition) error {
+	query := `
+		INSERT INTO hedge_positions (
+			id, paper_position_id, user_id, symbol, side, entry_price, size, status, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9
+		)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		hedge.ID, hedge.PaperPositionID, hedge.UserID, hedge.Symbol, hedge.Side,
+		hedge.EntryPrice, hedge.Size, hedge.Status, hedge.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save hedge position: %w", err)
+	}
+
+	return nil
+}
+
+// GetByPaperPositionID retrieves the open hedge leg for a paper position, if
+// any. Returns nil, nil if no hedge was opened for it.
+func (r *HedgePositionRepositoryImpl) GetByPaperPositionID(ctx context.Context, paperPositionID uuid.UUID) (*domain.HedgePosition, error) {
+	query := `
+		SELECT id, paper_position_id, user_id, symbol, side, entry_price, size,
+		       exit_price, pnl, status, created_at, closed_at
+		FROM hedge_positions
+		WHERE paper_position_id = $1 AND status = 'OPEN'
+	`
+
+	hedge := &domain.HedgePosition{}
+	err := r.db.QueryRow(ctx, query, paperPositionID).Scan(
+		&hedge.ID, &hedge.PaperPositionID, &hedge.UserID, &hedge.Symbol, &hedge.Side,
+		&hedge.EntryPrice, &hedge.Size, &hedge.ExitPrice, &hedge.PnL, &hedge.Status,
+		&hedge.CreatedAt, &hedge.ClosedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hedge position for paper position %s: %w", paperPositionID, err)
+	}
+
+	return hedge, nil
+}
+
+// Update updates a hedge leg's status, exit price, and PnL
+func (r *HedgePositionRepositoryImpl) Update(ctx context.Context, hedge *domain.HedgePosition) error {
+	query := `
+		UPDATE hedge_positions
+		SET status = $1, exit_price = $2, pnl = $3, closed_at = $4
+		WHERE id = $5
+	`
+
+	_, err := r.db.Exec(ctx, query, hedge.Status, hedge.ExitPrice, hedge.PnL, hedge.ClosedAt, hedge.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update hedge position %s: %w", hedge.ID, err)
+	}
+
+	return nil
+}
+
+// GetOpenHedges retrieves every open hedge leg, for the reconciliation loop
+// to sum exposure by symbol
+func (r *HedgePositionRepositoryImpl) GetOpenHedges(ctx context.Context) ([]*domain.HedgePosition, error) {
+	query := `
+		SELECT id, paper_position_id, user_id, symbol, side, entry_price, size,
+		       exit_price, pnl, status, created_at, closed_at
+		FROM hedge_positions
+		WHERE status = 'OPEN'
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query open hedge positions: %w", err)
+	}
+	defer rows.Close()
+
+	var hedges []*domain.HedgePosition
+	for rows.Next() {
+		hedge := &domain.HedgePosition{}
+		if err := rows.Scan(
+			&hedge.ID, &hedge.PaperPositionID, &hedge.UserID, &hedge.Symbol, &hedge.Side,
+			&hedge.EntryPrice, &hedge.Size, &hedge.ExitPrice, &hedge.PnL, &hedge.Status,
+			&hedge.CreatedAt, &hedge.ClosedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan hedge position: %w", err)
+		}
+		hedges = append(hedges, hedge)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating open hedge positions: %w", err)
+	}
+
+	return hedges, nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"neurotrade/internal/domain"
+)
+
+// KlineRepository caches a rolling window of candles per symbol/interval in
+// Postgres. MarketHandler.GetKlines writes every series it fetches here;
+// MarketPriceService.FetchKlines/FetchKlinesRange do not read from it, so
+// their callers (backtests, ReviewService's ExitRule chain) still fetch
+// every bar from the exchange.
+type KlineRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewKlineRepository creates a new repository instance
+func NewKlineRepository(db *pgxpool.Pool) *KlineRepository {
+	return &KlineRepository{db: db}
+}
+
+// Upsert stores klines for symbol/interval, overwriting any existing rows
+// at the same open_time (a re-fetched candle may have settled since it was
+// last cached).
+func (r *KlineRepository) Upsert(ctx context.Context, symbol, interval string, klines []domain.Kline) error {
+	if len(klines) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, k := range klines {
+		batch.Queue(`
+			INSERT INTO klines (symbol, interval, open_time, close_time, open, high, low, close, volume, quote_volume, trade_count)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			ON CONFLICT (symbol, interval, open_time) DO UPDATE SET
+				close_time   = EXCLUDED.close_time,
+				open         = EXCLUDED.open,
+				high         = EXCLUDED.high,
+				low          = EXCLUDED.low,
+				close        = EXCLUDED.close,
+				volume       = EXCLUDED.volume,
+				quote_volume = EXCLUDED.quote_volume,
+				trade_count  = EXCLUDED.trade_count
+		`, symbol, interval, k.OpenTime, k.CloseTime, k.Open, k.High, k.Low, k.Close, k.Volume, k.QuoteVolume, k.TradeCount)
+	}
+
+	results := r.db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	for range klines {
+		if _, err := results.Exec(); err != nil {
+			return fmt.Errorf("failed to upsert klines for %s/%s: %w", symbol, interval, err)
+		}
+	}
+
+	return nil
+}
+
+// GetRange retrieves cached candles for symbol/interval with open_time in
+// [start, end], oldest first.
+func (r *KlineRepository) GetRange(ctx context.Context, symbol, interval string, start, end time.Time) ([]domain.Kline, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT open_time, close_time, open, high, low, close, volume, quote_volume, trade_count
+		FROM klines
+		WHERE symbol = $1 AND interval = $2 AND open_time >= $3 AND open_time <= $4
+		ORDER BY open_time ASC
+	`, symbol, interval, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get klines for %s/%s: %w", symbol, interval, err)
+	}
+	defer rows.Close()
+
+	var klines []domain.Kline
+	for rows.Next() {
+		var k domain.Kline
+		if err := rows.Scan(&k.OpenTime, &k.CloseTime, &k.Open, &k.High, &k.Low, &k.Close, &k.Volume, &k.QuoteVolume, &k.TradeCount); err != nil {
+			return nil, err
+		}
+		klines = append(klines, k)
+	}
+
+	return klines, nil
+}
+
+// PruneBefore deletes cached candles for symbol/interval older than before,
+// keeping the cache a rolling window rather than an unbounded history.
+func (r *KlineRepository) PruneBefore(ctx context.Context, symbol, interval string, before time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		DELETE FROM klines WHERE symbol = $1 AND interval = $2 AND open_time < $3
+	`, symbol, interval, before)
+	if err != nil {
+		return fmt.Errorf("failed to prune klines for %s/%s: %w", symbol, interval, err)
+	}
+	return nil
+}
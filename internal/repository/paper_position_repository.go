@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -57,7 +58,9 @@ func (r *PaperPositionRepositoryImpl) GetByUserID(ctx context.Context, userID uu
 	query := `
 		SELECT id, user_id, signal_id, symbol, side, entry_price,
 		       sl_price, tp_price, size, exit_price, pnl, status,
-		       created_at, closed_at
+		       created_at, closed_at,
+		       trailing_activation_ratios, trailing_callback_rates,
+		       high_water_price, low_water_price, active_trailing_tier
 		FROM paper_positions
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -87,6 +90,11 @@ func (r *PaperPositionRepositoryImpl) GetByUserID(ctx context.Context, userID uu
 			&position.Status,
 			&position.CreatedAt,
 			&position.ClosedAt,
+			&position.TrailingActivationRatios,
+			&position.TrailingCallbackRates,
+			&position.HighWaterPrice,
+			&position.LowWaterPrice,
+			&position.ActiveTrailingTier,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan position: %w", err)
@@ -106,7 +114,9 @@ func (r *PaperPositionRepositoryImpl) GetOpenPositions(ctx context.Context) ([]*
 	query := `
 		SELECT id, user_id, signal_id, symbol, side, entry_price,
 		       sl_price, tp_price, size, exit_price, pnl, status,
-		       created_at, closed_at
+		       created_at, closed_at,
+		       trailing_activation_ratios, trailing_callback_rates,
+		       high_water_price, low_water_price, active_trailing_tier
 		FROM paper_positions
 		WHERE status = 'OPEN'
 		ORDER BY created_at ASC
@@ -136,6 +146,11 @@ func (r *PaperPositionRepositoryImpl) GetOpenPositions(ctx context.Context) ([]*
 			&position.Status,
 			&position.CreatedAt,
 			&position.ClosedAt,
+			&position.TrailingActivationRatios,
+			&position.TrailingCallbackRates,
+			&position.HighWaterPrice,
+			&position.LowWaterPrice,
+			&position.ActiveTrailingTier,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan position: %w", err)
@@ -181,7 +196,9 @@ func (r *PaperPositionRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID)
 	query := `
 		SELECT id, user_id, signal_id, symbol, side, entry_price,
 		       sl_price, tp_price, size, exit_price, pnl, status,
-		       created_at, closed_at
+		       created_at, closed_at,
+		       trailing_activation_ratios, trailing_callback_rates,
+		       high_water_price, low_water_price, active_trailing_tier
 		FROM paper_positions
 		WHERE id = $1
 	`
@@ -202,6 +219,11 @@ func (r *PaperPositionRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID)
 		&position.Status,
 		&position.CreatedAt,
 		&position.ClosedAt,
+		&position.TrailingActivationRatios,
+		&position.TrailingCallbackRates,
+		&position.HighWaterPrice,
+		&position.LowWaterPrice,
+		&position.ActiveTrailingTier,
 	)
 
 	if err != nil {
@@ -210,3 +232,80 @@ func (r *PaperPositionRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID)
 
 	return position, nil
 }
+
+// UpdateTrailingTierState persists CheckTrailing's ratcheted high/low water
+// price and active tier, without touching any other column
+func (r *PaperPositionRepositoryImpl) UpdateTrailingTierState(ctx context.Context, positionID uuid.UUID, highWater, lowWater *float64, tier int) error {
+	query := `
+		UPDATE paper_positions
+		SET high_water_price = $1,
+		    low_water_price = $2,
+		    active_trailing_tier = $3
+		WHERE id = $4
+	`
+
+	_, err := r.db.Exec(ctx, query, highWater, lowWater, tier, positionID)
+	if err != nil {
+		return fmt.Errorf("failed to update trailing tier state: %w", err)
+	}
+
+	return nil
+}
+
+// GetForReport implements domain.PaperPositionRepository
+func (r *PaperPositionRepositoryImpl) GetForReport(ctx context.Context, userID uuid.UUID, symbol string, from, to time.Time) ([]*domain.PaperPosition, error) {
+	query := `
+		SELECT id, user_id, signal_id, symbol, side, entry_price,
+		       sl_price, tp_price, size, exit_price, pnl, status,
+		       created_at, closed_at,
+		       trailing_activation_ratios, trailing_callback_rates,
+		       high_water_price, low_water_price, active_trailing_tier
+		FROM paper_positions
+		WHERE user_id = $1
+		  AND ($2 = '' OR symbol = $2)
+		  AND (status = 'OPEN' OR closed_at BETWEEN $3 AND $4)
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, symbol, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query positions for report: %w", err)
+	}
+	defer rows.Close()
+
+	var positions []*domain.PaperPosition
+	for rows.Next() {
+		position := &domain.PaperPosition{}
+		err := rows.Scan(
+			&position.ID,
+			&position.UserID,
+			&position.SignalID,
+			&position.Symbol,
+			&position.Side,
+			&position.EntryPrice,
+			&position.SLPrice,
+			&position.TPPrice,
+			&position.Size,
+			&position.ExitPrice,
+			&position.PnL,
+			&position.Status,
+			&position.CreatedAt,
+			&position.ClosedAt,
+			&position.TrailingActivationRatios,
+			&position.TrailingCallbackRates,
+			&position.HighWaterPrice,
+			&position.LowWaterPrice,
+			&position.ActiveTrailingTier,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan position: %w", err)
+		}
+		positions = append(positions, position)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating positions: %w", err)
+	}
+
+	return positions, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -23,16 +24,24 @@ func NewPositionRepository(db *pgxpool.Pool) domain.PositionRepository {
 
 // Save creates a new position
 func (r *PositionRepositoryImpl) Save(ctx context.Context, position *domain.Position) error {
+	trailingConfig, err := json.Marshal(position.TrailingConfig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trailing config: %w", err)
+	}
+
 	query := `
 		INSERT INTO positions (
 			id, user_id, signal_id, symbol, side, entry_price,
-			sl_price, tp_price, size, leverage, status, created_at
+			sl_price, tp_price, size, leverage, status, created_at, trailing_config,
+			roi_stop_loss_percent, roi_take_profit_percent,
+			trailing_activation_ratios, trailing_callback_rates,
+			parent_signal_id, layer_index, entry_atr, position_side
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21
 		)
 	`
 
-	_, err := r.db.Exec(ctx, query,
+	_, err = r.db.Exec(ctx, query,
 		position.ID,
 		position.UserID,
 		position.SignalID,
@@ -45,6 +54,15 @@ func (r *PositionRepositoryImpl) Save(ctx context.Context, position *domain.Posi
 		position.Leverage,
 		position.Status,
 		position.CreatedAt,
+		trailingConfig,
+		position.ROIStopLossPercent,
+		position.ROITakeProfitPercent,
+		position.TrailingActivationRatios,
+		position.TrailingCallbackRates,
+		position.ParentSignalID,
+		position.LayerIndex,
+		position.EntryATR,
+		position.PositionSide,
 	)
 
 	if err != nil {
@@ -59,7 +77,7 @@ func (r *PositionRepositoryImpl) GetByUserID(ctx context.Context, userID uuid.UU
 	query := `
 		SELECT id, user_id, signal_id, symbol, side, entry_price,
 		       sl_price, tp_price, size, leverage, exit_price, pnl, status,
-		       created_at, closed_at
+		       created_at, closed_at, parent_signal_id, layer_index, entry_atr, position_side
 		FROM positions
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -90,6 +108,10 @@ func (r *PositionRepositoryImpl) GetByUserID(ctx context.Context, userID uuid.UU
 			&position.Status,
 			&position.CreatedAt,
 			&position.ClosedAt,
+			&position.ParentSignalID,
+			&position.LayerIndex,
+			&position.EntryATR,
+			&position.PositionSide,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan position: %w", err)
@@ -109,7 +131,10 @@ func (r *PositionRepositoryImpl) GetOpenPositions(ctx context.Context) ([]*domai
 	query := `
 		SELECT id, user_id, signal_id, symbol, side, entry_price,
 		       sl_price, tp_price, size, leverage, exit_price, pnl, status,
-		       created_at, closed_at
+		       created_at, closed_at, roi_stop_loss_percent, roi_take_profit_percent,
+		       bodyguard_trailing_tier, sl_grace_count,
+		       tp_levels, trailing_stop_pct, high_water_mark, low_water_mark,
+		       funding_paid, last_funding_accrual_at, position_side
 		FROM positions
 		WHERE status = 'OPEN'
 		ORDER BY created_at ASC
@@ -124,6 +149,7 @@ func (r *PositionRepositoryImpl) GetOpenPositions(ctx context.Context) ([]*domai
 	var positions []*domain.Position
 	for rows.Next() {
 		position := &domain.Position{}
+		var tpLevelsRaw []byte
 		err := rows.Scan(
 			&position.ID,
 			&position.UserID,
@@ -140,10 +166,29 @@ func (r *PositionRepositoryImpl) GetOpenPositions(ctx context.Context) ([]*domai
 			&position.Status,
 			&position.CreatedAt,
 			&position.ClosedAt,
+			&position.ROIStopLossPercent,
+			&position.ROITakeProfitPercent,
+			&position.BodyguardTrailingTier,
+			&position.SLGraceCount,
+			&tpLevelsRaw,
+			&position.TrailingStopPct,
+			&position.HighWaterMark,
+			&position.LowWaterMark,
+			&position.FundingPaid,
+			&position.LastFundingAccrualAt,
+			&position.PositionSide,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan position: %w", err)
 		}
+
+		if len(tpLevelsRaw) > 0 {
+			var levels []domain.TPLevel
+			if err := json.Unmarshal(tpLevelsRaw, &levels); err == nil {
+				position.TPLevels = levels
+			}
+		}
+
 		positions = append(positions, position)
 	}
 
@@ -193,7 +238,7 @@ func (r *PositionRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*do
 	query := `
 		SELECT id, user_id, signal_id, symbol, side, entry_price,
 		       sl_price, tp_price, size, leverage, exit_price, pnl, status,
-		       created_at, closed_at
+		       created_at, closed_at, roi_stop_loss_percent, roi_take_profit_percent, position_side
 		FROM positions
 		WHERE id = $1
 	`
@@ -215,6 +260,9 @@ func (r *PositionRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*do
 		&position.Status,
 		&position.CreatedAt,
 		&position.ClosedAt,
+		&position.ROIStopLossPercent,
+		&position.ROITakeProfitPercent,
+		&position.PositionSide,
 	)
 
 	if err != nil {
@@ -461,3 +509,421 @@ func (r *PositionRepositoryImpl) GetActivePositions(ctx context.Context) ([]*dom
 
 	return positions, nil
 }
+
+// UpdateTrailingState persists the ladder's peak price, active step, and the
+// SL price it produced, without touching any other column on the row
+func (r *PositionRepositoryImpl) UpdateTrailingState(ctx context.Context, positionID uuid.UUID, peak float64, step int, newSL float64) error {
+	query := `
+		UPDATE positions
+		SET peak_price = $1,
+		    active_trailing_step = $2,
+		    sl_price = $3
+		WHERE id = $4
+	`
+
+	_, err := r.db.Exec(ctx, query, peak, step, newSL, positionID)
+	if err != nil {
+		return fmt.Errorf("failed to update trailing state: %w", err)
+	}
+
+	return nil
+}
+
+// GetPositionsWithTrailing retrieves all open positions with a trailing-stop
+// ladder configured, for a background worker to tick on each price update
+func (r *PositionRepositoryImpl) GetPositionsWithTrailing(ctx context.Context) ([]*domain.Position, error) {
+	query := `
+		SELECT id, user_id, signal_id, symbol, side, entry_price,
+		       sl_price, tp_price, size, leverage, status,
+		       created_at, peak_price, active_trailing_step, trailing_config
+		FROM positions
+		WHERE status = 'OPEN' AND trailing_config IS NOT NULL
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query positions with trailing: %w", err)
+	}
+	defer rows.Close()
+
+	var positions []*domain.Position
+	for rows.Next() {
+		position := &domain.Position{}
+		var trailingConfigRaw []byte
+		err := rows.Scan(
+			&position.ID,
+			&position.UserID,
+			&position.SignalID,
+			&position.Symbol,
+			&position.Side,
+			&position.EntryPrice,
+			&position.SLPrice,
+			&position.TPPrice,
+			&position.Size,
+			&position.Leverage,
+			&position.Status,
+			&position.CreatedAt,
+			&position.PeakPrice,
+			&position.ActiveTrailingStep,
+			&trailingConfigRaw,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan position: %w", err)
+		}
+
+		if len(trailingConfigRaw) > 0 {
+			var cfg domain.TrailingConfig
+			if err := json.Unmarshal(trailingConfigRaw, &cfg); err == nil {
+				position.TrailingConfig = &cfg
+			}
+		}
+
+		positions = append(positions, position)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating positions: %w", err)
+	}
+
+	return positions, nil
+}
+
+// UpdateTrailingTierState persists the flat ladder's high/low water price and
+// active tier, without touching any other column on the row
+func (r *PositionRepositoryImpl) UpdateTrailingTierState(ctx context.Context, positionID uuid.UUID, highWater, lowWater *float64, tier int) error {
+	query := `
+		UPDATE positions
+		SET high_water_price = $1,
+		    low_water_price = $2,
+		    active_trailing_tier = $3
+		WHERE id = $4
+	`
+
+	_, err := r.db.Exec(ctx, query, highWater, lowWater, tier, positionID)
+	if err != nil {
+		return fmt.Errorf("failed to update trailing tier state: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBodyguardTrailingState persists the tier and resulting SL price
+// BodyguardService.applyTrailingStop's env-configured activation/
+// callback ladder ratcheted to, without touching any other column
+func (r *PositionRepositoryImpl) UpdateBodyguardTrailingState(ctx context.Context, positionID uuid.UUID, tier int, newSL float64) error {
+	query := `
+		UPDATE positions
+		SET bodyguard_trailing_tier = $1,
+		    sl_price = $2
+		WHERE id = $3
+	`
+
+	_, err := r.db.Exec(ctx, query, tier, newSL, positionID)
+	if err != nil {
+		return fmt.Errorf("failed to update bodyguard trailing state: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSLGraceCount persists EvaluateSLGraceBreach's consecutive-noise
+// counter without touching any other column
+func (r *PositionRepositoryImpl) UpdateSLGraceCount(ctx context.Context, positionID uuid.UUID, count int) error {
+	query := `
+		UPDATE positions
+		SET sl_grace_count = $1
+		WHERE id = $2
+	`
+
+	_, err := r.db.Exec(ctx, query, count, positionID)
+	if err != nil {
+		return fmt.Errorf("failed to update SL grace count: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateTPLevelState persists the TPLevels slice (with the just-fired level
+// marked Filled) and the reduced Size, without touching any other column
+func (r *PositionRepositoryImpl) UpdateTPLevelState(ctx context.Context, positionID uuid.UUID, tpLevels []domain.TPLevel, newSize float64) error {
+	levelsJSON, err := json.Marshal(tpLevels)
+	if err != nil {
+		return fmt.Errorf("failed to marshal TP levels: %w", err)
+	}
+
+	query := `
+		UPDATE positions
+		SET tp_levels = $1,
+		    size = $2
+		WHERE id = $3
+	`
+
+	_, err = r.db.Exec(ctx, query, levelsJSON, newSize, positionID)
+	if err != nil {
+		return fmt.Errorf("failed to update TP level state: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateTrailingMarkState persists UpdateTrailingStopPct's ratcheted SL price
+// and high/low water mark, without touching any other column
+func (r *PositionRepositoryImpl) UpdateTrailingMarkState(ctx context.Context, positionID uuid.UUID, newSL float64, highWaterMark, lowWaterMark *float64) error {
+	query := `
+		UPDATE positions
+		SET sl_price = $1,
+		    high_water_mark = $2,
+		    low_water_mark = $3
+		WHERE id = $4
+	`
+
+	_, err := r.db.Exec(ctx, query, newSL, highWaterMark, lowWaterMark, positionID)
+	if err != nil {
+		return fmt.Errorf("failed to update trailing mark state: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateFundingState persists FundingService.AccrueDue's running
+// FundingPaid total and the last funding window folded into it, without
+// touching any other column
+func (r *PositionRepositoryImpl) UpdateFundingState(ctx context.Context, positionID uuid.UUID, fundingPaid float64, lastFundingAccrualAt time.Time) error {
+	query := `
+		UPDATE positions
+		SET funding_paid = $1,
+		    last_funding_accrual_at = $2
+		WHERE id = $3
+	`
+
+	_, err := r.db.Exec(ctx, query, fundingPaid, lastFundingAccrualAt, positionID)
+	if err != nil {
+		return fmt.Errorf("failed to update funding state: %w", err)
+	}
+
+	return nil
+}
+
+// SavePartialFill records one scaled take-profit exit
+func (r *PositionRepositoryImpl) SavePartialFill(ctx context.Context, fill *domain.PartialFill) error {
+	query := `
+		INSERT INTO partial_fills (id, position_id, price, size, fee, closed_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.Exec(ctx, query, fill.ID, fill.PositionID, fill.Price, fill.Size, fill.Fee, fill.ClosedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save partial fill: %w", err)
+	}
+
+	return nil
+}
+
+// GetPartialFills retrieves every partial exit recorded against a position,
+// in execution order
+func (r *PositionRepositoryImpl) GetPartialFills(ctx context.Context, positionID uuid.UUID) ([]*domain.PartialFill, error) {
+	query := `
+		SELECT id, position_id, price, size, fee, closed_at
+		FROM partial_fills
+		WHERE position_id = $1
+		ORDER BY closed_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, positionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query partial fills: %w", err)
+	}
+	defer rows.Close()
+
+	var fills []*domain.PartialFill
+	for rows.Next() {
+		fill := &domain.PartialFill{}
+		if err := rows.Scan(&fill.ID, &fill.PositionID, &fill.Price, &fill.Size, &fill.Fee, &fill.ClosedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan partial fill: %w", err)
+		}
+		fills = append(fills, fill)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating partial fills: %w", err)
+	}
+
+	return fills, nil
+}
+
+// GetPositionsWithTrailingTier retrieves all open positions with a flat
+// activation-ratio/callback-rate trailing ladder configured
+func (r *PositionRepositoryImpl) GetPositionsWithTrailingTier(ctx context.Context) ([]*domain.Position, error) {
+	query := `
+		SELECT id, user_id, signal_id, symbol, side, entry_price,
+		       sl_price, tp_price, size, leverage, status,
+		       created_at, high_water_price, low_water_price, active_trailing_tier,
+		       trailing_activation_ratios, trailing_callback_rates
+		FROM positions
+		WHERE status = 'OPEN' AND trailing_activation_ratios IS NOT NULL
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query positions with trailing tier: %w", err)
+	}
+	defer rows.Close()
+
+	var positions []*domain.Position
+	for rows.Next() {
+		position := &domain.Position{}
+		err := rows.Scan(
+			&position.ID,
+			&position.UserID,
+			&position.SignalID,
+			&position.Symbol,
+			&position.Side,
+			&position.EntryPrice,
+			&position.SLPrice,
+			&position.TPPrice,
+			&position.Size,
+			&position.Leverage,
+			&position.Status,
+			&position.CreatedAt,
+			&position.HighWaterPrice,
+			&position.LowWaterPrice,
+			&position.ActiveTrailingTier,
+			&position.TrailingActivationRatios,
+			&position.TrailingCallbackRates,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan position: %w", err)
+		}
+		positions = append(positions, position)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating positions: %w", err)
+	}
+
+	return positions, nil
+}
+
+// GetByParentSignalID retrieves every scaled-entry layer for a signal,
+// ordered by LayerIndex
+func (r *PositionRepositoryImpl) GetByParentSignalID(ctx context.Context, parentSignalID uuid.UUID) ([]*domain.Position, error) {
+	query := `
+		SELECT id, user_id, signal_id, symbol, side, entry_price,
+		       sl_price, tp_price, size, leverage, exit_price, pnl, status,
+		       created_at, closed_at, parent_signal_id, layer_index
+		FROM positions
+		WHERE parent_signal_id = $1
+		ORDER BY layer_index ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, parentSignalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query positions by parent signal ID: %w", err)
+	}
+	defer rows.Close()
+
+	var positions []*domain.Position
+	for rows.Next() {
+		position := &domain.Position{}
+		err := rows.Scan(
+			&position.ID,
+			&position.UserID,
+			&position.SignalID,
+			&position.Symbol,
+			&position.Side,
+			&position.EntryPrice,
+			&position.SLPrice,
+			&position.TPPrice,
+			&position.Size,
+			&position.Leverage,
+			&position.ExitPrice,
+			&position.PnL,
+			&position.Status,
+			&position.CreatedAt,
+			&position.ClosedAt,
+			&position.ParentSignalID,
+			&position.LayerIndex,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan position: %w", err)
+		}
+		positions = append(positions, position)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating positions: %w", err)
+	}
+
+	return positions, nil
+}
+
+// UpdateBatch updates multiple positions in a single transaction, used when
+// closing every layer of a scaled entry together (see
+// VirtualBrokerService.CloseAllLayers)
+func (r *PositionRepositoryImpl) UpdateBatch(ctx context.Context, positions []*domain.Position) error {
+	if len(positions) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE positions
+		SET exit_price = $1,
+		    pnl = $2,
+		    status = $3,
+		    closed_at = $4,
+		    sl_price = $5,
+		    pnl_percent = $6,
+		    closed_by = $7,
+		    leverage = $8
+		WHERE id = $9
+	`
+
+	for _, position := range positions {
+		_, err := tx.Exec(ctx, query,
+			position.ExitPrice,
+			position.PnL,
+			position.Status,
+			position.ClosedAt,
+			position.SLPrice,
+			position.PnLPercent,
+			position.ClosedBy,
+			position.Leverage,
+			position.ID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update position %s in batch: %w", position.ID, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit batch update: %w", err)
+	}
+
+	return nil
+}
+
+// PartialClose reduces an open position's Size by sizeDelta, e.g. trimming
+// exposure during a portfolio rebalance. It does not touch ExitPrice/PnL/
+// Status since the position stays open.
+func (r *PositionRepositoryImpl) PartialClose(ctx context.Context, id uuid.UUID, sizeDelta float64) error {
+	query := `
+		UPDATE positions
+		SET size = GREATEST(size - $1, 0)
+		WHERE id = $2 AND status = $3
+	`
+
+	_, err := r.db.Exec(ctx, query, sizeDelta, id, domain.StatusOpen)
+	if err != nil {
+		return fmt.Errorf("failed to partial-close position %s: %w", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"neurotrade/internal/domain"
+)
+
+// ProfitStatsRepositoryImpl implements domain.ProfitStatsRepository
+type ProfitStatsRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewProfitStatsRepository creates a new ProfitStatsRepository
+func NewProfitStatsRepository(db *pgxpool.Pool) domain.ProfitStatsRepository {
+	return &ProfitStatsRepositoryImpl{db: db}
+}
+
+// Get retrieves the bucket for (userID, symbol, period), returning a
+// zero-value ProfitStats (not an error) if it doesn't exist yet.
+func (r *ProfitStatsRepositoryImpl) Get(ctx context.Context, userID uuid.UUID, symbol, period string) (*domain.ProfitStats, error) {
+	query := `
+		SELECT user_id, symbol, period, trades, wins, losses,
+		       gross_profit, gross_loss, largest_win, largest_loss,
+		       win_rate, profit_factor, max_drawdown, sharpe_ratio,
+		       start_balance, current_balance, updated_at
+		FROM profit_stats
+		WHERE user_id = $1 AND symbol = $2 AND period = $3
+	`
+
+	stats := &domain.ProfitStats{UserID: userID, Symbol: symbol, Period: period}
+
+	err := r.db.QueryRow(ctx, query, userID, symbol, period).Scan(
+		&stats.UserID, &stats.Symbol, &stats.Period,
+		&stats.Trades, &stats.Wins, &stats.Losses,
+		&stats.GrossProfit, &stats.GrossLoss, &stats.LargestWin, &stats.LargestLoss,
+		&stats.WinRate, &stats.ProfitFactor, &stats.MaxDrawdown, &stats.SharpeRatio,
+		&stats.StartBalance, &stats.CurrentBalance, &stats.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return stats, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profit stats for %s/%s/%s: %w", userID, symbol, period, err)
+	}
+
+	return stats, nil
+}
+
+// Upsert persists stats, replacing any existing row for the same
+// (user_id, symbol, period).
+func (r *ProfitStatsRepositoryImpl) Upsert(ctx context.Context, stats *domain.ProfitStats) error {
+	query := `
+		INSERT INTO profit_stats (
+			user_id, symbol, period, trades, wins, losses,
+			gross_profit, gross_loss, largest_win, largest_loss,
+			win_rate, profit_factor, max_drawdown, sharpe_ratio,
+			start_balance, current_balance, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+		)
+		ON CONFLICT (user_id, symbol, period) DO UPDATE SET
+			trades          = EXCLUDED.trades,
+			wins            = EXCLUDED.wins,
+			losses          = EXCLUDED.losses,
+			gross_profit    = EXCLUDED.gross_profit,
+			gross_loss      = EXCLUDED.gross_loss,
+			largest_win     = EXCLUDED.largest_win,
+			largest_loss    = EXCLUDED.largest_loss,
+			win_rate        = EXCLUDED.win_rate,
+			profit_factor   = EXCLUDED.profit_factor,
+			max_drawdown    = EXCLUDED.max_drawdown,
+			sharpe_ratio    = EXCLUDED.sharpe_ratio,
+			start_balance   = EXCLUDED.start_balance,
+			current_balance = EXCLUDED.current_balance,
+			updated_at      = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		stats.UserID, stats.Symbol, stats.Period, stats.Trades, stats.Wins, stats.Losses,
+		stats.GrossProfit, stats.GrossLoss, stats.LargestWin, stats.LargestLoss,
+		stats.WinRate, stats.ProfitFactor, stats.MaxDrawdown, stats.SharpeRatio,
+		stats.StartBalance, stats.CurrentBalance, stats.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert profit stats for %s/%s/%s: %w", stats.UserID, stats.Symbol, stats.Period, err)
+	}
+
+	return nil
+}
+
+// GetEquityCurve retrieves a user's daily equity snapshots since since,
+// oldest first. A zero since returns the full history.
+func (r *ProfitStatsRepositoryImpl) GetEquityCurve(ctx context.Context, userID uuid.UUID, since time.Time) ([]*domain.EquityPoint, error) {
+	query := `
+		SELECT user_id, balance, as_of
+		FROM equity_curve
+		WHERE user_id = $1 AND as_of >= $2
+		ORDER BY as_of ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query equity curve for %s: %w", userID, err)
+	}
+	defer rows.Close()
+
+	var curve []*domain.EquityPoint
+	for rows.Next() {
+		point := &domain.EquityPoint{}
+		if err := rows.Scan(&point.UserID, &point.Balance, &point.AsOf); err != nil {
+			return nil, fmt.Errorf("failed to scan equity point: %w", err)
+		}
+		curve = append(curve, point)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating equity curve: %w", err)
+	}
+
+	return curve, nil
+}
+
+// SnapshotEquity records a user's balance as asOf's equity point, replacing
+// any snapshot already recorded for that date.
+func (r *ProfitStatsRepositoryImpl) SnapshotEquity(ctx context.Context, userID uuid.UUID, balance float64, asOf time.Time) error {
+	query := `
+		INSERT INTO equity_curve (user_id, as_of, balance)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, as_of) DO UPDATE SET balance = EXCLUDED.balance
+	`
+
+	_, err := r.db.Exec(ctx, query, userID, asOf, balance)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot equity for %s: %w", userID, err)
+	}
+
+	return nil
+}
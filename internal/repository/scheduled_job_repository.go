@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// JobType identifies which service a ScheduledJob dispatches to. New types
+// should also be handled in infra.Scheduler.runJob.
+type JobType string
+
+const (
+	JobTypeMarketScan          JobType = "MARKET_SCAN"
+	JobTypeReviewSignals       JobType = "REVIEW_SIGNALS"
+	JobTypeCloseStalePositions JobType = "CLOSE_STALE_POSITIONS"
+	JobTypeBacktestRolling     JobType = "BACKTEST_ROLLING"
+)
+
+// ScheduledJob is one row of scheduled_jobs: a cron expression paired with a
+// job type and its parameters, so ops can add/pause/retime scheduled work
+// without a redeploy.
+type ScheduledJob struct {
+	ID         int             `json:"id"`
+	Name       string          `json:"name"`
+	CronExpr   string          `json:"cron_expr"`
+	JobType    JobType         `json:"job_type"`
+	ParamsJSON json.RawMessage `json:"params_json"`
+	Enabled    bool            `json:"enabled"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+// ScheduledJobRepository handles scheduled_jobs database operations
+type ScheduledJobRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewScheduledJobRepository creates a new repository instance
+func NewScheduledJobRepository(db *pgxpool.Pool) *ScheduledJobRepository {
+	return &ScheduledJobRepository{db: db}
+}
+
+// GetAll retrieves every scheduled job, enabled or not
+func (r *ScheduledJobRepository) GetAll(ctx context.Context) ([]*ScheduledJob, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, name, cron_expr, job_type, params_json, enabled, created_at, updated_at
+		FROM scheduled_jobs
+		ORDER BY id ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduled jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*ScheduledJob
+	for rows.Next() {
+		var j ScheduledJob
+		if err := rows.Scan(&j.ID, &j.Name, &j.CronExpr, &j.JobType, &j.ParamsJSON, &j.Enabled, &j.CreatedAt, &j.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled job: %w", err)
+		}
+		jobs = append(jobs, &j)
+	}
+
+	return jobs, rows.Err()
+}
+
+// GetEnabled retrieves every enabled scheduled job, for loading the cron
+// instance at startup and on reload
+func (r *ScheduledJobRepository) GetEnabled(ctx context.Context) ([]*ScheduledJob, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, name, cron_expr, job_type, params_json, enabled, created_at, updated_at
+		FROM scheduled_jobs
+		WHERE enabled = true
+		ORDER BY id ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enabled scheduled jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*ScheduledJob
+	for rows.Next() {
+		var j ScheduledJob
+		if err := rows.Scan(&j.ID, &j.Name, &j.CronExpr, &j.JobType, &j.ParamsJSON, &j.Enabled, &j.CreatedAt, &j.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled job: %w", err)
+		}
+		jobs = append(jobs, &j)
+	}
+
+	return jobs, rows.Err()
+}
+
+// GetByID retrieves a single scheduled job
+func (r *ScheduledJobRepository) GetByID(ctx context.Context, id int) (*ScheduledJob, error) {
+	var j ScheduledJob
+	err := r.db.QueryRow(ctx, `
+		SELECT id, name, cron_expr, job_type, params_json, enabled, created_at, updated_at
+		FROM scheduled_jobs
+		WHERE id = $1
+	`, id).Scan(&j.ID, &j.Name, &j.CronExpr, &j.JobType, &j.ParamsJSON, &j.Enabled, &j.CreatedAt, &j.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("scheduled job not found: %d", id)
+	}
+
+	return &j, nil
+}
+
+// Create inserts a new scheduled job
+func (r *ScheduledJobRepository) Create(ctx context.Context, j *ScheduledJob) error {
+	if j.ParamsJSON == nil {
+		j.ParamsJSON = json.RawMessage("{}")
+	}
+
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO scheduled_jobs (name, cron_expr, job_type, params_json, enabled)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`, j.Name, j.CronExpr, j.JobType, j.ParamsJSON, j.Enabled).Scan(&j.ID, &j.CreatedAt, &j.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create scheduled job: %w", err)
+	}
+
+	return nil
+}
+
+// Update overwrites a scheduled job's cron expression, type, params and
+// enabled flag
+func (r *ScheduledJobRepository) Update(ctx context.Context, j *ScheduledJob) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE scheduled_jobs
+		SET cron_expr = $1, job_type = $2, params_json = $3, enabled = $4, updated_at = now()
+		WHERE id = $5
+	`, j.CronExpr, j.JobType, j.ParamsJSON, j.Enabled, j.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update scheduled job: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("scheduled job not found: %d", j.ID)
+	}
+
+	return nil
+}
+
+// SetEnabled toggles a scheduled job on/off without touching its schedule
+func (r *ScheduledJobRepository) SetEnabled(ctx context.Context, id int, enabled bool) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE scheduled_jobs SET enabled = $1, updated_at = now() WHERE id = $2
+	`, enabled, id)
+	if err != nil {
+		return fmt.Errorf("failed to set scheduled job enabled: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("scheduled job not found: %d", id)
+	}
+
+	return nil
+}
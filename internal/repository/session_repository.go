@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"neurotrade/internal/domain"
+)
+
+// SessionRepositoryImpl implements the SessionRepository interface
+type SessionRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewSessionRepository creates a new SessionRepository
+func NewSessionRepository(db *pgxpool.Pool) domain.SessionRepository {
+	return &SessionRepositoryImpl{db: db}
+}
+
+// Create inserts a new session row
+func (r *SessionRepositoryImpl) Create(ctx context.Context, session *domain.Session) error {
+	query := `
+		INSERT INTO sessions (
+			id, user_id, refresh_token_hash, user_agent, ip, expires_at, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7
+		)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		session.ID,
+		session.UserID,
+		session.RefreshTokenHash,
+		session.UserAgent,
+		session.IP,
+		session.ExpiresAt,
+		session.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTokenHash looks up the session for a hashed refresh token value
+func (r *SessionRepositoryImpl) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Session, error) {
+	query := `
+		SELECT id, user_id, refresh_token_hash, user_agent, ip, expires_at, revoked_at, created_at
+		FROM sessions
+		WHERE refresh_token_hash = $1
+	`
+
+	session := &domain.Session{}
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(
+		&session.ID,
+		&session.UserID,
+		&session.RefreshTokenHash,
+		&session.UserAgent,
+		&session.IP,
+		&session.ExpiresAt,
+		&session.RevokedAt,
+		&session.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("session not found")
+		}
+		return nil, fmt.Errorf("failed to get session by token hash: %w", err)
+	}
+
+	return session, nil
+}
+
+// Revoke marks a single session revoked
+func (r *SessionRepositoryImpl) Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	query := `
+		UPDATE sessions
+		SET revoked_at = $1
+		WHERE id = $2
+	`
+
+	_, err := r.db.Exec(ctx, query, revokedAt, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAllForUser marks every session belonging to userID revoked
+func (r *SessionRepositoryImpl) RevokeAllForUser(ctx context.Context, userID uuid.UUID, revokedAt time.Time) error {
+	query := `
+		UPDATE sessions
+		SET revoked_at = $1
+		WHERE user_id = $2 AND revoked_at IS NULL
+	`
+
+	_, err := r.db.Exec(ctx, query, revokedAt, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions for user: %w", err)
+	}
+
+	return nil
+}
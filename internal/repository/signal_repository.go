@@ -51,23 +51,27 @@ func (r *SignalRepositoryImpl) Save(ctx context.Context, signal *domain.Signal)
 	return nil
 }
 
-// UpsertPending updates an existing PENDING signal or creates a new one
+// UpsertPending updates an existing PENDING signal or creates a new one.
+// Dedup keys on (symbol, type) rather than symbol alone, so a hedge-mode
+// user's simultaneous LONG and SHORT interest in the same symbol produce two
+// independent PENDING rows instead of one clobbering the other -- see
+// domain.PositionSideForSide.
 // Returns true if created (INSERT), false if updated
 func (r *SignalRepositoryImpl) UpsertPending(ctx context.Context, signal *domain.Signal) (bool, error) {
-	// 1. Try to UPDATE existing PENDING signal for this symbol
+	// 1. Try to UPDATE existing PENDING signal for this symbol+type
 	updateQuery := `
 		UPDATE signals
-		SET type = $1, entry_price = $2, sl_price = $3, tp_price = $4,
-		    confidence = $5, reasoning = $6, created_at = $7
-		WHERE symbol = $8 AND status = 'PENDING'
+		SET entry_price = $1, sl_price = $2, tp_price = $3,
+		    confidence = $4, reasoning = $5, created_at = $6
+		WHERE symbol = $7 AND type = $8 AND status = 'PENDING'
 		RETURNING id
 	`
 
 	var existingID uuid.UUID
 	err := r.db.QueryRow(ctx, updateQuery,
-		signal.Type, signal.EntryPrice, signal.SLPrice, signal.TPPrice,
+		signal.EntryPrice, signal.SLPrice, signal.TPPrice,
 		signal.Confidence, signal.Reasoning, signal.CreatedAt,
-		signal.Symbol,
+		signal.Symbol, signal.Type,
 	).Scan(&existingID)
 
 	if err == nil {
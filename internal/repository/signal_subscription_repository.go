@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"neurotrade/internal/domain"
+)
+
+// SignalSubscriptionRepositoryImpl implements domain.SignalSubscriptionRepository
+type SignalSubscriptionRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewSignalSubscriptionRepository creates a new SignalSubscriptionRepository
+func NewSignalSubscriptionRepository(db *pgxpool.Pool) domain.SignalSubscriptionRepository {
+	return &SignalSubscriptionRepositoryImpl{db: db}
+}
+
+// GetForUser retrieves every subscription a user has opted into
+func (r *SignalSubscriptionRepositoryImpl) GetForUser(ctx context.Context, userID uuid.UUID) ([]*domain.SignalSubscription, error) {
+	query := `
+		SELECT id, user_id, symbol, strategy, created_at
+		FROM signal_subscriptions
+		WHERE user_id = $1
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query signal subscriptions for user %s: %w", userID, err)
+	}
+	defer rows.Close()
+
+	var subs []*domain.SignalSubscription
+	for rows.Next() {
+		sub := &domain.SignalSubscription{}
+		if err := rows.Scan(&sub.ID, &sub.UserID, &sub.Symbol, &sub.Strategy, &sub.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan signal subscription: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating signal subscriptions: %w", err)
+	}
+
+	return subs, nil
+}
@@ -2,22 +2,27 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"neurotrade/internal/crypto"
 	"neurotrade/internal/domain"
 )
 
 // UserRepositoryImpl implements the UserRepository interface
 type UserRepositoryImpl struct {
-	db *pgxpool.Pool
+	db       *pgxpool.Pool
+	envelope *crypto.Envelope
 }
 
-// NewUserRepository creates a new UserRepository
-func NewUserRepository(db *pgxpool.Pool) domain.UserRepository {
-	return &UserRepositoryImpl{db: db}
+// NewUserRepository creates a new UserRepository. Credentials are
+// transparently encrypted on write and decrypted on read using envelope.
+func NewUserRepository(db *pgxpool.Pool, envelope *crypto.Envelope) domain.UserRepository {
+	return &UserRepositoryImpl{db: db, envelope: envelope}
 }
 
 // Create creates a new user
@@ -25,9 +30,10 @@ func (r *UserRepositoryImpl) Create(ctx context.Context, user *domain.User) erro
 	query := `
 		INSERT INTO users (
 			id, username, password_hash, role,
-			paper_balance, mode, is_auto_trade_enabled, fixed_order_size, leverage, created_at
+			paper_balance, mode, is_auto_trade_enabled, fixed_order_size, leverage,
+			min_confidence, position_size_percent, max_concurrent_positions, hedge_enabled, created_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
 		)
 	`
 
@@ -41,6 +47,10 @@ func (r *UserRepositoryImpl) Create(ctx context.Context, user *domain.User) erro
 		user.IsAutoTradeEnabled,
 		user.FixedOrderSize,
 		user.Leverage,
+		user.MinConfidence,
+		user.PositionSizePercent,
+		user.MaxConcurrentPositions,
+		user.HedgeEnabled,
 		user.CreatedAt,
 	)
 
@@ -55,8 +65,11 @@ func (r *UserRepositoryImpl) Create(ctx context.Context, user *domain.User) erro
 func (r *UserRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	query := `
 		SELECT id, username, password_hash, role,
-		       paper_balance, real_balance_cache, mode, is_auto_trade_enabled, fixed_order_size, leverage, 
-               COALESCE(binance_api_key, ''), COALESCE(binance_api_secret, ''), created_at, updated_at
+		       paper_balance, real_balance_cache, testnet_balance_cache, mode, is_auto_trade_enabled, fixed_order_size, leverage,
+               min_confidence, position_size_percent, max_concurrent_positions, hedge_enabled, position_mode,
+               COALESCE(binance_api_key, ''), COALESCE(binance_api_secret, ''),
+               COALESCE(binance_testnet_api_key, ''), COALESCE(binance_testnet_api_secret, ''),
+               trade_cooldown_until, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -69,12 +82,21 @@ func (r *UserRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain
 		&user.Role,
 		&user.PaperBalance,
 		&user.RealBalanceCache,
+		&user.TestnetBalanceCache,
 		&user.Mode,
 		&user.IsAutoTradeEnabled,
 		&user.FixedOrderSize,
 		&user.Leverage,
+		&user.MinConfidence,
+		&user.PositionSizePercent,
+		&user.MaxConcurrentPositions,
+		&user.HedgeEnabled,
+		&user.PositionMode,
 		&user.BinanceAPIKey,
 		&user.BinanceAPISecret,
+		&user.BinanceTestnetAPIKey,
+		&user.BinanceTestnetAPISecret,
+		&user.TradeCooldownUntil,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -83,6 +105,10 @@ func (r *UserRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain
 		return nil, fmt.Errorf("failed to get user by ID: %w", err)
 	}
 
+	if err := r.decryptCredentials(user); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
@@ -90,8 +116,10 @@ func (r *UserRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain
 func (r *UserRepositoryImpl) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
 	query := `
 		SELECT id, username, password_hash, role,
-		       paper_balance, real_balance_cache, mode, is_auto_trade_enabled, fixed_order_size, leverage, 
-               COALESCE(binance_api_key, ''), COALESCE(binance_api_secret, ''), created_at, updated_at
+		       paper_balance, real_balance_cache, testnet_balance_cache, mode, is_auto_trade_enabled, fixed_order_size, leverage,
+               min_confidence, position_size_percent, max_concurrent_positions, hedge_enabled, position_mode,
+               COALESCE(binance_api_key, ''), COALESCE(binance_api_secret, ''),
+               COALESCE(binance_testnet_api_key, ''), COALESCE(binance_testnet_api_secret, ''), created_at, updated_at
 		FROM users
 		WHERE username = $1
 	`
@@ -104,12 +132,20 @@ func (r *UserRepositoryImpl) GetByUsername(ctx context.Context, username string)
 		&user.Role,
 		&user.PaperBalance,
 		&user.RealBalanceCache,
+		&user.TestnetBalanceCache,
 		&user.Mode,
 		&user.IsAutoTradeEnabled,
 		&user.FixedOrderSize,
 		&user.Leverage,
+		&user.MinConfidence,
+		&user.PositionSizePercent,
+		&user.MaxConcurrentPositions,
+		&user.HedgeEnabled,
+		&user.PositionMode,
 		&user.BinanceAPIKey,
 		&user.BinanceAPISecret,
+		&user.BinanceTestnetAPIKey,
+		&user.BinanceTestnetAPISecret,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -118,6 +154,10 @@ func (r *UserRepositoryImpl) GetByUsername(ctx context.Context, username string)
 		return nil, fmt.Errorf("failed to get user by username: %w", err)
 	}
 
+	if err := r.decryptCredentials(user); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
@@ -125,13 +165,20 @@ func (r *UserRepositoryImpl) GetByUsername(ctx context.Context, username string)
 func (r *UserRepositoryImpl) UpdateBalance(ctx context.Context, userID uuid.UUID, balance float64, mode string) error {
 	var query string
 
-	if mode == domain.ModeReal {
+	switch mode {
+	case domain.ModeReal:
 		query = `
 			UPDATE users
 			SET real_balance_cache = $1, updated_at = NOW()
 			WHERE id = $2
 		`
-	} else {
+	case domain.ModeTestnet:
+		query = `
+			UPDATE users
+			SET testnet_balance_cache = $1, updated_at = NOW()
+			WHERE id = $2
+		`
+	default:
 		// Default to PAPER
 		query = `
 			UPDATE users
@@ -150,17 +197,32 @@ func (r *UserRepositoryImpl) UpdateBalance(ctx context.Context, userID uuid.UUID
 
 // GetAll retrieves all users
 func (r *UserRepositoryImpl) GetAll(ctx context.Context) ([]*domain.User, error) {
-	query := `
+	return r.queryUsers(ctx, "", nil)
+}
+
+// GetActiveTraders retrieves every user with auto-trading enabled, for
+// ProcessMarketScan to fan signals out to instead of one defaultUserID
+func (r *UserRepositoryImpl) GetActiveTraders(ctx context.Context) ([]*domain.User, error) {
+	return r.queryUsers(ctx, "WHERE is_auto_trade_enabled = true", nil)
+}
+
+// queryUsers runs the shared user listing query with an optional WHERE
+// clause (whereClause may be "") and its args, used by GetAll/GetActiveTraders.
+func (r *UserRepositoryImpl) queryUsers(ctx context.Context, whereClause string, args []interface{}) ([]*domain.User, error) {
+	query := fmt.Sprintf(`
 		SELECT id, username, password_hash, role,
-		       paper_balance, real_balance_cache, mode, is_auto_trade_enabled, fixed_order_size, leverage, 
-		       COALESCE(binance_api_key, ''), COALESCE(binance_api_secret, ''), created_at, updated_at
+		       paper_balance, real_balance_cache, testnet_balance_cache, mode, is_auto_trade_enabled, fixed_order_size, leverage,
+		       min_confidence, position_size_percent, max_concurrent_positions, hedge_enabled, position_mode,
+		       COALESCE(binance_api_key, ''), COALESCE(binance_api_secret, ''),
+		       COALESCE(binance_testnet_api_key, ''), COALESCE(binance_testnet_api_secret, ''), created_at, updated_at
 		FROM users
+		%s
 		ORDER BY created_at ASC
-	`
+	`, whereClause)
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query all users: %w", err)
+		return nil, fmt.Errorf("failed to query users: %w", err)
 	}
 	defer rows.Close()
 
@@ -174,18 +236,29 @@ func (r *UserRepositoryImpl) GetAll(ctx context.Context) ([]*domain.User, error)
 			&user.Role,
 			&user.PaperBalance,
 			&user.RealBalanceCache,
+			&user.TestnetBalanceCache,
 			&user.Mode,
 			&user.IsAutoTradeEnabled,
 			&user.FixedOrderSize,
 			&user.Leverage,
+			&user.MinConfidence,
+			&user.PositionSizePercent,
+			&user.MaxConcurrentPositions,
+			&user.HedgeEnabled,
+			&user.PositionMode,
 			&user.BinanceAPIKey,
 			&user.BinanceAPISecret,
+			&user.BinanceTestnetAPIKey,
+			&user.BinanceTestnetAPISecret,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
+		if err := r.decryptCredentials(user); err != nil {
+			return nil, err
+		}
 		users = append(users, user)
 	}
 
@@ -196,6 +269,25 @@ func (r *UserRepositoryImpl) GetAll(ctx context.Context) ([]*domain.User, error)
 	return users, nil
 }
 
+// decryptCredentials decrypts a freshly-scanned user's Binance credential
+// columns in place
+func (r *UserRepositoryImpl) decryptCredentials(user *domain.User) error {
+	var err error
+	if user.BinanceAPIKey, err = r.envelope.Decrypt(user.BinanceAPIKey); err != nil {
+		return fmt.Errorf("failed to decrypt binance api key: %w", err)
+	}
+	if user.BinanceAPISecret, err = r.envelope.Decrypt(user.BinanceAPISecret); err != nil {
+		return fmt.Errorf("failed to decrypt binance api secret: %w", err)
+	}
+	if user.BinanceTestnetAPIKey, err = r.envelope.Decrypt(user.BinanceTestnetAPIKey); err != nil {
+		return fmt.Errorf("failed to decrypt binance testnet api key: %w", err)
+	}
+	if user.BinanceTestnetAPISecret, err = r.envelope.Decrypt(user.BinanceTestnetAPISecret); err != nil {
+		return fmt.Errorf("failed to decrypt binance testnet api secret: %w", err)
+	}
+	return nil
+}
+
 // UpdateAutoTradeStatus updates the auto-trade flag for a user
 func (r *UserRepositoryImpl) UpdateAutoTradeStatus(ctx context.Context, userID uuid.UUID, enabled bool) error {
 	query := `
@@ -212,22 +304,67 @@ func (r *UserRepositoryImpl) UpdateAutoTradeStatus(ctx context.Context, userID u
 	return nil
 }
 
+// UpdatePositionMode switches a user between ONE_WAY and HEDGE position mode
+func (r *UserRepositoryImpl) UpdatePositionMode(ctx context.Context, userID uuid.UUID, mode string) error {
+	query := `
+		UPDATE users
+		SET position_mode = $1, updated_at = NOW()
+		WHERE id = $2
+	`
+
+	_, err := r.db.Exec(ctx, query, mode, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update position mode: %w", err)
+	}
+
+	return nil
+}
+
 // UpdateSettings updates user trading settings
 func (r *UserRepositoryImpl) UpdateSettings(ctx context.Context, user *domain.User) error {
+	defaultTrailingConfig, err := json.Marshal(user.DefaultTrailingConfig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal default trailing config: %w", err)
+	}
+
+	encryptedAPIKey, err := r.envelope.Encrypt(user.BinanceAPIKey)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt binance api key: %w", err)
+	}
+	encryptedAPISecret, err := r.envelope.Encrypt(user.BinanceAPISecret)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt binance api secret: %w", err)
+	}
+	encryptedTestnetAPIKey, err := r.envelope.Encrypt(user.BinanceTestnetAPIKey)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt binance testnet api key: %w", err)
+	}
+	encryptedTestnetAPISecret, err := r.envelope.Encrypt(user.BinanceTestnetAPISecret)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt binance testnet api secret: %w", err)
+	}
+
 	query := `
 		UPDATE users
-		SET mode = $1, fixed_order_size = $2, leverage = $3, is_auto_trade_enabled = $4, 
-            binance_api_key = $5, binance_api_secret = $6, updated_at = NOW()
-		WHERE id = $7
+		SET mode = $1, fixed_order_size = $2, leverage = $3, is_auto_trade_enabled = $4,
+            binance_api_key = $5, binance_api_secret = $6, default_trailing_config = $7,
+            binance_testnet_api_key = $8, binance_testnet_api_secret = $9,
+            default_roi_sl_percent = $10, default_roi_tp_percent = $11, updated_at = NOW()
+		WHERE id = $12
 	`
 
-	_, err := r.db.Exec(ctx, query,
+	_, err = r.db.Exec(ctx, query,
 		user.Mode,
 		user.FixedOrderSize,
 		user.Leverage,
 		user.IsAutoTradeEnabled,
-		user.BinanceAPIKey,
-		user.BinanceAPISecret,
+		encryptedAPIKey,
+		encryptedAPISecret,
+		defaultTrailingConfig,
+		encryptedTestnetAPIKey,
+		encryptedTestnetAPISecret,
+		user.DefaultROIStopLossPercent,
+		user.DefaultROITakeProfitPercent,
 		user.ID,
 	)
 
@@ -253,3 +390,153 @@ func (r *UserRepositoryImpl) UpdateRealBalance(ctx context.Context, userID uuid.
 
 	return nil
 }
+
+// UpdateTestnetBalance updates cached testnet wallet balance from Binance,
+// mirroring UpdateRealBalance so TESTNET behaves like REAL for accounting
+// purposes without ever touching live funds
+func (r *UserRepositoryImpl) UpdateTestnetBalance(ctx context.Context, userID uuid.UUID, balance float64) error {
+	query := `
+		UPDATE users
+		SET testnet_balance_cache = $1, updated_at = NOW()
+		WHERE id = $2
+	`
+
+	_, err := r.db.Exec(ctx, query, balance, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update testnet balance cache: %w", err)
+	}
+
+	return nil
+}
+
+// SetTradeCooldownUntil records an exchange-throttling cooldown deadline for
+// a user, without touching is_auto_trade_enabled, so the auto-trader can skip
+// them transparently until it elapses. Pass a zero time to clear it early.
+func (r *UserRepositoryImpl) SetTradeCooldownUntil(ctx context.Context, userID uuid.UUID, until time.Time) error {
+	query := `
+		UPDATE users
+		SET trade_cooldown_until = NULLIF($1, '0001-01-01 00:00:00+00'::timestamptz), updated_at = NOW()
+		WHERE id = $2
+	`
+
+	_, err := r.db.Exec(ctx, query, until, userID)
+	if err != nil {
+		return fmt.Errorf("failed to set trade cooldown: %w", err)
+	}
+
+	return nil
+}
+
+// RotateEncryptionKey re-encrypts every user's Binance credential columns
+// from oldKey to newKey. Each row is decrypted and re-encrypted within its
+// own transaction, so a failure partway through leaves already-rotated rows
+// committed instead of rolling back the whole user table.
+func (r *UserRepositoryImpl) RotateEncryptionKey(ctx context.Context, oldKey, newKey []byte) error {
+	oldEnvelope, err := crypto.NewEnvelope(oldKey)
+	if err != nil {
+		return fmt.Errorf("failed to build envelope for old key: %w", err)
+	}
+	newEnvelope, err := crypto.NewEnvelope(newKey)
+	if err != nil {
+		return fmt.Errorf("failed to build envelope for new key: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, COALESCE(binance_api_key, ''), COALESCE(binance_api_secret, ''),
+		       COALESCE(binance_testnet_api_key, ''), COALESCE(binance_testnet_api_secret, '')
+		FROM users
+		ORDER BY created_at ASC
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to list users for key rotation: %w", err)
+	}
+
+	type encryptedRow struct {
+		id                        uuid.UUID
+		apiKey, apiSecret         string
+		testnetKey, testnetSecret string
+	}
+
+	var toRotate []encryptedRow
+	for rows.Next() {
+		var row encryptedRow
+		if err := rows.Scan(&row.id, &row.apiKey, &row.apiSecret, &row.testnetKey, &row.testnetSecret); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan user for key rotation: %w", err)
+		}
+		toRotate = append(toRotate, row)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return fmt.Errorf("error iterating users for key rotation: %w", err)
+	}
+	rows.Close()
+
+	for _, row := range toRotate {
+		apiKey, err := oldEnvelope.Decrypt(row.apiKey)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt binance api key for user %s: %w", row.id, err)
+		}
+		apiSecret, err := oldEnvelope.Decrypt(row.apiSecret)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt binance api secret for user %s: %w", row.id, err)
+		}
+		testnetKey, err := oldEnvelope.Decrypt(row.testnetKey)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt binance testnet api key for user %s: %w", row.id, err)
+		}
+		testnetSecret, err := oldEnvelope.Decrypt(row.testnetSecret)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt binance testnet api secret for user %s: %w", row.id, err)
+		}
+
+		reEncryptedAPIKey, err := newEnvelope.Encrypt(apiKey)
+		if err != nil {
+			return fmt.Errorf("failed to re-encrypt binance api key for user %s: %w", row.id, err)
+		}
+		reEncryptedAPISecret, err := newEnvelope.Encrypt(apiSecret)
+		if err != nil {
+			return fmt.Errorf("failed to re-encrypt binance api secret for user %s: %w", row.id, err)
+		}
+		reEncryptedTestnetKey, err := newEnvelope.Encrypt(testnetKey)
+		if err != nil {
+			return fmt.Errorf("failed to re-encrypt binance testnet api key for user %s: %w", row.id, err)
+		}
+		reEncryptedTestnetSecret, err := newEnvelope.Encrypt(testnetSecret)
+		if err != nil {
+			return fmt.Errorf("failed to re-encrypt binance testnet api secret for user %s: %w", row.id, err)
+		}
+
+		if err := r.rotateRow(ctx, row.id, reEncryptedAPIKey, reEncryptedAPISecret, reEncryptedTestnetKey, reEncryptedTestnetSecret); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rotateRow writes one user's re-encrypted credentials inside its own
+// transaction
+func (r *UserRepositoryImpl) rotateRow(ctx context.Context, userID uuid.UUID, apiKey, apiSecret, testnetKey, testnetSecret string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin rotation transaction for user %s: %w", userID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE users
+		SET binance_api_key = $1, binance_api_secret = $2,
+            binance_testnet_api_key = $3, binance_testnet_api_secret = $4, updated_at = NOW()
+		WHERE id = $5
+	`, apiKey, apiSecret, testnetKey, testnetSecret, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update rotated credentials for user %s: %w", userID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit rotation for user %s: %w", userID, err)
+	}
+
+	return nil
+}
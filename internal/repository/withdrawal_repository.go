@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"neurotrade/internal/domain"
+)
+
+// WithdrawalRepositoryImpl implements the WithdrawalRepository interface
+type WithdrawalRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewWithdrawalRepository creates a new WithdrawalRepository
+func NewWithdrawalRepository(db *pgxpool.Pool) domain.WithdrawalRepository {
+	return &WithdrawalRepositoryImpl{db: db}
+}
+
+// Upsert inserts a withdrawal, or is a no-op if (exchange, txn_id) already exists
+func (r *WithdrawalRepositoryImpl) Upsert(ctx context.Context, withdrawal *domain.Withdrawal) error {
+	query := `
+		INSERT INTO withdrawals (
+			id, user_id, exchange, asset, address, network, amount,
+			txn_id, txn_fee, txn_fee_currency, time, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+		)
+		ON CONFLICT (exchange, txn_id) DO NOTHING
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		withdrawal.ID, withdrawal.UserID, withdrawal.Exchange, withdrawal.Asset,
+		withdrawal.Address, withdrawal.Network, withdrawal.Amount, withdrawal.TxnID,
+		withdrawal.TxnFee, withdrawal.TxnFeeCurrency, withdrawal.Time, withdrawal.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert withdrawal: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUserSince retrieves withdrawals for a user since a given time
+func (r *WithdrawalRepositoryImpl) GetByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]*domain.Withdrawal, error) {
+	query := `
+		SELECT id, user_id, exchange, asset, address, network, amount,
+		       txn_id, txn_fee, txn_fee_currency, time, created_at
+		FROM withdrawals
+		WHERE user_id = $1 AND time >= $2
+		ORDER BY time DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query withdrawals: %w", err)
+	}
+	defer rows.Close()
+
+	var withdrawals []*domain.Withdrawal
+	for rows.Next() {
+		w := &domain.Withdrawal{}
+		if err := rows.Scan(
+			&w.ID, &w.UserID, &w.Exchange, &w.Asset, &w.Address, &w.Network,
+			&w.Amount, &w.TxnID, &w.TxnFee, &w.TxnFeeCurrency, &w.Time, &w.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan withdrawal: %w", err)
+		}
+		withdrawals = append(withdrawals, w)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating withdrawals: %w", err)
+	}
+
+	return withdrawals, nil
+}
+
+// SumByUserSince sums withdrawal amounts for a user since a given time
+func (r *WithdrawalRepositoryImpl) SumByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (float64, error) {
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM withdrawals
+		WHERE user_id = $1 AND time >= $2
+	`
+
+	var total float64
+	if err := r.db.QueryRow(ctx, query, userID, since).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to sum withdrawals: %w", err)
+	}
+
+	return total, nil
+}
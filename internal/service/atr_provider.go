@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"neurotrade/internal/domain"
+)
+
+// atrCacheTTL is how long a fetched ATR value is reused before refetching,
+// so a 10-second monitoring loop doesn't hit the kline endpoint every tick.
+const atrCacheTTL = 1 * time.Minute
+
+type cachedATR struct {
+	value     float64
+	expiresAt time.Time
+}
+
+// MarketATRProvider implements domain.ATRProvider on top of a KlineProvider,
+// caching each symbol/interval/window's ATR for atrCacheTTL.
+type MarketATRProvider struct {
+	klineProvider domain.KlineProvider
+
+	mu    sync.Mutex
+	cache map[string]cachedATR
+}
+
+// NewMarketATRProvider creates a MarketATRProvider backed by klineProvider
+func NewMarketATRProvider(klineProvider domain.KlineProvider) *MarketATRProvider {
+	return &MarketATRProvider{
+		klineProvider: klineProvider,
+		cache:         make(map[string]cachedATR),
+	}
+}
+
+// GetATR implements domain.ATRProvider, computing the Wilder ATR over window
+// candles at interval and caching the result per symbol/interval/window.
+func (p *MarketATRProvider) GetATR(ctx context.Context, symbol, interval string, window int) (float64, error) {
+	key := fmt.Sprintf("%s|%s|%d", symbol, interval, window)
+
+	p.mu.Lock()
+	if cached, ok := p.cache[key]; ok && time.Now().Before(cached.expiresAt) {
+		p.mu.Unlock()
+		return cached.value, nil
+	}
+	p.mu.Unlock()
+
+	klines, err := p.klineProvider.GetKlines(ctx, symbol, interval, window+1)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch klines for %s: %w", symbol, err)
+	}
+
+	atr := calculateATR(klines, window)
+	if atr <= 0 {
+		return 0, fmt.Errorf("could not compute ATR for %s: insufficient klines", symbol)
+	}
+
+	p.mu.Lock()
+	p.cache[key] = cachedATR{value: atr, expiresAt: time.Now().Add(atrCacheTTL)}
+	p.mu.Unlock()
+
+	return atr, nil
+}
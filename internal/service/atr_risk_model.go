@@ -0,0 +1,93 @@
+package service
+
+import (
+	"math"
+
+	"neurotrade/internal/domain"
+)
+
+// ATRRiskModel derives SL/TP from Average True Range instead of a fixed
+// per-strategy distance, so stop placement adapts to each symbol's current
+// volatility. ATR_n = EMA(TrueRange, Window), seeded with the SMA of the
+// first Window true ranges.
+type ATRRiskModel struct {
+	Interval         string  // kline interval to request from the KlineProvider, e.g. "15m"
+	Window           int     // EMA window for the ATR
+	SLMultiplier     float64 // SL = entry -/+ SLMultiplier*ATR
+	TPMultiplier     float64 // TP = entry +/- TPMultiplier*ATR
+	MinPriceRangePct float64 // reject if (2*SLMultiplier*ATR)/entry is below this
+}
+
+// NewATRRiskModel creates an ATRRiskModel
+func NewATRRiskModel(interval string, window int, slMultiplier, tpMultiplier, minPriceRangePct float64) *ATRRiskModel {
+	return &ATRRiskModel{
+		Interval:         interval,
+		Window:           window,
+		SLMultiplier:     slMultiplier,
+		TPMultiplier:     tpMultiplier,
+		MinPriceRangePct: minPriceRangePct,
+	}
+}
+
+// ComputeSLTP implements domain.RiskModel
+func (m *ATRRiskModel) ComputeSLTP(side string, entryPrice float64, klines []domain.Kline) (sl, tp, atr float64, ok bool) {
+	atr = calculateATR(klines, m.Window)
+	if atr <= 0 || entryPrice <= 0 {
+		return 0, 0, 0, false
+	}
+
+	if side == domain.SideLong {
+		sl = entryPrice - m.SLMultiplier*atr
+		tp = entryPrice + m.TPMultiplier*atr
+	} else {
+		sl = entryPrice + m.SLMultiplier*atr
+		tp = entryPrice - m.TPMultiplier*atr
+	}
+
+	rangePct := (2 * m.SLMultiplier * atr) / entryPrice
+	if rangePct < m.MinPriceRangePct {
+		return sl, tp, atr, false
+	}
+
+	return sl, tp, atr, true
+}
+
+// calculateATR computes TrueRange = max(high-low, |high-prevClose|,
+// |low-prevClose|) for each candle after the first, then EMAs it over
+// window, seeding the EMA with the SMA of the first window true ranges.
+func calculateATR(klines []domain.Kline, window int) float64 {
+	if window < 1 || len(klines) < 2 {
+		return 0
+	}
+
+	trueRanges := make([]float64, 0, len(klines)-1)
+	for i := 1; i < len(klines); i++ {
+		high, low, prevClose := klines[i].High, klines[i].Low, klines[i-1].Close
+
+		tr := high - low
+		if v := math.Abs(high - prevClose); v > tr {
+			tr = v
+		}
+		if v := math.Abs(low - prevClose); v > tr {
+			tr = v
+		}
+		trueRanges = append(trueRanges, tr)
+	}
+
+	if window > len(trueRanges) {
+		window = len(trueRanges)
+	}
+
+	var atr float64
+	for i := 0; i < window; i++ {
+		atr += trueRanges[i]
+	}
+	atr /= float64(window)
+
+	alpha := 2.0 / float64(window+1)
+	for i := window; i < len(trueRanges); i++ {
+		atr = alpha*trueRanges[i] + (1-alpha)*atr
+	}
+
+	return atr
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"neurotrade/internal/domain"
+)
+
+// bbCacheTTL is how long a fetched Bollinger Band reading is reused before
+// refetching, so a 10-second monitoring loop doesn't hit the kline endpoint
+// every tick.
+const bbCacheTTL = 1 * time.Minute
+
+type cachedBands struct {
+	upper, mid, lower float64
+	expiresAt         time.Time
+}
+
+// MarketBBProvider implements domain.BBProvider on top of a KlineProvider,
+// caching each symbol/interval/window/k's bands for bbCacheTTL.
+type MarketBBProvider struct {
+	klineProvider domain.KlineProvider
+
+	mu    sync.Mutex
+	cache map[string]cachedBands
+}
+
+// NewMarketBBProvider creates a MarketBBProvider backed by klineProvider
+func NewMarketBBProvider(klineProvider domain.KlineProvider) *MarketBBProvider {
+	return &MarketBBProvider{
+		klineProvider: klineProvider,
+		cache:         make(map[string]cachedBands),
+	}
+}
+
+// GetBands implements domain.BBProvider, computing an SMA +/- k*stdev over
+// window closes at interval and caching the result per symbol/interval/
+// window/k.
+func (p *MarketBBProvider) GetBands(ctx context.Context, symbol, interval string, window int, k float64) (upper, mid, lower float64, err error) {
+	key := fmt.Sprintf("%s|%s|%d|%.2f", symbol, interval, window, k)
+
+	p.mu.Lock()
+	if cached, ok := p.cache[key]; ok && time.Now().Before(cached.expiresAt) {
+		p.mu.Unlock()
+		return cached.upper, cached.mid, cached.lower, nil
+	}
+	p.mu.Unlock()
+
+	klines, err := p.klineProvider.GetKlines(ctx, symbol, interval, window)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to fetch klines for %s: %w", symbol, err)
+	}
+	if len(klines) < window {
+		return 0, 0, 0, fmt.Errorf("could not compute Bollinger Bands for %s: insufficient klines", symbol)
+	}
+
+	upper, mid, lower = calculateBollingerBands(klines, window, k)
+
+	p.mu.Lock()
+	p.cache[key] = cachedBands{upper: upper, mid: mid, lower: lower, expiresAt: time.Now().Add(bbCacheTTL)}
+	p.mu.Unlock()
+
+	return upper, mid, lower, nil
+}
+
+// calculateBollingerBands computes the SMA of the last window closes plus/
+// minus k standard deviations of those same closes
+func calculateBollingerBands(klines []domain.Kline, window int, k float64) (upper, mid, lower float64) {
+	closes := klines[len(klines)-window:]
+
+	sum := 0.0
+	for _, c := range closes {
+		sum += c.Close
+	}
+	mid = sum / float64(window)
+
+	variance := 0.0
+	for _, c := range closes {
+		diff := c.Close - mid
+		variance += diff * diff
+	}
+	stdev := math.Sqrt(variance / float64(window))
+
+	upper = mid + k*stdev
+	lower = mid - k*stdev
+	return upper, mid, lower
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"strconv"
@@ -9,6 +10,8 @@ import (
 	"time"
 
 	"neurotrade/internal/domain"
+	"neurotrade/internal/notification"
+	"neurotrade/internal/portfolioguard"
 )
 
 // getEnvFloat gets an environment variable as float64 or returns a default value
@@ -21,6 +24,44 @@ func getEnvFloat(key string, defaultValue float64) float64 {
 	return defaultValue
 }
 
+// getEnvFloatList gets a comma-separated environment variable as a []float64,
+// falling back to defaultValue if unset or if any entry fails to parse
+func getEnvFloatList(key string, defaultValue []float64) []float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parts := strings.Split(value, ",")
+	list := make([]float64, 0, len(parts))
+	for _, part := range parts {
+		floatVal, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return defaultValue
+		}
+		list = append(list, floatVal)
+	}
+	return list
+}
+
+// getEnvBool gets an environment variable as a bool or returns a default value
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			return boolVal
+		}
+	}
+	return defaultValue
+}
+
+// getEnvString gets an environment variable or returns a default value
+func getEnvString(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
 // BodyguardService provides fast position monitoring (10-second interval)
 // This is the "safety net" that checks SL/TP more frequently than the 1-minute Virtual Broker
 type BodyguardService struct {
@@ -30,9 +71,41 @@ type BodyguardService struct {
 	signalRepo   domain.SignalRepository
 	notifService NotificationService // Use same interface as VirtualBroker
 	aiService    domain.AIService    // Python Bridge for WebSocket prices
+	orderLimiter *OrderRateLimiter
+	cooldowns    *CooldownTracker
+
+	// atrProvider, when configured, scales the trailing-stop distance by
+	// TRAILING_ATR_MULT*ATR/currentPrice instead of the fixed-percent ladder.
+	// Nil disables ATR-aware trailing, falling back to the fixed ladder.
+	atrProvider domain.ATRProvider
+
+	// klineProvider, when configured, feeds CheckPositionsFast's opt-in
+	// shadow-wick and cumulative-volume take-profit checks. Nil disables both.
+	klineProvider domain.KlineProvider
+
+	// bbProvider, when configured, lets CheckPositionsFast hold an SL breach
+	// as noise (see holdSLBreachAsNoise) instead of closing immediately. Nil
+	// disables the grace period, closing on every SL breach as before.
+	bbProvider domain.BBProvider
+
+	// eventBus, when configured, is published to after a close or
+	// trailing-stop update so an SSE handler can push a live positions
+	// fragment instead of a client polling on a separate clock. Nil disables
+	// publishing (the REST/HTMX polling endpoints keep working either way).
+	eventBus *PositionEventBus
+
+	// portfolioGuard, when configured, enforces per-user exposure caps using
+	// the same positions/prices already fetched this tick. Nil disables
+	// exposure-cap enforcement entirely.
+	portfolioGuard *portfolioguard.Service
 }
 
-// NewBodyguardService creates a new BodyguardService
+// NewBodyguardService creates a new BodyguardService. atrProvider,
+// klineProvider, eventBus, portfolioGuard, and bbProvider may be nil, in
+// which case applyTrailingStop uses its fixed-percent ladder, the
+// shadow/cumulative-volume TP checks are skipped, no live-update
+// notifications are published, no exposure caps are enforced, and every SL
+// breach closes immediately, respectively.
 func NewBodyguardService(
 	positionRepo domain.PositionRepository,
 	userRepo domain.UserRepository,
@@ -40,14 +113,27 @@ func NewBodyguardService(
 	signalRepo domain.SignalRepository,
 	notifService NotificationService,
 	aiService domain.AIService,
+	orderLimiter *OrderRateLimiter,
+	atrProvider domain.ATRProvider,
+	klineProvider domain.KlineProvider,
+	eventBus *PositionEventBus,
+	portfolioGuard *portfolioguard.Service,
+	bbProvider domain.BBProvider,
 ) *BodyguardService {
 	return &BodyguardService{
-		positionRepo: positionRepo,
-		userRepo:     userRepo,
-		priceService: priceService,
-		signalRepo:   signalRepo,
-		notifService: notifService,
-		aiService:    aiService,
+		positionRepo:   positionRepo,
+		userRepo:       userRepo,
+		priceService:   priceService,
+		signalRepo:     signalRepo,
+		notifService:   notifService,
+		aiService:      aiService,
+		orderLimiter:   orderLimiter,
+		cooldowns:      NewCooldownTracker(),
+		atrProvider:    atrProvider,
+		klineProvider:  klineProvider,
+		eventBus:       eventBus,
+		portfolioGuard: portfolioGuard,
+		bbProvider:     bbProvider,
 	}
 }
 
@@ -91,7 +177,10 @@ func (s *BodyguardService) CheckPositionsFast(ctx context.Context) error {
 		}
 	}
 
-	// Check each position against fetched prices
+	// Check each position against fetched prices. klineCache is shared across
+	// positions so symbols checked by more than one position still only fetch
+	// klines once per tick.
+	klineCache := make(map[string][]domain.Kline)
 	closedCount := 0
 	for _, pos := range positions {
 		currentPrice, ok := prices[pos.Symbol]
@@ -106,6 +195,12 @@ func (s *BodyguardService) CheckPositionsFast(ctx context.Context) error {
 
 		// Check SL/TP
 		shouldClose, status, closedBy := pos.CheckSLTP(currentPrice)
+		if shouldClose && closedBy == domain.ClosedBySL && s.holdSLBreachAsNoise(ctx, pos, currentPrice) {
+			shouldClose = false
+		}
+		if !shouldClose {
+			shouldClose, status, closedBy = s.checkRichExits(ctx, pos, currentPrice, klineCache)
+		}
 		if shouldClose {
 			err := s.closePosition(ctx, pos, currentPrice, status, closedBy)
 			if err != nil {
@@ -124,6 +219,12 @@ func (s *BodyguardService) CheckPositionsFast(ctx context.Context) error {
 		log.Printf("[GUARD] Bodyguard: Closed %d position(s)", closedCount)
 	}
 
+	// Enforce per-user exposure caps using this tick's already-fetched
+	// positions/prices, rather than a separate cron job re-querying them.
+	if s.portfolioGuard != nil {
+		s.portfolioGuard.Check(ctx, positions, prices)
+	}
+
 	return nil
 }
 
@@ -137,23 +238,56 @@ func (s *BodyguardService) closePosition(ctx context.Context, pos *domain.Positi
 
 	// 2. REAL TRADING EXECUTION
 	if user.Mode == domain.ModeReal {
+		if user.InCooldown(time.Now()) {
+			log.Printf("[GUARD] Skipping REAL CLOSE for %s: user %s in exchange cooldown until %s", pos.Symbol, user.ID, user.TradeCooldownUntil)
+			return fmt.Errorf("user %s is in exchange cooldown until %s", user.ID, user.TradeCooldownUntil)
+		}
+
+		// Throttle outbound orders so many auto-trading users can't
+		// collectively trip Binance's -1003 TOO_MANY_REQUESTS ban
+		if err := s.orderLimiter.Wait(ctx); err != nil {
+			return fmt.Errorf("order rate limiter: %w", err)
+		}
+
 		// Determine Closing Side (Opposite of Position Side)
 		closeSide := "SELL"
 		if pos.Side == "SHORT" { // Assuming Side is stored as "LONG" or "SHORT"
 			closeSide = "BUY"
 		}
 
-		// Execute Close via Python Engine -> Binance
-		// Retry logic could be added here, but bodyguard retries every 10s anyway
-		res, err := s.aiService.ExecuteClose(ctx, pos.Symbol, closeSide, pos.Size)
+		// Execute Close via Python Engine -> Binance. ExecuteClose retries
+		// transient failures itself (jittered backoff, idempotency-key-safe),
+		// so bodyguard's own 10s polling loop is the only outer retry.
+		res, err := s.aiService.ExecuteClose(ctx, &domain.CloseParams{
+			Symbol:       pos.Symbol,
+			Side:         closeSide,
+			PositionSide: pos.PositionSide,
+			Quantity:     pos.Size,
+			APIKey:       user.BinanceAPIKey,
+			APISecret:    user.BinanceAPISecret,
+		})
 		if err != nil {
+			if isExchangeThrottled(err) {
+				until := s.cooldowns.Escalate(user.ID, time.Now())
+				if cdErr := s.userRepo.SetTradeCooldownUntil(ctx, user.ID, until); cdErr != nil {
+					log.Printf("[WARN] Bodyguard: failed to persist trade cooldown for %s: %v", user.ID, cdErr)
+				}
+				log.Printf("[GUARD] Bodyguard: exchange throttled user %s, cooling down until %s", user.ID, until)
+			}
 			log.Printf("[ERR] Bodyguard: FAILED to execute REAL CLOSE for %s: %v", pos.Symbol, err)
+			if s.notifService != nil {
+				s.notifService.Emit(ctx, notification.TopicRealTradeFailed, notification.Payload{Symbol: pos.Symbol, Side: closeSide, Detail: err.Error()})
+			}
 			return err // Return error so Bodyguard will retry in next cycle
 		}
+		s.cooldowns.Reset(user.ID)
 
 		// Use ACTUAL execution price from Binance
 		exitPrice = res.AvgPrice
 		log.Printf("[GUARD] REAL EXECUTION SUCCESS: %s Closed @ %.4f", pos.Symbol, exitPrice)
+		if s.notifService != nil {
+			s.notifService.Emit(ctx, notification.TopicRealTradeExecuted, notification.Payload{Symbol: pos.Symbol, Side: closeSide, Price: exitPrice})
+		}
 	}
 
 	// 3. Calculate PnL Stats (Valid for both Real & Paper for reporting)
@@ -230,64 +364,235 @@ func (s *BodyguardService) closePosition(ctx context.Context, pos *domain.Positi
 	}()
 
 	// Send notification
-	if s.notifService != nil && sig != nil {
-		sig.ReviewResult = &result
-		if err := s.notifService.SendReview(*sig, &pnl); err != nil {
+	if s.notifService != nil {
+		topic := notification.TopicPositionClosedWin
+		if result == "LOSS" {
+			topic = notification.TopicPositionClosedLoss
+		}
+		if sig != nil {
+			sig.ReviewResult = &result
+		}
+		payload := notification.Payload{Signal: sig, Symbol: pos.Symbol, Side: pos.Side, Price: exitPrice, PnL: &pnl}
+		if err := s.notifService.Emit(ctx, topic, payload); err != nil {
 			log.Printf("[WARN] Failed to send notification: %v", err)
 		}
 	}
 
+	if s.eventBus != nil {
+		s.eventBus.Publish(pos.UserID)
+	}
+
 	return nil
 }
 
+// checkRichExits evaluates the opt-in shadow-wick and cumulative-volume
+// take-profit exits (SHADOW_TP_RATIO/SHADOW_TP_INTERVAL/CUMVOL_TP_WINDOW/
+// CUMVOL_TP_MIN_QUOTE, overridable per-position), on top of the plain
+// price-based CheckSLTP. klineCache is shared across positions within one
+// CheckPositionsFast tick so the same symbol/interval/window only fetches
+// klines once.
+func (s *BodyguardService) checkRichExits(ctx context.Context, pos *domain.Position, currentPrice float64, klineCache map[string][]domain.Kline) (shouldClose bool, status, closedBy string) {
+	if s.klineProvider == nil {
+		return false, "", ""
+	}
+
+	interval := getEnvString("SHADOW_TP_INTERVAL", "5m")
+	if pos.ShadowTPInterval != nil {
+		interval = *pos.ShadowTPInterval
+	}
+
+	shadowRatio := getEnvFloat("SHADOW_TP_RATIO", 0)
+	if pos.ShadowTPRatio != nil {
+		shadowRatio = *pos.ShadowTPRatio
+	}
+	if shadowRatio > 0 {
+		candles, err := s.fetchKlinesCached(ctx, klineCache, pos.Symbol, interval, 2)
+		if err != nil {
+			log.Printf("[WARN] Bodyguard: shadow TP kline fetch failed for %s: %v", pos.Symbol, err)
+		} else if len(candles) > 0 {
+			latest := candles[len(candles)-1]
+			if close, closedBy := pos.CheckShadowTP(latest, shadowRatio); close {
+				return true, domain.StatusClosedWin, closedBy
+			}
+		}
+	}
+
+	cumVolWindow := int(getEnvFloat("CUMVOL_TP_WINDOW", 0))
+	if pos.CumVolTPWindow != nil {
+		cumVolWindow = *pos.CumVolTPWindow
+	}
+	cumVolMinQuote := getEnvFloat("CUMVOL_TP_MIN_QUOTE", 0)
+	if pos.CumVolTPMinQuote != nil {
+		cumVolMinQuote = *pos.CumVolTPMinQuote
+	}
+	if cumVolWindow > 0 && cumVolMinQuote > 0 {
+		candles, err := s.fetchKlinesCached(ctx, klineCache, pos.Symbol, interval, cumVolWindow)
+		if err != nil {
+			log.Printf("[WARN] Bodyguard: cumulative-volume TP kline fetch failed for %s: %v", pos.Symbol, err)
+		} else if close, closedBy := pos.CheckCumulativeVolumeTP(candles, cumVolMinQuote, currentPrice); close {
+			return true, domain.StatusClosedWin, closedBy
+		}
+	}
+
+	return false, "", ""
+}
+
+// fetchKlinesCached fetches klines for symbol/interval/limit, reusing
+// klineCache across positions that share the same tick's key so bulk checks
+// across many positions don't refetch the same candles repeatedly.
+func (s *BodyguardService) fetchKlinesCached(ctx context.Context, klineCache map[string][]domain.Kline, symbol, interval string, limit int) ([]domain.Kline, error) {
+	key := fmt.Sprintf("%s|%s|%d", symbol, interval, limit)
+	if cached, ok := klineCache[key]; ok {
+		return cached, nil
+	}
+
+	klines, err := s.klineProvider.GetKlines(ctx, symbol, interval, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	klineCache[key] = klines
+	return klines, nil
+}
+
+// holdSLBreachAsNoise consults the symbol's current Bollinger Bands
+// (BB_INTERVAL/BB_WINDOW/BB_K, defaults 5m/20/2.0) when bbProvider is
+// configured, treating an SL breach as a noise wick -- and holding the
+// position instead of closing -- while price is still inside the band. Each
+// held tick counts against BB_GRACE_TICKS (default 3); once that's spent,
+// the breach is forced through even if price is still inside the band.
+func (s *BodyguardService) holdSLBreachAsNoise(ctx context.Context, pos *domain.Position, currentPrice float64) bool {
+	if s.bbProvider == nil {
+		return false
+	}
+
+	interval := getEnvString("BB_INTERVAL", "5m")
+	window := int(getEnvFloat("BB_WINDOW", 20))
+	k := getEnvFloat("BB_K", 2.0)
+	graceTicks := int(getEnvFloat("BB_GRACE_TICKS", 3))
+
+	upper, _, lower, err := s.bbProvider.GetBands(ctx, pos.Symbol, interval, window, k)
+	if err != nil {
+		log.Printf("[WARN] Bodyguard: Bollinger Band lookup failed for %s, letting SL breach through: %v", pos.Symbol, err)
+		return false
+	}
+
+	hold := pos.EvaluateSLGraceBreach(currentPrice, upper, lower, graceTicks)
+
+	if err := s.positionRepo.UpdateSLGraceCount(ctx, pos.ID, pos.SLGraceCount); err != nil {
+		log.Printf("[WARN] Bodyguard: failed to persist SL grace count for %s: %v", pos.Symbol, err)
+	}
+
+	if hold {
+		log.Printf("[GUARD] Bodyguard: held SL breach for %s as band noise (%d/%d grace ticks used)", pos.Symbol, pos.SLGraceCount, graceTicks)
+	}
+
+	return hold
+}
+
 // GetBulkPrices fetches all prices in a single API call (exported for potential reuse)
 func (s *BodyguardService) GetBulkPrices(ctx context.Context, symbols []string) (map[string]float64, error) {
 	return s.priceService.FetchRealTimePrices(ctx, symbols)
 }
 
-// applyTrailingStop updates SL dynamically to lock in profits
+// defaultTrailingActivatePcts and defaultTrailingCallbackPcts are the
+// fallback activation/callback ladder when TRAILING_ACTIVATE_PCTS /
+// TRAILING_CALLBACK_PCTS are unset, ordered ascending by activation
+var (
+	defaultTrailingActivatePcts = []float64{0.3, 1.0, 2.5}
+	defaultTrailingCallbackPcts = []float64{0.15, 0.5, 1.0}
+)
+
+// applyTrailingStop ratchets SL tighter as a position's PnL% climbs through a
+// configured ladder of activation/callback tiers (TRAILING_ACTIVATE_PCTS /
+// TRAILING_CALLBACK_PCTS, ascending by activation). The tier only ever
+// increases, so a price retrace never widens the stop back out.
 func (s *BodyguardService) applyTrailingStop(ctx context.Context, pos *domain.Position, currentPrice float64) {
-	// 1. Activation Check: Must be in Profit > configured % (default 1.0%)
-	activationThreshold := getEnvFloat("TRAILING_ACTIVATE_PCT", 1.0)
+	activatePcts := getEnvFloatList("TRAILING_ACTIVATE_PCTS", defaultTrailingActivatePcts)
+	callbackPcts := getEnvFloatList("TRAILING_CALLBACK_PCTS", defaultTrailingCallbackPcts)
+
+	if len(activatePcts) == 0 || len(activatePcts) != len(callbackPcts) {
+		log.Printf("[WARN] Bodyguard: TRAILING_ACTIVATE_PCTS/TRAILING_CALLBACK_PCTS misconfigured, skipping trailing stop for %s", pos.Symbol)
+		return
+	}
+
 	pnlPct := pos.CalculatePnLPercent(currentPrice)
 
-	if pnlPct < activationThreshold {
+	// Find the highest tier whose activation threshold has been crossed
+	tier := -1
+	for i, activation := range activatePcts {
+		if pnlPct >= activation {
+			tier = i
+		}
+	}
+	if tier < 0 {
 		return
 	}
 
-	// 2. Trailing Distance: configured % from Current Price (default 0.5%)
-	trailingDistancePct := getEnvFloat("TRAILING_DISTANCE_PCT", 0.5)
+	// Tier only ratchets forward, never back
+	if tier < pos.BodyguardTrailingTier {
+		tier = pos.BodyguardTrailingTier
+	}
+
+	callbackPct := callbackPcts[tier]
+
+	// When an ATRProvider is configured, scale the trailing distance by
+	// volatility instead of using the tier's fixed percent: a high-vol
+	// symbol gets more room, a low-vol one gets ratcheted in tighter.
+	if s.atrProvider != nil {
+		atr, err := s.atrProvider.GetATR(ctx, pos.Symbol, "15m", 14)
+		if err != nil {
+			log.Printf("[WARN] Bodyguard: ATR lookup failed for %s, using fixed-percent trailing distance: %v", pos.Symbol, err)
+		} else {
+			atrMult := getEnvFloat("TRAILING_ATR_MULT", 2.0)
+			callbackPct = atrMult * atr / currentPrice * 100.0
+		}
+	}
 
 	var newSL float64
 	updated := false
 
 	if pos.IsLong() {
-		// LONG: New SL = Price * (1 - 0.5%)
-		// Move SL UP
-		trailPrice := currentPrice * (1.0 - (trailingDistancePct / 100.0))
+		trailPrice := currentPrice * (1.0 - (callbackPct / 100.0))
 		if trailPrice > pos.SLPrice {
 			newSL = trailPrice
 			updated = true
 		}
 	} else {
-		// SHORT: New SL = Price * (1 + 0.5%)
-		// Move SL DOWN
-		trailPrice := currentPrice * (1.0 + (trailingDistancePct / 100.0))
-		// For SHORT, SL is above price. We want to lower it.
+		trailPrice := currentPrice * (1.0 + (callbackPct / 100.0))
 		if trailPrice < pos.SLPrice {
 			newSL = trailPrice
 			updated = true
 		}
 	}
 
-	if updated {
-		pos.SLPrice = newSL
-		// Update SL in DB
-		if err := s.positionRepo.Update(ctx, pos); err != nil {
-			log.Printf("[WARN] Failed to update Trailing Stop for %s: %v", pos.Symbol, err)
-		} else {
-			log.Printf("[TRAIL] Trailing Stop Updated for %s: New SL %.4f (Price %.4f, PnL %.2f%%)",
-				pos.Symbol, pos.SLPrice, currentPrice, pnlPct)
-		}
+	if !updated && tier == pos.BodyguardTrailingTier {
+		return
+	}
+
+	if !updated {
+		newSL = pos.SLPrice
+	}
+
+	tierAdvanced := tier > pos.BodyguardTrailingTier
+
+	if err := s.positionRepo.UpdateBodyguardTrailingState(ctx, pos.ID, tier, newSL); err != nil {
+		log.Printf("[WARN] Failed to update Trailing Stop for %s: %v", pos.Symbol, err)
+		return
+	}
+
+	pos.SLPrice = newSL
+	pos.BodyguardTrailingTier = tier
+
+	if tierAdvanced {
+		log.Printf("[TRAIL] Tier %d active for %s -> tighter stop: New SL %.4f (Price %.4f, PnL %.2f%%)",
+			tier, pos.Symbol, pos.SLPrice, currentPrice, pnlPct)
+	} else {
+		log.Printf("[TRAIL] Trailing Stop Updated for %s: New SL %.4f (Price %.4f, PnL %.2f%%)",
+			pos.Symbol, pos.SLPrice, currentPrice, pnlPct)
+	}
+
+	if s.eventBus != nil {
+		s.eventBus.Publish(pos.UserID)
 	}
 }
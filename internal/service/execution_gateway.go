@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/time/rate"
+
+	"neurotrade/internal/domain"
+	"neurotrade/internal/notification"
+	"neurotrade/internal/reliability"
+)
+
+const (
+	// gatewayOrderRPS/gatewayOrderBurst match Binance Futures' documented
+	// per-second order-placement cap (mirrors OrderRateLimiter's numbers -
+	// kept separate since ExecutionGateway owns its own retry/backoff loop
+	// around this limiter instead of a bare Wait before the call).
+	gatewayOrderRPS   = 5
+	gatewayOrderBurst = 2
+
+	// gatewayWeightPerMinute is Binance Futures' REST weight budget; the
+	// limiter refills continuously at this rate per minute so a burst of
+	// closes can't spend a whole minute's budget in one tick.
+	gatewayWeightPerMinute = 1200
+
+	// gatewayMaxAttempts bounds ExecuteClose's retry loop for a transient
+	// (5xx / -1021 timestamp) failure before it's surfaced as a hard error.
+	gatewayMaxAttempts = 3
+)
+
+// mbxStatusPattern matches the "status=5xx" shape python_bridge.go's errors
+// wrap a failed HTTP response in.
+var mbxStatusPattern = regexp.MustCompile(`status=5\d\d`)
+
+// AIExecutor is the narrow subset of domain.AIService ExecutionGateway
+// wraps: the order-placing call REAL-mode flows retry/throttle around.
+type AIExecutor interface {
+	ExecuteClose(ctx context.Context, params *domain.CloseParams) (*domain.ExecutionResult, error)
+}
+
+// ExecutionGateway wraps an AIExecutor with a token-bucket order limiter and
+// a weight-aware REST limiter (Binance Futures' 5 orders/sec and 1200
+// weight/min caps), retrying a transient failure with jittered exponential
+// backoff and failing fast on one retrying can't fix. A failure that
+// survives every retry is surfaced via notifService so a position never
+// silently de-syncs from the exchange.
+type ExecutionGateway struct {
+	executor      AIExecutor
+	orderLimiter  *rate.Limiter
+	weightLimiter *rate.Limiter
+	notifService  NotificationService
+}
+
+// NewExecutionGateway creates an ExecutionGateway around executor.
+// notifService may be nil, in which case an exhausted-retry failure is only
+// logged.
+func NewExecutionGateway(executor AIExecutor, notifService NotificationService) *ExecutionGateway {
+	return &ExecutionGateway{
+		executor:      executor,
+		orderLimiter:  rate.NewLimiter(rate.Limit(gatewayOrderRPS), gatewayOrderBurst),
+		weightLimiter: rate.NewLimiter(rate.Limit(gatewayWeightPerMinute)/60, gatewayWeightPerMinute),
+		notifService:  notifService,
+	}
+}
+
+// ExecuteClose waits for both the order and weight limiters, then calls the
+// wrapped executor, retrying a transient failure with jittered exponential
+// backoff for up to gatewayMaxAttempts attempts in all. A -2010/-2011 error
+// (insufficient balance / unknown order) fails on the first attempt since
+// retrying it can't change the outcome. Exhausting retries emits
+// TopicRealTradeFailed before returning the last error. params.PositionSide
+// is domain.PositionSideBoth/Long/Short, forwarded to executor unchanged so a
+// hedge-mode close lands under the correct side. params.IdempotencyKey is
+// generated here once (UUIDv7) if left empty and sent with every attempt,
+// so these retries resolve to the same order; only a caller-supplied key
+// also carries across process restarts.
+func (g *ExecutionGateway) ExecuteClose(ctx context.Context, params *domain.CloseParams) (*domain.ExecutionResult, error) {
+	if err := g.orderLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("execution gateway: order limiter: %w", err)
+	}
+	if err := g.weightLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("execution gateway: weight limiter: %w", err)
+	}
+
+	if params.IdempotencyKey == "" {
+		key, err := uuid.NewV7()
+		if err != nil {
+			return nil, fmt.Errorf("execution gateway: failed to generate idempotency key: %w", err)
+		}
+		withKey := *params
+		withKey.IdempotencyKey = key.String()
+		params = &withKey
+	}
+
+	cfg := reliability.RetryConfig{
+		MaxAttempts: gatewayMaxAttempts,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Retryable:   isRetryableExecutionError,
+	}
+
+	var result *domain.ExecutionResult
+	err := reliability.Do(ctx, cfg, func() error {
+		res, err := g.executor.ExecuteClose(ctx, params)
+		if err != nil {
+			return err
+		}
+		g.adjustWeightBudget(res)
+		result = res
+		return nil
+	})
+
+	if err != nil {
+		log.Printf("[ERR] ExecutionGateway: ExecuteClose failed for %s after retries: %v", params.Symbol, err)
+		if g.notifService != nil {
+			payload := notification.Payload{Symbol: params.Symbol, Side: params.Side, Detail: err.Error()}
+			if notifyErr := g.notifService.Emit(ctx, notification.TopicRealTradeFailed, payload); notifyErr != nil {
+				log.Printf("[WARN] ExecutionGateway: failed to send failure notification for %s: %v", params.Symbol, notifyErr)
+			}
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// adjustWeightBudget narrows the weight limiter's burst toward res's
+// remaining headroom for the current minute, so a run of calls that already
+// used most of the budget throttles harder instead of waiting for a 418.
+// A zero UsedWeight1m (Python Engine didn't forward the header) leaves the
+// limiter at its steady-state budget.
+func (g *ExecutionGateway) adjustWeightBudget(res *domain.ExecutionResult) {
+	if res == nil || res.UsedWeight1m <= 0 {
+		return
+	}
+
+	remaining := gatewayWeightPerMinute - res.UsedWeight1m
+	if remaining < 1 {
+		remaining = 1
+	}
+	g.weightLimiter.SetBurst(remaining)
+}
+
+// isRetryableExecutionError reports whether err looks like a transient
+// Binance failure worth retrying: a 5xx from the Python Engine's proxied
+// response, or -1021 INVALID_TIMESTAMP from local/exchange clock drift.
+// -2010/-2011 (insufficient balance / unknown order) fail fast since no
+// amount of retrying changes the outcome.
+func isRetryableExecutionError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := err.Error()
+	if strings.Contains(msg, "-2010") || strings.Contains(msg, "-2011") {
+		return false
+	}
+
+	return strings.Contains(msg, "-1021") || mbxStatusPattern.MatchString(msg)
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"neurotrade/internal/domain"
+)
+
+// ExitDecision is the outcome an ExitRule reaches for a signal at a point in
+// time. ReviewService evaluates a preset's configured chain of ExitRules in
+// order and takes the first non-HOLD decision.
+type ExitDecision string
+
+const (
+	ExitHold ExitDecision = "HOLD"
+	ExitWin  ExitDecision = "WIN"
+	ExitLoss ExitDecision = "LOSS"
+)
+
+// ExitRule decides whether a reviewed signal should be graded WIN or LOSS at
+// currentPrice. priceHistory is a recent window of klines for signal.Symbol;
+// rules that don't need it (FixedTPSLRule, ROIStopRule) just ignore it.
+type ExitRule interface {
+	Evaluate(ctx context.Context, signal *domain.Signal, currentPrice float64, priceHistory []domain.Kline) (ExitDecision, error)
+}
+
+// FixedTPSLRule closes a signal once currentPrice crosses its own TP or SL
+// price -- the check ReviewService ran unconditionally before the rule chain
+// existed, and the default chain when a preset configures none.
+type FixedTPSLRule struct{}
+
+// NewFixedTPSLRule creates a new FixedTPSLRule
+func NewFixedTPSLRule() *FixedTPSLRule {
+	return &FixedTPSLRule{}
+}
+
+// Evaluate implements ExitRule
+func (r *FixedTPSLRule) Evaluate(_ context.Context, signal *domain.Signal, currentPrice float64, _ []domain.Kline) (ExitDecision, error) {
+	switch signal.Type {
+	case domain.SideLong:
+		if currentPrice >= signal.TPPrice {
+			return ExitWin, nil
+		}
+		if currentPrice <= signal.SLPrice {
+			return ExitLoss, nil
+		}
+	case domain.SideShort:
+		if currentPrice <= signal.TPPrice {
+			return ExitWin, nil
+		}
+		if currentPrice >= signal.SLPrice {
+			return ExitLoss, nil
+		}
+	}
+
+	return ExitHold, nil
+}
+
+// ROIStopRule closes a signal once its floating PnL crosses a fixed ROI
+// band, independent of its TP/SL prices -- for presets that want to grade on
+// a percentage move rather than the AI's own suggested TP/SL.
+type ROIStopRule struct {
+	roiTakeProfitPercentage float64
+	roiStopLossPercentage   float64
+}
+
+// NewROIStopRule creates a new ROIStopRule
+func NewROIStopRule(roiTakeProfitPercentage, roiStopLossPercentage float64) *ROIStopRule {
+	return &ROIStopRule{
+		roiTakeProfitPercentage: roiTakeProfitPercentage,
+		roiStopLossPercentage:   roiStopLossPercentage,
+	}
+}
+
+// Evaluate implements ExitRule
+func (r *ROIStopRule) Evaluate(_ context.Context, signal *domain.Signal, currentPrice float64, _ []domain.Kline) (ExitDecision, error) {
+	pnlPercent := SignalPnLPercent(signal, currentPrice)
+
+	if pnlPercent >= r.roiTakeProfitPercentage {
+		return ExitWin, nil
+	}
+	if pnlPercent <= -r.roiStopLossPercentage {
+		return ExitLoss, nil
+	}
+
+	return ExitHold, nil
+}
+
+// EMAStopRule closes a LONG signal once currentPrice drops below
+// EMA(window) on interval (mirrored for SHORT: closes once price rises above
+// it), treating a break of trend as a loss regardless of the signal's own
+// floating PnL.
+type EMAStopRule struct {
+	priceService *MarketPriceService
+	window       int
+	interval     string
+}
+
+// NewEMAStopRule creates a new EMAStopRule
+func NewEMAStopRule(priceService *MarketPriceService, window int, interval string) *EMAStopRule {
+	return &EMAStopRule{priceService: priceService, window: window, interval: interval}
+}
+
+// Evaluate implements ExitRule
+func (r *EMAStopRule) Evaluate(ctx context.Context, signal *domain.Signal, currentPrice float64, _ []domain.Kline) (ExitDecision, error) {
+	emaValue, err := r.priceService.FetchIndicator(ctx, signal.Symbol, r.interval, r.window, IndicatorEMA)
+	if err != nil {
+		return ExitHold, fmt.Errorf("EMAStopRule: %w", err)
+	}
+
+	switch signal.Type {
+	case domain.SideLong:
+		if currentPrice < emaValue {
+			return ExitLoss, nil
+		}
+	case domain.SideShort:
+		if currentPrice > emaValue {
+			return ExitLoss, nil
+		}
+	}
+
+	return ExitHold, nil
+}
+
+// LowerShadowRule closes a signal as a WIN once the most recent bar's lower
+// shadow -- (close-low)/close -- exceeds lowerShadowRatio, a wick-rejection
+// pattern worth taking profit on aggressively rather than waiting for TP/SL
+// or the ROI band.
+type LowerShadowRule struct {
+	lowerShadowRatio float64
+}
+
+// NewLowerShadowRule creates a new LowerShadowRule
+func NewLowerShadowRule(lowerShadowRatio float64) *LowerShadowRule {
+	return &LowerShadowRule{lowerShadowRatio: lowerShadowRatio}
+}
+
+// Evaluate implements ExitRule
+func (r *LowerShadowRule) Evaluate(_ context.Context, _ *domain.Signal, _ float64, priceHistory []domain.Kline) (ExitDecision, error) {
+	if len(priceHistory) == 0 {
+		return ExitHold, nil
+	}
+
+	bar := priceHistory[len(priceHistory)-1]
+	if bar.Close <= 0 {
+		return ExitHold, nil
+	}
+
+	shadowRatio := (bar.Close - bar.Low) / bar.Close
+	if shadowRatio > r.lowerShadowRatio {
+		return ExitWin, nil
+	}
+
+	return ExitHold, nil
+}
+
+// SignalPnLPercent computes signal's floating PnL percentage at
+// currentPrice, shared by ReviewService.calculateFloatingPnL and
+// ROIStopRule so both use the same sign convention.
+func SignalPnLPercent(signal *domain.Signal, currentPrice float64) float64 {
+	if signal.Type == domain.SideShort {
+		return ((signal.EntryPrice - currentPrice) / signal.EntryPrice) * 100
+	}
+	return ((currentPrice - signal.EntryPrice) / signal.EntryPrice) * 100
+}
+
+// DefaultExitRuleChain is the chain ReviewService falls back to when a
+// preset configures no exit_rules: the plain TP/SL check it always ran
+// before the rule chain existed.
+func DefaultExitRuleChain() []ExitRule {
+	return []ExitRule{NewFixedTPSLRule()}
+}
+
+// exitRuleSpec is one entry of a preset's exit_rules JSON array.
+type exitRuleSpec struct {
+	Type                    string  `json:"type"`
+	ROITakeProfitPercentage float64 `json:"roi_take_profit_percentage"`
+	ROIStopLossPercentage   float64 `json:"roi_stop_loss_percentage"`
+	Window                  int     `json:"window"`
+	Interval                string  `json:"interval"`
+	LowerShadowRatio        float64 `json:"lower_shadow_ratio"`
+}
+
+// ExitRule type identifiers used in a preset's exit_rules JSON
+const (
+	ExitRuleTypeFixedTPSL   = "FIXED_TPSL"
+	ExitRuleTypeROIStop     = "ROI_STOP"
+	ExitRuleTypeEMAStop     = "EMA_STOP"
+	ExitRuleTypeLowerShadow = "LOWER_SHADOW"
+)
+
+// ParseExitRuleChain decodes a strategy_presets.exit_rules JSON array into
+// the ordered chain of ExitRules it configures. An empty/nil rulesJSON
+// yields DefaultExitRuleChain so a preset with no exit_rules column keeps
+// the original fixed TP/SL behavior.
+func ParseExitRuleChain(priceService *MarketPriceService, rulesJSON []byte) ([]ExitRule, error) {
+	if len(rulesJSON) == 0 {
+		return DefaultExitRuleChain(), nil
+	}
+
+	var specs []exitRuleSpec
+	if err := json.Unmarshal(rulesJSON, &specs); err != nil {
+		return nil, fmt.Errorf("failed to parse exit_rules: %w", err)
+	}
+	if len(specs) == 0 {
+		return DefaultExitRuleChain(), nil
+	}
+
+	chain := make([]ExitRule, 0, len(specs))
+	for _, spec := range specs {
+		switch spec.Type {
+		case ExitRuleTypeFixedTPSL:
+			chain = append(chain, NewFixedTPSLRule())
+		case ExitRuleTypeROIStop:
+			chain = append(chain, NewROIStopRule(spec.ROITakeProfitPercentage, spec.ROIStopLossPercentage))
+		case ExitRuleTypeEMAStop:
+			chain = append(chain, NewEMAStopRule(priceService, spec.Window, spec.Interval))
+		case ExitRuleTypeLowerShadow:
+			chain = append(chain, NewLowerShadowRule(spec.LowerShadowRatio))
+		default:
+			return nil, fmt.Errorf("unknown exit rule type: %s", spec.Type)
+		}
+	}
+
+	return chain, nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"neurotrade/internal/domain"
+)
+
+// BinanceFundingRateProvider implements domain.FundingRateProvider against
+// Binance Futures' funding rate history and premium index endpoints, using
+// the same proxy-URL-from-env convention as MarketPriceService.
+type BinanceFundingRateProvider struct {
+	httpClient      *http.Client
+	fundingRateURL  string
+	premiumIndexURL string
+}
+
+// NewBinanceFundingRateProvider creates a BinanceFundingRateProvider reading
+// its upstream URLs from BINANCE_FUNDING_RATE_URL/BINANCE_PREMIUM_INDEX_URL.
+func NewBinanceFundingRateProvider() *BinanceFundingRateProvider {
+	return &BinanceFundingRateProvider{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		fundingRateURL:  os.Getenv("BINANCE_FUNDING_RATE_URL"),
+		premiumIndexURL: os.Getenv("BINANCE_PREMIUM_INDEX_URL"),
+	}
+}
+
+// GetFundingRates implements domain.FundingRateProvider, fetching every
+// settled funding payment for symbol in [since, until).
+func (p *BinanceFundingRateProvider) GetFundingRates(ctx context.Context, symbol string, since, until time.Time) ([]domain.FundingRate, error) {
+	if p.fundingRateURL == "" {
+		return nil, fmt.Errorf("BINANCE_FUNDING_RATE_URL environment variable is not set")
+	}
+
+	norm := strings.ReplaceAll(strings.ToUpper(symbol), "/", "")
+	reqURL := p.fundingRateURL + "?" + url.Values{
+		"symbol":    {norm},
+		"startTime": {strconv.FormatInt(since.UnixMilli(), 10)},
+		"endTime":   {strconv.FormatInt(until.UnixMilli(), 10)},
+	}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch funding rates from Binance: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Binance API error: status=%d, body=%s", resp.StatusCode, string(body))
+	}
+
+	var raw []struct {
+		Symbol      string `json:"symbol"`
+		FundingRate string `json:"fundingRate"`
+		FundingTime int64  `json:"fundingTime"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	rates := make([]domain.FundingRate, 0, len(raw))
+	for _, r := range raw {
+		rate, err := strconv.ParseFloat(r.FundingRate, 64)
+		if err != nil {
+			continue
+		}
+		rates = append(rates, domain.FundingRate{
+			Symbol:    r.Symbol,
+			Rate:      rate,
+			FundingAt: time.UnixMilli(r.FundingTime),
+		})
+	}
+
+	return rates, nil
+}
+
+// GetCurrentFundingRate implements domain.FundingRateProvider, reading the
+// premium index's realtime funding rate estimate for a window that hasn't
+// settled yet.
+func (p *BinanceFundingRateProvider) GetCurrentFundingRate(ctx context.Context, symbol string) (float64, error) {
+	if p.premiumIndexURL == "" {
+		return 0, fmt.Errorf("BINANCE_PREMIUM_INDEX_URL environment variable is not set")
+	}
+
+	norm := strings.ReplaceAll(strings.ToUpper(symbol), "/", "")
+	reqURL := p.premiumIndexURL + "?" + url.Values{"symbol": {norm}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch premium index from Binance: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("Binance API error: status=%d, body=%s", resp.StatusCode, string(body))
+	}
+
+	var raw struct {
+		LastFundingRate string `json:"lastFundingRate"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	rate, err := strconv.ParseFloat(raw.LastFundingRate, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse funding rate: %w", err)
+	}
+
+	return rate, nil
+}
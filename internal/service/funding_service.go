@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"neurotrade/internal/domain"
+)
+
+// fundingWindowHoursUTC are the UTC hours Binance perpetual futures settle
+// funding on: every 8 hours starting at midnight.
+var fundingWindowHoursUTC = []int{0, 8, 16}
+
+// FundingService accrues realized funding payments into Position.FundingPaid
+// for every 00:00/08:00/16:00 UTC funding window crossed while a position is
+// open, so calculateNetPnL's NetPnL = Gross - Fees - FundingPaid reflects the
+// full cost of holding a perpetual position, not just entry/exit fees.
+type FundingService struct {
+	fundingProvider domain.FundingRateProvider
+	positionRepo    domain.PositionRepository
+}
+
+// NewFundingService creates a FundingService backed by fundingProvider and
+// positionRepo. A nil fundingProvider disables accrual: AccrueDue becomes a
+// no-op, leaving FundingPaid at 0 (the pre-existing behavior).
+func NewFundingService(fundingProvider domain.FundingRateProvider, positionRepo domain.PositionRepository) *FundingService {
+	return &FundingService{fundingProvider: fundingProvider, positionRepo: positionRepo}
+}
+
+// AccrueDue walks every funding window crossed since each position's last
+// accrual (or CreatedAt, if never accrued before) up to now, adding
+// Size*EntryPrice*fundingRate*sign(side) to FundingPaid for each one and
+// persisting the running total via UpdateFundingState. The notional is the
+// entry notional; the mark price at each funding time is not used. A
+// symbol whose funding rates fail to fetch is skipped rather than returning
+// an error, since a funding miss shouldn't block the caller's TP/SL
+// evaluation for this tick.
+func (s *FundingService) AccrueDue(ctx context.Context, positions []*domain.Position) {
+	if s.fundingProvider == nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	for _, position := range positions {
+		since := position.CreatedAt.UTC()
+		if position.LastFundingAccrualAt != nil {
+			since = position.LastFundingAccrualAt.UTC()
+		}
+
+		windows := fundingWindowsBetween(since, now)
+		if len(windows) == 0 {
+			continue
+		}
+
+		rates, err := s.fundingProvider.GetFundingRates(ctx, position.Symbol, since, now)
+		if err != nil {
+			log.Printf("[WARN] FundingService: failed to fetch funding rates for %s: %v", position.Symbol, err)
+			continue
+		}
+
+		sign := 1.0
+		if !position.IsLong() {
+			sign = -1.0
+		}
+		notional := position.Size * position.EntryPrice
+
+		accrued := position.FundingPaid
+		lastWindow := since
+		accruedAny := false
+		for _, window := range windows {
+			rate, ok := fundingRateAt(rates, window)
+			if !ok {
+				continue
+			}
+			accrued += notional * rate * sign
+			lastWindow = window
+			accruedAny = true
+		}
+
+		if !accruedAny {
+			continue
+		}
+
+		position.FundingPaid = accrued
+		position.LastFundingAccrualAt = &lastWindow
+		if err := s.positionRepo.UpdateFundingState(ctx, position.ID, accrued, lastWindow); err != nil {
+			log.Printf("[WARN] FundingService: failed to persist funding accrual for %s: %v", position.Symbol, err)
+		}
+	}
+}
+
+// fundingWindowsBetween returns every 00:00/08:00/16:00 UTC boundary in
+// (since, until], oldest first.
+func fundingWindowsBetween(since, until time.Time) []time.Time {
+	var windows []time.Time
+	day := time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, time.UTC)
+	for !day.After(until) {
+		for _, hour := range fundingWindowHoursUTC {
+			window := day.Add(time.Duration(hour) * time.Hour)
+			if window.After(since) && !window.After(until) {
+				windows = append(windows, window)
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return windows
+}
+
+// fundingRateAt finds the rate whose FundingAt matches window, within a
+// minute to tolerate provider rounding.
+func fundingRateAt(rates []domain.FundingRate, window time.Time) (float64, bool) {
+	for _, r := range rates {
+		diff := r.FundingAt.Sub(window)
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff < time.Minute {
+			return r.Rate, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"neurotrade/configs"
+	"neurotrade/internal/domain"
+	"neurotrade/internal/exchange/binance"
+	"neurotrade/internal/reliability"
+)
+
+// HedgeService opens a smaller opposite-side live position on a separate
+// hedge exchange account whenever a hedge-enabled user opens a paper
+// position, modeled on bbgo's cross-exchange market-making strategy: one
+// "maker" leg (the paper trade) and one "hedge" leg (hedgeExchange) kept
+// roughly proportional by OpenHedge/CloseHedge on the happy path and a
+// periodic Reconcile pass for exchange-side drift.
+type HedgeService struct {
+	hedgeExchange binance.FuturesExchange
+	hedgeRepo     domain.HedgePositionRepository
+	cfg           configs.HedgeConfig
+	breaker       *reliability.CircuitBreaker
+	mu            sync.Mutex
+}
+
+// NewHedgeService creates a new HedgeService. hedgeExchange may be nil if
+// hedge mode isn't configured; every method becomes a no-op in that case
+// (OpenHedge logs the skip, CloseHedge and Reconcile return nil silently),
+// the same posture futuresExchange/riskModel take
+// elsewhere for optional REAL-mode dependencies.
+func NewHedgeService(hedgeExchange binance.FuturesExchange, hedgeRepo domain.HedgePositionRepository, cfg configs.HedgeConfig) *HedgeService {
+	return &HedgeService{
+		hedgeExchange: hedgeExchange,
+		hedgeRepo:     hedgeRepo,
+		cfg:           cfg,
+		breaker:       reliability.NewCircuitBreaker("hedge_exchange", reliability.DefaultBreakerConfig()),
+	}
+}
+
+// hedgeSymbol maps a paper-traded symbol to its hedge-exchange ticker,
+// falling back to the same symbol if no mapping was configured.
+func (s *HedgeService) hedgeSymbol(symbol string) string {
+	if mapped, ok := s.cfg.SymbolMap[symbol]; ok {
+		return mapped
+	}
+	return symbol
+}
+
+// opposite returns the other side of side, for sizing the hedge leg against
+// the paper position it's offsetting.
+func opposite(side string) string {
+	if side == domain.SideLong {
+		return domain.SideShort
+	}
+	return domain.SideLong
+}
+
+// OpenHedge opens a hedge leg sized paper.Size*HedgeRatio on the opposite
+// side of paper. A nil hedgeExchange or a hedge that would push total open
+// exposure past MaxExposure is skipped with a log line, and a zero-size
+// hedge is skipped without one, rather than treated as errors, since
+// hedging is a best-effort opt-in overlay on top of the paper trade.
+func (s *HedgeService) OpenHedge(ctx context.Context, paper *domain.PaperPosition) error {
+	if s.hedgeExchange == nil {
+		log.Printf("Skipping hedge open for %s: no hedge exchange configured", paper.Symbol)
+		return nil
+	}
+
+	hedgeSize := paper.Size * s.cfg.HedgeRatio
+	if hedgeSize <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if exposure, err := s.openExposureUSDT(ctx); err == nil {
+		if notional := hedgeSize * paper.EntryPrice; exposure+notional > s.cfg.MaxExposure {
+			log.Printf("Skipping hedge open for %s: would exceed max exposure (%.2f + %.2f > %.2f)",
+				paper.Symbol, exposure, notional, s.cfg.MaxExposure)
+			return nil
+		}
+	}
+
+	if err := s.breaker.Allow(); err != nil {
+		return fmt.Errorf("hedge exchange circuit open, skipping hedge for %s: %w", paper.Symbol, err)
+	}
+
+	side := opposite(paper.Side)
+	symbol := s.hedgeSymbol(paper.Symbol)
+
+	var result *binance.OpenPositionResult
+	err := reliability.Do(ctx, reliability.DefaultRetryConfig(), func() error {
+		var err error
+		result, err = s.hedgeExchange.OpenPosition(ctx, binance.OpenPositionRequest{
+			Symbol: symbol,
+			Side:   side,
+			Size:   hedgeSize,
+		})
+		return err
+	})
+	if err != nil {
+		s.breaker.RecordFailure(err)
+		return fmt.Errorf("failed to open hedge position for %s: %w", paper.Symbol, err)
+	}
+	s.breaker.RecordSuccess()
+
+	hedge := &domain.HedgePosition{
+		ID:              uuid.New(),
+		PaperPositionID: paper.ID,
+		UserID:          paper.UserID,
+		Symbol:          symbol,
+		Side:            side,
+		EntryPrice:      result.EntryPrice,
+		Size:            hedgeSize,
+		Status:          domain.StatusOpen,
+		CreatedAt:       time.Now(),
+	}
+
+	if err := s.hedgeRepo.Save(ctx, hedge); err != nil {
+		return fmt.Errorf("failed to save hedge position for %s: %w", paper.Symbol, err)
+	}
+
+	log.Printf("Opened hedge leg: %s %s | Size: %.6f | Entry: %.4f", hedge.Symbol, hedge.Side, hedge.Size, hedge.EntryPrice)
+	return nil
+}
+
+// CloseHedge closes the open hedge leg for paperPositionID, if any, so a
+// closed paper position never leaves a naked hedge behind. A missing hedge
+// (hedging wasn't enabled for this user, or OpenHedge skipped it) is not an
+// error.
+func (s *HedgeService) CloseHedge(ctx context.Context, paperPositionID uuid.UUID) error {
+	if s.hedgeExchange == nil {
+		return nil
+	}
+
+	hedge, err := s.hedgeRepo.GetByPaperPositionID(ctx, paperPositionID)
+	if err != nil {
+		return fmt.Errorf("failed to look up hedge position: %w", err)
+	}
+	if hedge == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result *binance.ClosePositionResult
+	err = reliability.Do(ctx, reliability.DefaultRetryConfig(), func() error {
+		var err error
+		result, err = s.hedgeExchange.ClosePosition(ctx, hedge.Symbol, hedge.Side, domain.PositionSideBoth, hedge.Size)
+		return err
+	})
+	if err != nil {
+		s.breaker.RecordFailure(err)
+		return fmt.Errorf("failed to close hedge position %s: %w", hedge.ID, err)
+	}
+	s.breaker.RecordSuccess()
+
+	var pnl float64
+	if hedge.Side == domain.SideLong {
+		pnl = (result.ExitPrice - hedge.EntryPrice) * hedge.Size
+	} else {
+		pnl = (hedge.EntryPrice - result.ExitPrice) * hedge.Size
+	}
+
+	now := time.Now()
+	hedge.ExitPrice = &result.ExitPrice
+	hedge.PnL = &pnl
+	hedge.Status = domain.StatusClosedManual
+	hedge.ClosedAt = &now
+
+	if err := s.hedgeRepo.Update(ctx, hedge); err != nil {
+		return fmt.Errorf("failed to update closed hedge position %s: %w", hedge.ID, err)
+	}
+
+	log.Printf("Closed hedge leg: %s %s | PnL: %.2f", hedge.Symbol, hedge.Side, pnl)
+	return nil
+}
+
+// openExposureUSDT sums entry_price*size across every open hedge leg, for
+// OpenHedge's MaxExposure guard.
+func (s *HedgeService) openExposureUSDT(ctx context.Context) (float64, error) {
+	hedges, err := s.hedgeRepo.GetOpenHedges(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var total float64
+	for _, h := range hedges {
+		total += h.EntryPrice * h.Size
+	}
+	return total, nil
+}
+
+// Reconcile checks that total open hedge exposure per symbol stays roughly
+// proportional to open paper exposure × HedgeRatio, for a periodic cron job.
+// OpenHedge/CloseHedge already keep the two legs in lockstep on the happy
+// path, so today this only logs drift past 20% as a safety net for
+// exchange-side surprises (partial fills, manual intervention on the hedge
+// account) rather than issuing correcting orders itself.
+func (s *HedgeService) Reconcile(ctx context.Context, paperPositions []*domain.PaperPosition) error {
+	if s.hedgeExchange == nil {
+		return nil
+	}
+
+	paperExposure := make(map[string]float64)
+	for _, p := range paperPositions {
+		if p.Status != domain.StatusOpen {
+			continue
+		}
+		paperExposure[s.hedgeSymbol(p.Symbol)] += p.Size * p.EntryPrice
+	}
+
+	hedges, err := s.hedgeRepo.GetOpenHedges(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list open hedges for reconciliation: %w", err)
+	}
+
+	hedgeExposure := make(map[string]float64)
+	for _, h := range hedges {
+		hedgeExposure[h.Symbol] += h.Size * h.EntryPrice
+	}
+
+	for symbol, paper := range paperExposure {
+		want := paper * s.cfg.HedgeRatio
+		if want == 0 {
+			continue
+		}
+		got := hedgeExposure[symbol]
+		if drift := (got - want) / want; drift < -0.2 || drift > 0.2 {
+			log.Printf("WARNING: hedge exposure drift for %s: want ~%.2f, have %.2f (%.0f%% off)", symbol, want, got, drift*100)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"neurotrade/internal/domain"
+)
+
+// instrumentInfoCacheTTL is how long a fetched InstrumentInfo is reused
+// before refetching. Exchange precision rules change far less often than
+// ATR/price readings, so this is much longer than atrCacheTTL.
+const instrumentInfoCacheTTL = 1 * time.Hour
+
+type cachedInstrumentInfo struct {
+	info      domain.InstrumentInfo
+	expiresAt time.Time
+}
+
+// MarketInfoService implements domain.InstrumentRepository against Binance
+// Futures' exchangeInfo endpoint, caching each symbol's precision rules for
+// instrumentInfoCacheTTL.
+type MarketInfoService struct {
+	httpClient      *http.Client
+	exchangeInfoURL string
+
+	mu    sync.Mutex
+	cache map[string]cachedInstrumentInfo
+}
+
+// NewMarketInfoService creates a MarketInfoService reading exchangeInfo from
+// BINANCE_EXCHANGE_INFO_URL.
+func NewMarketInfoService() *MarketInfoService {
+	return &MarketInfoService{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		exchangeInfoURL: os.Getenv("BINANCE_EXCHANGE_INFO_URL"),
+		cache:           make(map[string]cachedInstrumentInfo),
+	}
+}
+
+// GetInstrumentInfo implements domain.InstrumentRepository, fetching and
+// caching symbol's tick/step/minNotional precision from exchangeInfo.
+func (s *MarketInfoService) GetInstrumentInfo(ctx context.Context, symbol string) (*domain.InstrumentInfo, error) {
+	norm := strings.ReplaceAll(strings.ToUpper(symbol), "/", "")
+
+	s.mu.Lock()
+	if cached, ok := s.cache[norm]; ok && time.Now().Before(cached.expiresAt) {
+		s.mu.Unlock()
+		return &cached.info, nil
+	}
+	s.mu.Unlock()
+
+	info, err := s.fetchInstrumentInfo(ctx, norm)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.cache[norm] = cachedInstrumentInfo{info: *info, expiresAt: time.Now().Add(instrumentInfoCacheTTL)}
+	s.mu.Unlock()
+
+	return info, nil
+}
+
+// fetchInstrumentInfo fetches exchangeInfo and extracts symbol's PRICE_FILTER/
+// LOT_SIZE/MIN_NOTIONAL filters.
+func (s *MarketInfoService) fetchInstrumentInfo(ctx context.Context, symbol string) (*domain.InstrumentInfo, error) {
+	if s.exchangeInfoURL == "" {
+		return nil, fmt.Errorf("BINANCE_EXCHANGE_INFO_URL environment variable is not set")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", s.exchangeInfoURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch exchangeInfo from Binance: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Binance API error: status=%d, body=%s", resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var raw struct {
+		Symbols []struct {
+			Symbol  string `json:"symbol"`
+			Filters []struct {
+				FilterType string `json:"filterType"`
+				TickSize   string `json:"tickSize"`
+				StepSize   string `json:"stepSize"`
+				Notional   string `json:"notional"`
+			} `json:"filters"`
+		} `json:"symbols"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	for _, sym := range raw.Symbols {
+		if sym.Symbol != symbol {
+			continue
+		}
+
+		info := &domain.InstrumentInfo{Symbol: symbol, ContractSize: 1}
+		for _, f := range sym.Filters {
+			switch f.FilterType {
+			case "PRICE_FILTER":
+				info.PriceTickSize, _ = strconv.ParseFloat(f.TickSize, 64)
+			case "LOT_SIZE":
+				info.QuantityStepSize, _ = strconv.ParseFloat(f.StepSize, 64)
+			case "MIN_NOTIONAL":
+				info.MinNotional, _ = strconv.ParseFloat(f.Notional, 64)
+			}
+		}
+
+		return info, nil
+	}
+
+	return nil, fmt.Errorf("symbol %s not found in exchangeInfo", symbol)
+}
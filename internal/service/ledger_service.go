@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"neurotrade/internal/adapter"
+	"neurotrade/internal/domain"
+)
+
+// LedgerExchangeName identifies the exchange these ledger rows came from.
+// Only Binance is supported today, so it's a constant rather than per-row config.
+const LedgerExchangeName = "BINANCE"
+
+// LedgerService reconciles real_balance_cache against external fund
+// movement: periodically pulling each REAL-mode user's withdrawal/deposit
+// history from Binance, and answering net-flow questions for P&L views
+type LedgerService struct {
+	userRepo       domain.UserRepository
+	withdrawalRepo domain.WithdrawalRepository
+	depositRepo    domain.DepositRepository
+	bridge         *adapter.PythonBridge
+}
+
+// NewLedgerService creates a new LedgerService
+func NewLedgerService(
+	userRepo domain.UserRepository,
+	withdrawalRepo domain.WithdrawalRepository,
+	depositRepo domain.DepositRepository,
+	bridge *adapter.PythonBridge,
+) *LedgerService {
+	return &LedgerService{
+		userRepo:       userRepo,
+		withdrawalRepo: withdrawalRepo,
+		depositRepo:    depositRepo,
+		bridge:         bridge,
+	}
+}
+
+// SyncAll pulls withdrawal/deposit history for every REAL-mode user and
+// upserts it into the ledger. Meant to be called periodically by a cron job.
+func (s *LedgerService) SyncAll(ctx context.Context) error {
+	users, err := s.userRepo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+
+	for _, user := range users {
+		if user.Mode != domain.ModeReal {
+			continue
+		}
+
+		if err := s.syncUser(ctx, user); err != nil {
+			log.Printf("[WARN] Ledger: failed to sync user %s: %v", user.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// syncUser pulls and upserts wallet history for a single user
+func (s *LedgerService) syncUser(ctx context.Context, user *domain.User) error {
+	withdrawals, deposits, err := s.bridge.GetWalletHistory(ctx, user.BinanceAPIKey, user.BinanceAPISecret)
+	if err != nil {
+		return fmt.Errorf("failed to fetch wallet history: %w", err)
+	}
+
+	for _, w := range withdrawals {
+		withdrawal := &domain.Withdrawal{
+			ID:             uuid.New(),
+			UserID:         user.ID,
+			Exchange:       LedgerExchangeName,
+			Asset:          w.Asset,
+			Address:        w.Address,
+			Network:        w.Network,
+			Amount:         w.Amount,
+			TxnID:          w.TxnID,
+			TxnFee:         w.TxnFee,
+			TxnFeeCurrency: w.TxnFeeCurrency,
+			Time:           time.UnixMilli(w.Time),
+			CreatedAt:      time.Now(),
+		}
+		if err := s.withdrawalRepo.Upsert(ctx, withdrawal); err != nil {
+			log.Printf("[WARN] Ledger: failed to upsert withdrawal %s for user %s: %v", w.TxnID, user.ID, err)
+		}
+	}
+
+	for _, d := range deposits {
+		deposit := &domain.Deposit{
+			ID:             uuid.New(),
+			UserID:         user.ID,
+			Exchange:       LedgerExchangeName,
+			Asset:          d.Asset,
+			Address:        d.Address,
+			Network:        d.Network,
+			Amount:         d.Amount,
+			TxnID:          d.TxnID,
+			TxnFee:         d.TxnFee,
+			TxnFeeCurrency: d.TxnFeeCurrency,
+			Time:           time.UnixMilli(d.Time),
+			CreatedAt:      time.Now(),
+		}
+		if err := s.depositRepo.Upsert(ctx, deposit); err != nil {
+			log.Printf("[WARN] Ledger: failed to upsert deposit %s for user %s: %v", d.TxnID, user.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// GetNetFlow returns external fund movement (deposits - withdrawals) for a
+// user since a given time, so P&L views can subtract it from balance deltas
+// before attributing the rest to trading performance
+func (s *LedgerService) GetNetFlow(ctx context.Context, userID uuid.UUID, since time.Time) (float64, error) {
+	depositSum, err := s.depositRepo.SumByUserSince(ctx, userID, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum deposits: %w", err)
+	}
+
+	withdrawalSum, err := s.withdrawalRepo.SumByUserSince(ctx, userID, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum withdrawals: %w", err)
+	}
+
+	return depositSum - withdrawalSum, nil
+}
@@ -2,141 +2,216 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"os"
+	"log"
 	"strings"
 	"time"
+
+	"neurotrade/internal/domain"
+	"neurotrade/internal/exchange"
 )
 
+// supportedKlineIntervals are the interval spellings FetchKlines/
+// FetchKlinesRange accept: exchange.KlinePeriod's normalized set except
+// Kline30Min, which only GetKlines passes through.
+var supportedKlineIntervals = map[string]bool{
+	string(exchange.Kline1Min):  true,
+	string(exchange.Kline5Min):  true,
+	string(exchange.Kline15Min): true,
+	string(exchange.Kline1Hour): true,
+	string(exchange.Kline4Hour): true,
+	string(exchange.Kline1Day):  true,
+}
+
+// maxKlinesPerRequest is the largest candle count GetKlineRecords is asked
+// for in one call, matching Binance Futures' /fapi/v1/klines limit.
+const maxKlinesPerRequest = 1000
+
 // PriceData represents the current price for a symbol
 type PriceData struct {
 	Symbol string
 	Price  float64
 }
 
-// MarketPriceService fetches real-time prices from Binance
+// MarketPriceService fetches real-time prices and klines through a
+// venue-agnostic exchange.Exchange, so the trading venue is an EXCHANGE
+// env var away from Binance/Bybit/OKX rather than hard-coded here.
 type MarketPriceService struct {
-	httpClient *http.Client
-	priceURL   string
+	exch exchange.Exchange
 }
 
-// NewMarketPriceService creates a new MarketPriceService
+// NewMarketPriceService creates a new MarketPriceService, picking its
+// exchange.Exchange from the EXCHANGE env var (see exchange.NewFromEnv).
+// An unrecognized EXCHANGE value falls back to Binance rather than
+// blocking startup.
 func NewMarketPriceService() *MarketPriceService {
-	// Use single URL from environment variable, no fallback
-	priceURL := os.Getenv("BINANCE_PRICE_URL")
-
-	return &MarketPriceService{
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		priceURL: priceURL,
+	exch, err := exchange.NewFromEnv()
+	if err != nil {
+		log.Printf("WARNING: %v, falling back to Binance", err)
+		exch = exchange.NewBinanceExchange()
 	}
+
+	return &MarketPriceService{exch: exch}
 }
 
-// FetchRealTimePrices fetches current prices for multiple symbols from Binance Futures
+// FetchRealTimePrices fetches current prices for multiple symbols from the
+// configured exchange. Accepts either symbol spelling ("BTC/USDT" or
+// "BTCUSDT") and returns prices keyed by whatever spelling the caller used.
 func (s *MarketPriceService) FetchRealTimePrices(ctx context.Context, symbols []string) (map[string]float64, error) {
 	if len(symbols) == 0 {
 		return make(map[string]float64), nil
 	}
 
-	prices := make(map[string]float64)
+	prices := make(map[string]float64, len(symbols))
+	var missing []string
 
-	// Use configured URL directly
-	url := s.priceURL
-	if url == "" {
-		return nil, fmt.Errorf("BINANCE_PRICE_URL environment variable is not set")
+	for _, symbol := range symbols {
+		ticker, err := s.exch.GetTicker(ctx, exchange.NewCurrencyPair(symbol))
+		if err != nil {
+			missing = append(missing, symbol)
+			continue
+		}
+		prices[symbol] = ticker.Last
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	if len(missing) > 0 {
+		return prices, fmt.Errorf("missing prices for symbols: %v", missing)
 	}
 
-	resp, err := s.httpClient.Do(req)
+	return prices, nil
+}
+
+// FetchSinglePrice fetches the current price for a single symbol
+func (s *MarketPriceService) FetchSinglePrice(ctx context.Context, symbol string) (float64, error) {
+	prices, err := s.FetchRealTimePrices(ctx, []string{symbol})
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch prices from Binance: %w", err)
+		return 0, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Binance API error: status=%d, body=%s", resp.StatusCode, string(body))
+	price, ok := prices[strings.ToUpper(symbol)]
+	if !ok {
+		return 0, fmt.Errorf("price not found for symbol: %s", symbol)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	return price, nil
+}
+
+// GetPrice fetches the current price for a single symbol (alias for FetchSinglePrice)
+func (s *MarketPriceService) GetPrice(ctx context.Context, symbol string) (float64, error) {
+	return s.FetchSinglePrice(ctx, symbol)
+}
+
+// GetKlines implements domain.KlineProvider, fetching recent candles for
+// symbol/interval from the configured exchange, oldest first. interval is
+// one of exchange.Kline1Min/5Min/15Min/30Min/1Hour/4Hour/1Day's string
+// value (e.g. "15m"); see exchange.KlinePeriod.
+func (s *MarketPriceService) GetKlines(ctx context.Context, symbol, interval string, limit int) ([]domain.Kline, error) {
+	klines, err := s.exch.GetKlineRecords(ctx, exchange.NewCurrencyPair(symbol), exchange.KlinePeriod(interval), limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to fetch klines for %s: %w", symbol, err)
 	}
+	return klines, nil
+}
 
-	// Parse response - Binance returns array of all tickers
-	var tickers []struct {
-		Symbol string `json:"symbol"`
-		Price  string `json:"price"`
+// FetchKlines fetches up to limit recent candles for symbol/interval from
+// the configured exchange, oldest first, for callers outside the
+// domain.KlineProvider call path (e.g. MarketHandler, the Python AI bridge)
+// that want the raw series rather than a derived SL/TP. interval must be
+// one of "1m"/"5m"/"15m"/"1h"/"4h"/"1d".
+func (s *MarketPriceService) FetchKlines(ctx context.Context, symbol, interval string, limit int) ([]domain.Kline, error) {
+	if !supportedKlineIntervals[interval] {
+		return nil, fmt.Errorf("unsupported interval: %s", interval)
 	}
 
-	if err := json.Unmarshal(body, &tickers); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	return s.GetKlines(ctx, symbol, interval, limit)
+}
+
+// FetchKlinesRange fetches every candle for symbol/interval between start
+// and end (inclusive), chunking into maxKlinesPerRequest-candle requests so
+// a multi-month range doesn't exceed the exchange's per-call limit.
+func (s *MarketPriceService) FetchKlinesRange(ctx context.Context, symbol, interval string, start, end time.Time) ([]domain.Kline, error) {
+	if !supportedKlineIntervals[interval] {
+		return nil, fmt.Errorf("unsupported interval: %s", interval)
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("end must be after start")
 	}
 
-	// Create a map for quick lookup: Normalized -> Original(s)
-	// We need to map normalized symbol (BTCUSDT) back to requested symbol (BTC/USDT)
-	symbolMap := make(map[string][]string)
-	for _, symbol := range symbols {
-		// Remove slash and uppercase: "BTC/USDT" -> "BTCUSDT"
-		norm := strings.ReplaceAll(strings.ToUpper(symbol), "/", "")
-		symbolMap[norm] = append(symbolMap[norm], symbol)
-	}
-
-	// Extract prices for requested symbols
-	for _, ticker := range tickers {
-		if originals, ok := symbolMap[ticker.Symbol]; ok {
-			var price float64
-			_, err := fmt.Sscanf(ticker.Price, "%f", &price)
-			if err != nil {
-				continue
-			}
-
-			// Store price for all variations requested (e.g. both BTC/USDT and BTCUSDT)
-			for _, original := range originals {
-				prices[original] = price
-			}
+	pair := exchange.NewCurrencyPair(symbol)
+	period := exchange.KlinePeriod(interval)
+
+	var all []domain.Kline
+	cursor := start
+	for cursor.Before(end) {
+		chunk, err := s.exch.GetKlineRecords(ctx, pair, period, maxKlinesPerRequest,
+			exchange.WithStartTime(cursor), exchange.WithEndTime(end))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch klines for %s: %w", symbol, err)
 		}
-	}
+		if len(chunk) == 0 {
+			break
+		}
+
+		all = append(all, chunk...)
 
-	// Check if we got all requested symbols
-	if len(prices) != len(symbols) {
-		missing := []string{}
-		for _, symbol := range symbols {
-			if _, ok := prices[symbol]; !ok {
-				missing = append(missing, symbol)
-			}
+		last := chunk[len(chunk)-1].OpenTime
+		if !last.After(cursor) {
+			// The exchange returned no progress past cursor; stop rather than
+			// looping forever on a venue that ignores startTime.
+			break
 		}
-		// Return found prices and error listing missing ones
-		return prices, fmt.Errorf("missing prices for symbols: %v", missing)
+		cursor = last.Add(time.Millisecond)
 	}
 
-	return prices, nil
+	return all, nil
 }
 
-// FetchSinglePrice fetches the current price for a single symbol
-func (s *MarketPriceService) FetchSinglePrice(ctx context.Context, symbol string) (float64, error) {
-	prices, err := s.FetchRealTimePrices(ctx, []string{symbol})
-	if err != nil {
-		return 0, err
+// Indicator identifies which technical indicator FetchIndicator computes.
+type Indicator string
+
+const (
+	IndicatorEMA Indicator = "EMA"
+)
+
+// FetchIndicator fetches window+1 recent candles for symbol/interval and
+// computes indicator over the most recent window of them, for ExitRule
+// implementations that need more than a raw price (e.g. EMAStopRule).
+func (s *MarketPriceService) FetchIndicator(ctx context.Context, symbol, interval string, window int, indicator Indicator) (float64, error) {
+	if indicator != IndicatorEMA {
+		return 0, fmt.Errorf("unsupported indicator: %s", indicator)
 	}
 
-	price, ok := prices[strings.ToUpper(symbol)]
-	if !ok {
-		return 0, fmt.Errorf("price not found for symbol: %s", symbol)
+	klines, err := s.GetKlines(ctx, symbol, interval, window+1)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch klines for %s indicator: %w", indicator, err)
+	}
+	if len(klines) < window {
+		return 0, fmt.Errorf("not enough klines for %s(%d): got %d", indicator, window, len(klines))
 	}
 
-	return price, nil
+	return ema(klines[len(klines)-window:]), nil
 }
 
-// GetPrice fetches the current price for a single symbol (alias for FetchSinglePrice)
-func (s *MarketPriceService) GetPrice(ctx context.Context, symbol string) (float64, error) {
-	return s.FetchSinglePrice(ctx, symbol)
+// ema computes the exponential moving average of klines' Close, seeded with
+// a simple average of the first third of the window (a common EMA
+// warm-up), smoothing factor 2/(n+1).
+func ema(klines []domain.Kline) float64 {
+	n := len(klines)
+	seedLen := n / 3
+	if seedLen < 1 {
+		seedLen = 1
+	}
+
+	var seed float64
+	for _, k := range klines[:seedLen] {
+		seed += k.Close
+	}
+	value := seed / float64(seedLen)
+
+	multiplier := 2.0 / float64(n+1)
+	for _, k := range klines[seedLen:] {
+		value = (k.Close-value)*multiplier + value
+	}
+
+	return value
 }
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/time/rate"
+)
+
+// Binance documents a safe sustained cap well under its hard IP ban
+// threshold; 5 rps with a small burst keeps many auto-trading users from
+// collectively tripping a -1003 TOO_MANY_REQUESTS ban.
+const (
+	orderRateLimitRPS   = 5
+	orderRateLimitBurst = 2
+)
+
+// OrderRateLimiter is a process-wide limiter shared by every service that
+// places or closes orders against the exchange, so concurrent users can't
+// collectively exceed Binance's rate limits.
+type OrderRateLimiter struct {
+	limiter *rate.Limiter
+}
+
+// NewOrderRateLimiter creates the shared order rate limiter
+func NewOrderRateLimiter() *OrderRateLimiter {
+	return &OrderRateLimiter{
+		limiter: rate.NewLimiter(rate.Limit(orderRateLimitRPS), orderRateLimitBurst),
+	}
+}
+
+// Wait blocks until an order slot is available or ctx is cancelled
+func (l *OrderRateLimiter) Wait(ctx context.Context) error {
+	return l.limiter.Wait(ctx)
+}
+
+// isExchangeThrottled reports whether err looks like a Binance 429/418
+// (TOO_MANY_REQUESTS / IP ban) response surfaced through the Python bridge
+func isExchangeThrottled(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "429") ||
+		strings.Contains(msg, "418") ||
+		strings.Contains(msg, "too_many_requests") ||
+		strings.Contains(msg, "too many requests")
+}
+
+const (
+	cooldownBase = 30 * time.Second
+	cooldownMax  = 15 * time.Minute
+)
+
+// CooldownTracker doubles each user's trade cooldown on repeated exchange
+// throttling and resets it once they've gone a cycle without being throttled.
+// It only tracks the in-memory backoff duration; the resulting deadline is
+// persisted via UserRepository.SetTradeCooldownUntil so it survives restarts
+// and is visible to whatever skips auto-trade-enabled users.
+type CooldownTracker struct {
+	mu   sync.Mutex
+	next map[uuid.UUID]time.Duration
+}
+
+// NewCooldownTracker creates an empty per-user cooldown tracker
+func NewCooldownTracker() *CooldownTracker {
+	return &CooldownTracker{next: make(map[uuid.UUID]time.Duration)}
+}
+
+// Escalate doubles (capped at cooldownMax) the user's cooldown and returns
+// the deadline to persist
+func (c *CooldownTracker) Escalate(userID uuid.UUID, now time.Time) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	d := c.next[userID]
+	if d == 0 {
+		d = cooldownBase
+	} else {
+		d *= 2
+		if d > cooldownMax {
+			d = cooldownMax
+		}
+	}
+	c.next[userID] = d
+
+	return now.Add(d)
+}
+
+// Reset clears a user's escalated cooldown after a clean (non-throttled) order
+func (c *CooldownTracker) Reset(userID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.next, userID)
+}
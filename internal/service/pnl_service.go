@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"neurotrade/internal/domain"
+)
+
+// PnLReport is a FIFO-lot PnL summary for one user/symbol over a time
+// window, the way bbgo's `pnl` command reports a session's performance.
+// Symbol "" means every symbol combined.
+type PnLReport struct {
+	UserID        uuid.UUID `json:"user_id"`
+	Symbol        string    `json:"symbol"`
+	From          time.Time `json:"from"`
+	To            time.Time `json:"to"`
+	RealizedPnL   float64   `json:"realized_pnl"`
+	UnrealizedPnL float64   `json:"unrealized_pnl"`
+	AverageCost   float64   `json:"average_cost"`
+	NumTrades     int       `json:"num_trades"`
+	WinRate       float64   `json:"win_rate"`
+	ProfitFactor  float64   `json:"profit_factor"`
+	MaxDrawdown   float64   `json:"max_drawdown"`
+	GrossPnL      float64   `json:"gross_pnl"`
+	NetPnL        float64   `json:"net_pnl"`
+	FeeBps        float64   `json:"fee_bps"`
+}
+
+// PnLService builds PnLReports by walking a user's PaperPosition rows in
+// FIFO (chronological) order as one lot per row, marking anything still
+// open to the current MarketPriceService price of its symbol. AverageCost
+// is the size-weighted entry price of every open lot in the report, not
+// split by symbol or side.
+//
+// PaperPosition rows already carry their own open-to-close round trip (no
+// separate buy/sell fills to match against each other), so "FIFO lot"
+// here governs the order lots are walked and averaged rather than
+// matching partial fills across rows.
+type PnLService struct {
+	positionRepo domain.PaperPositionRepository
+	priceService *MarketPriceService
+	feeBps       float64
+}
+
+// NewPnLService creates a PnLService charging feeBps (basis points, e.g.
+// 5 = 0.05%) per side when computing GrossPnL/NetPnL.
+func NewPnLService(positionRepo domain.PaperPositionRepository, priceService *MarketPriceService, feeBps float64) *PnLService {
+	return &PnLService{positionRepo: positionRepo, priceService: priceService, feeBps: feeBps}
+}
+
+// lot is one FIFO-ordered PaperPosition, open or closed.
+type lot struct {
+	side       string
+	entryPrice float64
+	size       float64
+	closed     bool
+	pnl        float64
+	exitPrice  float64
+	closedAt   time.Time
+}
+
+// GenerateReport computes userID's PnL report for symbol ("" = all
+// symbols) over [from, to].
+func (s *PnLService) GenerateReport(ctx context.Context, userID uuid.UUID, symbol string, from, to time.Time) (*PnLReport, error) {
+	positions, err := s.positionRepo.GetForReport(ctx, userID, symbol, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load positions for PnL report: %w", err)
+	}
+
+	report := &PnLReport{UserID: userID, Symbol: symbol, From: from, To: to, FeeBps: s.feeBps}
+
+	lots := make([]lot, 0, len(positions))
+	var openCostTotal, openSizeTotal float64
+	symbolsNeedingPrice := make(map[string]bool)
+
+	for _, p := range positions {
+		l := lot{side: p.Side, entryPrice: p.EntryPrice, size: p.Size}
+		if p.Status != domain.StatusOpen {
+			l.closed = true
+			if p.PnL != nil {
+				l.pnl = *p.PnL
+			}
+			if p.ExitPrice != nil {
+				l.exitPrice = *p.ExitPrice
+			}
+			if p.ClosedAt != nil {
+				l.closedAt = *p.ClosedAt
+			}
+		} else {
+			openCostTotal += p.EntryPrice * p.Size
+			openSizeTotal += p.Size
+			symbolsNeedingPrice[p.Symbol] = true
+		}
+		lots = append(lots, l)
+	}
+
+	if openSizeTotal > 0 {
+		report.AverageCost = openCostTotal / openSizeTotal
+	}
+
+	currentPrices := make(map[string]float64, len(symbolsNeedingPrice))
+	for sym := range symbolsNeedingPrice {
+		price, err := s.priceService.FetchSinglePrice(ctx, sym)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch mark price for %s: %w", sym, err)
+		}
+		currentPrices[sym] = price
+	}
+
+	var grossFees float64
+	closedChrono := make([]lot, 0, len(lots))
+	for i, l := range lots {
+		if !l.closed {
+			currentPrice := currentPrices[positions[i].Symbol]
+			unrealized := (currentPrice - l.entryPrice) * l.size
+			if l.side == domain.SideShort {
+				unrealized = -unrealized
+			}
+			report.UnrealizedPnL += unrealized
+			continue
+		}
+
+		report.NumTrades++
+		report.RealizedPnL += l.pnl
+		grossFees += (l.entryPrice + l.exitPrice) * l.size * s.feeBps / 10000
+		closedChrono = append(closedChrono, l)
+	}
+
+	sort.Slice(closedChrono, func(i, j int) bool { return closedChrono[i].closedAt.Before(closedChrono[j].closedAt) })
+
+	var grossProfit, grossLoss, cumPnL, peak float64
+	wins := 0
+	for _, l := range closedChrono {
+		if l.pnl >= 0 {
+			wins++
+			grossProfit += l.pnl
+		} else {
+			grossLoss += -l.pnl
+		}
+
+		cumPnL += l.pnl
+		if cumPnL > peak {
+			peak = cumPnL
+		}
+		if drawdown := peak - cumPnL; drawdown > report.MaxDrawdown {
+			report.MaxDrawdown = drawdown
+		}
+	}
+
+	if report.NumTrades > 0 {
+		report.WinRate = float64(wins) / float64(report.NumTrades) * 100
+	}
+	if grossLoss > 0 {
+		report.ProfitFactor = grossProfit / grossLoss
+	} else if grossProfit > 0 {
+		// No losing trades yet: there's no ratio to divide by, so report
+		// gross profit itself as a (very favorable) stand-in, matching
+		// domain.ProfitStats.recomputeRatios' convention.
+		report.ProfitFactor = grossProfit
+	}
+
+	report.GrossPnL = report.RealizedPnL
+	report.NetPnL = report.RealizedPnL - grossFees
+
+	return report, nil
+}
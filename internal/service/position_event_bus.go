@@ -0,0 +1,63 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// PositionEventBus fans out per-user "your positions changed" notifications
+// so an SSE handler can push a live update instead of polling. Publish is
+// called by BodyguardService after a close or trailing-stop update;
+// Subscribe returns a channel that receives a notification for that user
+// until the returned unsubscribe func is called.
+type PositionEventBus struct {
+	mu   sync.Mutex
+	subs map[uuid.UUID]map[chan struct{}]struct{}
+}
+
+// NewPositionEventBus creates an empty PositionEventBus
+func NewPositionEventBus() *PositionEventBus {
+	return &PositionEventBus{
+		subs: make(map[uuid.UUID]map[chan struct{}]struct{}),
+	}
+}
+
+// Subscribe registers a new notification channel for userID. Callers must
+// call the returned unsubscribe func when done (e.g. on SSE client
+// disconnect) or the channel leaks.
+func (b *PositionEventBus) Subscribe(userID uuid.UUID) (ch chan struct{}, unsubscribe func()) {
+	ch = make(chan struct{}, 1)
+
+	b.mu.Lock()
+	if b.subs[userID] == nil {
+		b.subs[userID] = make(map[chan struct{}]struct{})
+	}
+	b.subs[userID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.subs[userID], ch)
+		if len(b.subs[userID]) == 0 {
+			delete(b.subs, userID)
+		}
+		b.mu.Unlock()
+	}
+}
+
+// Publish notifies every subscriber of userID that its positions changed.
+// Non-blocking: each subscriber channel is buffered 1, so a subscriber that
+// hasn't drained the previous notification yet simply coalesces rather than
+// blocking the Bodyguard loop.
+func (b *PositionEventBus) Publish(userID uuid.UUID) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs[userID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"neurotrade/internal/domain"
+	"neurotrade/internal/metrics"
+)
+
+// priceStreamResubscribeInterval bounds how long a changed open-position
+// symbol set can go unnoticed if NotifyPositionsChanged is never called
+// (e.g. a position opened by a path that doesn't know about PriceStream).
+const priceStreamResubscribeInterval = 15 * time.Second
+
+// priceStreamStaleAfter is how old a websocket-cached price can be before
+// Latest reports it as absent, so CheckPositions falls back to a REST fetch
+// for that symbol instead of trading on a frozen price.
+const priceStreamStaleAfter = 30 * time.Second
+
+// PriceStream keeps a Binance mark-price websocket subscription (via
+// BinanceTickerPriceService) alive for the union of symbols currently held
+// in open positions, re-subscribing whenever that set changes. It has no
+// REST fallback of its own -- Latest simply reports "not found" for a stale
+// or never-ticked symbol, and callers (VirtualBrokerService.CheckPositions)
+// are expected to fetch those symbols over REST instead.
+type PriceStream struct {
+	ticker       domain.TickerPriceService
+	positionRepo domain.PositionRepository
+
+	mu      sync.Mutex
+	streams map[string]func() // symbol -> its own SubscribeTicker stop func
+
+	notifyCh chan struct{}
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+// NewPriceStream creates a PriceStream. Call Start to begin subscribing.
+func NewPriceStream(ticker domain.TickerPriceService, positionRepo domain.PositionRepository) *PriceStream {
+	return &PriceStream{
+		ticker:       ticker,
+		positionRepo: positionRepo,
+		streams:      make(map[string]func()),
+		notifyCh:     make(chan struct{}, 1),
+		stopCh:       make(chan struct{}),
+		doneCh:       make(chan struct{}),
+	}
+}
+
+// Start begins the background resubscribe loop. Call Close to stop it.
+func (ps *PriceStream) Start(ctx context.Context) {
+	go ps.run(ctx)
+}
+
+// NotifyPositionsChanged wakes the resubscribe loop immediately instead of
+// waiting for the next periodic check, for callers that just opened or
+// closed a position (e.g. VirtualBrokerService after a close, or the
+// trading usecase after an open). Non-blocking: a pending notification
+// already queued is enough, so this never blocks the caller.
+func (ps *PriceStream) NotifyPositionsChanged() {
+	select {
+	case ps.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// Latest returns symbol's websocket-cached price if PriceStream has one
+// fresher than priceStreamStaleAfter, and false otherwise (never ticked,
+// or stale -- the caller should fetch over REST instead).
+func (ps *PriceStream) Latest(symbol string) (float64, bool) {
+	price, age, ok := ps.ticker.GetLastPrice(symbol)
+	if !ok {
+		return 0, false
+	}
+	if age > priceStreamStaleAfter {
+		metrics.PriceStreamStaleFallbacksTotal.Inc()
+		return 0, false
+	}
+	return price, true
+}
+
+// Close stops the resubscribe loop and tears down the current subscription.
+func (ps *PriceStream) Close() {
+	close(ps.stopCh)
+	<-ps.doneCh
+}
+
+// run drives the periodic/notified resubscribe loop until Close is called.
+func (ps *PriceStream) run(ctx context.Context) {
+	defer close(ps.doneCh)
+
+	ps.resubscribe(ctx)
+
+	ticker := time.NewTicker(priceStreamResubscribeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ps.stopCh:
+			ps.mu.Lock()
+			for symbol, stop := range ps.streams {
+				stop()
+				delete(ps.streams, symbol)
+			}
+			ps.mu.Unlock()
+			return
+		case <-ticker.C:
+			ps.resubscribe(ctx)
+		case <-ps.notifyCh:
+			ps.resubscribe(ctx)
+		}
+	}
+}
+
+// resubscribe loads the current open-position symbol set and diffs it
+// against what's subscribed: symbols no longer open are unsubscribed,
+// newly-open symbols get their own subscription, and anything unchanged is
+// left alone -- one symbol opening/closing never drops the price feed for
+// every other symbol already streaming.
+func (ps *PriceStream) resubscribe(ctx context.Context) {
+	positions, err := ps.positionRepo.GetOpenPositions(ctx)
+	if err != nil {
+		log.Printf("[WARN] PriceStream: failed to load open positions for resubscribe: %v", err)
+		return
+	}
+
+	want := make(map[string]bool, len(positions))
+	for _, p := range positions {
+		want[p.Symbol] = true
+	}
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	for symbol, stop := range ps.streams {
+		if !want[symbol] {
+			stop()
+			delete(ps.streams, symbol)
+		}
+	}
+
+	for symbol := range want {
+		if _, ok := ps.streams[symbol]; ok {
+			continue
+		}
+
+		stop, err := ps.ticker.SubscribeTicker([]string{symbol})
+		if err != nil {
+			log.Printf("[WARN] PriceStream: failed to subscribe to %s, will retry next tick: %v", symbol, err)
+			continue
+		}
+
+		ps.streams[symbol] = stop
+		log.Printf("[PriceStream] subscribed to %s", symbol)
+	}
+}
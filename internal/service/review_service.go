@@ -8,6 +8,7 @@ import (
 	"strconv"
 
 	"neurotrade/internal/domain"
+	"neurotrade/internal/notification"
 )
 
 // getReviewThreshold gets the WIN_LOSS_THRESHOLD_PCT from env or returns default
@@ -26,10 +27,12 @@ var (
 	ReviewLossThresholdPercent = -getReviewThreshold() // e.g., -0.5% loss = LOSS
 )
 
-// NotificationService defines the interface for sending notifications
+// NotificationService is the narrow interface this package needs from
+// notification.Router, so callers don't depend on the concrete Router type
+// (mirrors domain.ATRProvider/domain.KlineProvider's narrow-interface
+// pattern elsewhere in the codebase).
 type NotificationService interface {
-	SendSignal(signal domain.Signal) error
-	SendReview(signal domain.Signal, pnl *float64) error
+	Emit(ctx context.Context, topic notification.Topic, payload notification.Payload) error
 }
 
 // ReviewService audits past signals and marks them as WIN/LOSS/FLOATING
@@ -37,18 +40,23 @@ type ReviewService struct {
 	signalRepo          domain.SignalRepository
 	priceService        *MarketPriceService
 	notificationService NotificationService
+	exitRules           []ExitRule
 }
 
-// NewReviewService creates a new ReviewService
+// NewReviewService creates a new ReviewService. exitRules is the configured
+// chain for the active strategy preset (see ParseExitRuleChain); pass
+// DefaultExitRuleChain() for the original fixed TP/SL-only behavior.
 func NewReviewService(
 	signalRepo domain.SignalRepository,
 	priceService *MarketPriceService,
 	notificationService NotificationService,
+	exitRules []ExitRule,
 ) *ReviewService {
 	return &ReviewService{
 		signalRepo:          signalRepo,
 		priceService:        priceService,
 		notificationService: notificationService,
+		exitRules:           exitRules,
 	}
 }
 
@@ -98,7 +106,7 @@ func (s *ReviewService) ReviewPastSignals(ctx context.Context, olderThanMinutes
 		floatingPnLPercent := s.calculateFloatingPnL(signal, currentPrice)
 
 		// Determine review result
-		result, pnl := s.determineReviewResult(signal, currentPrice, floatingPnLPercent)
+		result, pnl := s.determineReviewResult(ctx, signal, currentPrice, floatingPnLPercent)
 
 		// Update signal review status
 		if err := s.signalRepo.UpdateReviewStatus(ctx, signal.ID, result, &pnl); err != nil {
@@ -109,11 +117,11 @@ func (s *ReviewService) ReviewPastSignals(ctx context.Context, olderThanMinutes
 		log.Printf("[OK] Signal Reviewed: %s %s | Entry=%.2f Current=%.2f | PnL=%.2f%% | Result=%s",
 			signal.Symbol, signal.Type, signal.EntryPrice, currentPrice, floatingPnLPercent, result)
 
-		// Send Telegram notification for WIN/LOSS (not for FLOATING)
+		// Notify on WIN/LOSS (not for FLOATING)
 		if s.notificationService != nil && (result == "WIN" || result == "LOSS") {
 			signal.ReviewResult = &result
-			if err := s.notificationService.SendReview(*signal, nil); err != nil {
-				log.Printf("WARNING: Failed to send Telegram review notification: %v", err)
+			if err := s.notificationService.Emit(ctx, notification.TopicSignalReview, notification.Payload{Signal: signal}); err != nil {
+				log.Printf("WARNING: Failed to send review notification: %v", err)
 			}
 		}
 	}
@@ -123,39 +131,35 @@ func (s *ReviewService) ReviewPastSignals(ctx context.Context, olderThanMinutes
 
 // calculateFloatingPnL calculates the floating PnL percentage
 func (s *ReviewService) calculateFloatingPnL(signal *domain.Signal, currentPrice float64) float64 {
-	var pnlPercent float64
-
-	if signal.Type == "LONG" {
-		// Long: profit when price goes up
-		pnlPercent = ((currentPrice - signal.EntryPrice) / signal.EntryPrice) * 100
-	} else if signal.Type == "SHORT" {
-		// Short: profit when price goes down
-		pnlPercent = ((signal.EntryPrice - currentPrice) / signal.EntryPrice) * 100
-	}
-
-	return pnlPercent
+	return SignalPnLPercent(signal, currentPrice)
 }
 
-// determineReviewResult determines if signal is WIN/LOSS/FLOATING
-func (s *ReviewService) determineReviewResult(signal *domain.Signal, currentPrice, pnlPercent float64) (string, float64) {
-	// Check if TP or SL was hit first
-	if signal.Type == "LONG" {
-		if currentPrice >= signal.TPPrice {
-			return "WIN", pnlPercent
-		}
-		if currentPrice <= signal.SLPrice {
-			return "LOSS", pnlPercent
+// determineReviewResult evaluates s.exitRules in order against signal and
+// returns WIN/LOSS on the first non-HOLD decision. If every rule holds, it
+// falls back to the plain floating PnL thresholds (FLOATING_WIN/
+// FLOATING_LOSS/FLOATING), exactly as before the rule chain existed.
+func (s *ReviewService) determineReviewResult(ctx context.Context, signal *domain.Signal, currentPrice, pnlPercent float64) (string, float64) {
+	priceHistory, err := s.priceService.GetKlines(ctx, signal.Symbol, "1m", 50)
+	if err != nil {
+		log.Printf("WARNING: Review: failed to fetch price history for %s, history-dependent exit rules will hold: %v", signal.Symbol, err)
+	}
+
+	for _, rule := range s.exitRules {
+		decision, err := rule.Evaluate(ctx, signal, currentPrice, priceHistory)
+		if err != nil {
+			log.Printf("WARNING: Review: exit rule %T failed for signal %s, skipping: %v", rule, signal.ID, err)
+			continue
 		}
-	} else if signal.Type == "SHORT" {
-		if currentPrice <= signal.TPPrice {
+
+		switch decision {
+		case ExitWin:
 			return "WIN", pnlPercent
-		}
-		if currentPrice >= signal.SLPrice {
+		case ExitLoss:
 			return "LOSS", pnlPercent
 		}
 	}
 
-	// If TP/SL not hit, check floating PnL thresholds
+	// Every configured rule held: grade on the plain floating PnL bands.
 	if pnlPercent >= ReviewWinThresholdPercent {
 		return "FLOATING_WIN", pnlPercent
 	}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"neurotrade/internal/domain"
+	"neurotrade/internal/utils"
+)
+
+// StatsAggregator folds closed trades into per-user/symbol rolling
+// ProfitStats buckets (see domain.ProfitStats) and snapshots daily equity,
+// borrowing bbgo's ProfitStats idea but keyed by day instead of a fixed
+// calendar period so GetStats can sum any trailing window on read.
+type StatsAggregator struct {
+	repo         domain.ProfitStatsRepository
+	riskFreeRate float64 // daily risk-free rate subtracted before Sharpe
+}
+
+// NewStatsAggregator creates a StatsAggregator with a configurable daily
+// risk-free rate (0 is a reasonable default for a crypto futures bot).
+func NewStatsAggregator(repo domain.ProfitStatsRepository, riskFreeRate float64) *StatsAggregator {
+	return &StatsAggregator{repo: repo, riskFreeRate: riskFreeRate}
+}
+
+// dailyPeriodKey is the period key a trade closed at `at` is bucketed under,
+// in Jakarta time so day boundaries match the rest of this codebase's
+// timezone convention (see utils.GetJakartaTime).
+func dailyPeriodKey(at time.Time) string {
+	return at.In(utils.GetLocation()).Format("2006-01-02")
+}
+
+// RecordTrade folds one closed trade's realized pnl into today's daily
+// bucket and the evergreen all_time bucket, for both the traded symbol and
+// the "" (all-symbols) rollup.
+func (a *StatsAggregator) RecordTrade(ctx context.Context, userID uuid.UUID, symbol string, pnl, balanceAfter float64) error {
+	now := utils.GetJakartaTime()
+
+	for _, sym := range []string{symbol, ""} {
+		for _, period := range []string{dailyPeriodKey(now), domain.PeriodAllTime} {
+			stats, err := a.repo.Get(ctx, userID, sym, period)
+			if err != nil {
+				return fmt.Errorf("failed to get profit stats bucket %s/%s: %w", sym, period, err)
+			}
+
+			stats.UserID = userID
+			stats.Symbol = sym
+			stats.Period = period
+			stats.ApplyTrade(pnl, balanceAfter)
+
+			if err := a.repo.Upsert(ctx, stats); err != nil {
+				return fmt.Errorf("failed to upsert profit stats bucket %s/%s: %w", sym, period, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SnapshotEquity records userID's current balance into the equity curve
+// for today, for the nightly equity-curve cron job.
+func (a *StatsAggregator) SnapshotEquity(ctx context.Context, userID uuid.UUID, balance float64) error {
+	return a.repo.SnapshotEquity(ctx, userID, balance, utils.GetJakartaTime())
+}
+
+// GetStats aggregates a user's daily buckets for symbol ("" = all symbols)
+// over the trailing `days` days into one ProfitStats, alongside the
+// matching equity-curve slice for charting. days <= 0 returns the all_time
+// bucket and full equity history instead of summing dailies.
+func (a *StatsAggregator) GetStats(ctx context.Context, userID uuid.UUID, symbol string, days int) (*domain.ProfitStats, []*domain.EquityPoint, error) {
+	if days <= 0 {
+		stats, err := a.repo.Get(ctx, userID, symbol, domain.PeriodAllTime)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get all_time profit stats: %w", err)
+		}
+
+		curve, err := a.repo.GetEquityCurve(ctx, userID, time.Time{})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get equity curve: %w", err)
+		}
+
+		stats.SharpeRatio = sharpeRatio(curve, a.riskFreeRate)
+		return stats, curve, nil
+	}
+
+	since := utils.GetJakartaTime().AddDate(0, 0, -days)
+	combined := &domain.ProfitStats{UserID: userID, Symbol: symbol, Period: fmt.Sprintf("%dd", days)}
+
+	for d := 1; d <= days; d++ {
+		day := since.AddDate(0, 0, d)
+		bucket, err := a.repo.Get(ctx, userID, symbol, dailyPeriodKey(day))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get daily profit stats bucket: %w", err)
+		}
+		combined.Merge(bucket)
+	}
+
+	curve, err := a.repo.GetEquityCurve(ctx, userID, since)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get equity curve: %w", err)
+	}
+
+	combined.SharpeRatio = sharpeRatio(curve, a.riskFreeRate)
+	return combined, curve, nil
+}
+
+// sharpeRatio computes an annualized Sharpe ratio (mean/stddev of daily
+// returns vs. riskFreeRate, scaled by sqrt(365)) from the live equity
+// curve. Unlike backtest's per-trade sharpeRatio, it uses population
+// variance and is annualized.
+func sharpeRatio(curve []*domain.EquityPoint, riskFreeRate float64) float64 {
+	if len(curve) < 2 {
+		return 0
+	}
+
+	returns := make([]float64, 0, len(curve)-1)
+	for i := 1; i < len(curve); i++ {
+		prev := curve[i-1].Balance
+		if prev == 0 {
+			continue
+		}
+		returns = append(returns, (curve[i].Balance-prev)/prev-riskFreeRate)
+	}
+	if len(returns) == 0 {
+		return 0
+	}
+
+	var mean float64
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+
+	var variance float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	variance /= float64(len(returns))
+
+	stddev := math.Sqrt(variance)
+	if stddev == 0 {
+		return 0
+	}
+
+	return mean / stddev * math.Sqrt(365)
+}
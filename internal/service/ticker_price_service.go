@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"golang.org/x/time/rate"
+
+	"neurotrade/internal/metrics"
+)
+
+// Binance's public market-data endpoints tolerate a much higher rate than
+// order placement, but 5 rps keeps a busy ClosePosition fallback path from
+// ever being the thing that trips an IP ban.
+const (
+	tickerRateLimitRPS   = 5
+	tickerRateLimitBurst = 2
+)
+
+// tickerReconnectMinBackoff/MaxBackoff bound the jittered delay before
+// SubscribeTicker re-opens a single symbol's mark price stream after the
+// underlying websocket drops, matching the magnitude adapter.PriceStream
+// uses for its own reconnects.
+const (
+	tickerReconnectMinBackoff = 1 * time.Second
+	tickerReconnectMaxBackoff = 30 * time.Second
+)
+
+// tickerPrice is the cached value behind BinanceTickerPriceService's
+// sync.Map, stamped with the time it was received so GetLastPrice can judge
+// staleness.
+type tickerPrice struct {
+	price      float64
+	receivedAt time.Time
+}
+
+// BinanceTickerPriceService implements domain.TickerPriceService against
+// Binance USDT-M Futures: GetMarkPrice is a rate-limited REST call for a
+// one-off fresh read, SubscribeTicker keeps a cache warm for callers that
+// just want the last known price without waiting on the network.
+type BinanceTickerPriceService struct {
+	futures *futures.Client
+	limiter *rate.Limiter
+	last    sync.Map // symbol -> atomic.Value wrapping tickerPrice
+}
+
+// NewBinanceTickerPriceService creates a BinanceTickerPriceService. Mark
+// price and ticker streams are public market data, so no API credentials
+// are required.
+func NewBinanceTickerPriceService() *BinanceTickerPriceService {
+	return &BinanceTickerPriceService{
+		futures: futures.NewClient("", ""),
+		limiter: rate.NewLimiter(rate.Limit(tickerRateLimitRPS), tickerRateLimitBurst),
+	}
+}
+
+// GetMarkPrice fetches symbol's current mark price directly from Binance,
+// bypassing the ticker cache.
+func (s *BinanceTickerPriceService) GetMarkPrice(ctx context.Context, symbol string) (float64, error) {
+	if err := s.limiter.Wait(ctx); err != nil {
+		return 0, fmt.Errorf("binance: ticker rate limiter: %w", err)
+	}
+
+	result, err := s.futures.NewPremiumIndexService().Symbol(symbol).Do(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("binance: failed to fetch mark price for %s: %w", symbol, err)
+	}
+	if len(result) == 0 {
+		return 0, fmt.Errorf("binance: no mark price returned for %s", symbol)
+	}
+
+	price, err := strconv.ParseFloat(result[0].MarkPrice, 64)
+	if err != nil {
+		return 0, fmt.Errorf("binance: failed to parse mark price for %s: %w", symbol, err)
+	}
+
+	return price, nil
+}
+
+// GetLastPrice returns the most recent price SubscribeTicker cached for
+// symbol and how long ago it arrived. ok is false if symbol never ticked.
+func (s *BinanceTickerPriceService) GetLastPrice(symbol string) (price float64, age time.Duration, ok bool) {
+	v, found := s.last.Load(symbol)
+	if !found {
+		return 0, 0, false
+	}
+
+	tp := v.(*atomic.Value).Load().(tickerPrice)
+	return tp.price, time.Since(tp.receivedAt), true
+}
+
+// symbolStream owns one symbol's reconnect loop end to end in a single
+// goroutine (runSymbolStream), so stop/reconnect never race over who holds
+// the live connection's stopC -- only that one goroutine ever touches it.
+// stopOnce/stopCh request a shutdown; doneCh closes once the loop has
+// actually torn down its current connection and returned, so stop() can
+// block until teardown is genuinely finished.
+type symbolStream struct {
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+// SubscribeTicker opens one websocket mark-price stream per symbol and
+// coalesces updates into the last-price cache until stop is called. Each
+// symbol's stream reconnects itself with jittered exponential backoff if it
+// drops, so callers (e.g. PriceStream) only need to treat a prolonged gap in
+// GetLastPrice's age as "fall back to REST" rather than driving reconnects
+// themselves.
+func (s *BinanceTickerPriceService) SubscribeTicker(symbols []string) (stop func(), err error) {
+	var streams []*symbolStream
+
+	for _, symbol := range symbols {
+		symbol := symbol
+
+		v := &atomic.Value{}
+		s.last.Store(symbol, v)
+
+		stream := &symbolStream{stopCh: make(chan struct{}), doneCh: make(chan struct{})}
+		go s.runSymbolStream(symbol, v, stream)
+
+		streams = append(streams, stream)
+	}
+
+	return func() {
+		for _, st := range streams {
+			st.stop()
+		}
+	}, nil
+}
+
+// stop requests runSymbolStream's loop to exit and blocks until it has,
+// guaranteeing the underlying websocket connection is closed before stop
+// returns -- callers that tear down a batch of symbols and immediately
+// re-subscribe an overlapping set rely on this ordering.
+func (st *symbolStream) stop() {
+	st.stopOnce.Do(func() { close(st.stopCh) })
+	<-st.doneCh
+}
+
+// runSymbolStream owns symbol's entire connection lifecycle: connect, relay
+// ticks into v until the connection drops or stop() is requested, and
+// reconnect with jittered backoff on an unrequested drop. Running this as
+// the sole goroutine touching a given connection's stopC/doneC avoids any
+// handoff race between a live connection and a reconnect attempt.
+func (s *BinanceTickerPriceService) runSymbolStream(symbol string, v *atomic.Value, stream *symbolStream) {
+	defer close(stream.doneCh)
+
+	attempt := 0
+	for {
+		select {
+		case <-stream.stopCh:
+			return
+		default:
+		}
+
+		wsHandler := func(event *futures.WsMarkPriceEvent) {
+			price, err := strconv.ParseFloat(event.MarkPrice, 64)
+			if err != nil {
+				return
+			}
+			v.Store(tickerPrice{price: price, receivedAt: time.Now()})
+			metrics.PriceStreamMessagesTotal.Inc()
+		}
+
+		errHandler := func(err error) {
+			fmt.Printf("[WARN] binance mark price stream error for %s: %v\n", symbol, err)
+		}
+
+		doneC, stopC, err := futures.WsMarkPriceServe(symbol, wsHandler, errHandler)
+		if err != nil {
+			fmt.Printf("[WARN] binance mark price stream connect failed for %s: %v\n", symbol, err)
+			metrics.PriceStreamReconnectsTotal.Inc()
+			attempt++
+			if !sleepOrStop(stream.stopCh, tickerReconnectBackoff(attempt)) {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-stream.stopCh:
+			stopC <- struct{}{}
+			<-doneC
+			return
+		case <-doneC:
+			metrics.PriceStreamReconnectsTotal.Inc()
+			attempt++
+			if !sleepOrStop(stream.stopCh, tickerReconnectBackoff(attempt)) {
+				return
+			}
+		}
+	}
+}
+
+// sleepOrStop waits for d, returning false early (without having slept the
+// full duration) if stopCh fires first.
+func sleepOrStop(stopCh chan struct{}, d time.Duration) bool {
+	select {
+	case <-stopCh:
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// tickerReconnectBackoff returns a jittered delay before reconnect attempt
+// n, doubling from tickerReconnectMinBackoff up to tickerReconnectMaxBackoff.
+func tickerReconnectBackoff(attempt int) time.Duration {
+	ceiling := tickerReconnectMinBackoff << uint(attempt-1)
+	if ceiling <= 0 || ceiling > tickerReconnectMaxBackoff {
+		ceiling = tickerReconnectMaxBackoff
+	}
+	delay := tickerReconnectMinBackoff + time.Duration(rand.Int63n(int64(ceiling)))
+	if delay > tickerReconnectMaxBackoff {
+		delay = tickerReconnectMaxBackoff
+	}
+	return delay
+}
@@ -7,7 +7,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
+
 	"neurotrade/internal/domain"
+	"neurotrade/internal/exchange/binance"
+	"neurotrade/internal/notification"
 )
 
 const (
@@ -26,9 +30,54 @@ type VirtualBrokerService struct {
 	signalRepo          domain.SignalRepository
 	notificationService NotificationService
 	aiService           domain.AIService // Added for Real Trading Execution
+	orderLimiter        *OrderRateLimiter
+	cooldowns           *CooldownTracker
+
+	// gateway, when configured, replaces direct aiService.ExecuteClose calls
+	// with a rate-limited, retrying wrapper around it. Nil falls back to
+	// calling aiService.ExecuteClose directly, guarded only by orderLimiter.
+	gateway *ExecutionGateway
+
+	// futuresExchange, when configured, executes REAL-mode closes directly
+	// against Binance USDT-M Futures instead of going through aiService. Nil
+	// falls back to the pre-existing aiService.ExecuteClose path.
+	futuresExchange binance.FuturesExchange
+
+	// riskModel and klineProvider, when both configured, derive SL/TP from
+	// ATR for signals opened without their own explicit SL/TP. Either being
+	// nil falls back to using the signal's SL/TP unchanged.
+	riskModel     domain.RiskModel
+	klineProvider domain.KlineProvider
+
+	// instrumentRepo, when configured, rounds REAL-mode close quantities to
+	// the symbol's exchange precision before submission. Nil disables
+	// rounding, leaving sizes exactly as computed (the pre-existing
+	// behavior).
+	instrumentRepo domain.InstrumentRepository
+
+	// fundingService, when configured, accrues realized funding into each
+	// open position's FundingPaid before TP/SL is evaluated. Nil leaves
+	// FundingPaid at 0, so calculateNetPnL's NetPnL reduces to the
+	// pre-existing Gross-Fees formula.
+	fundingService *FundingService
+
+	// priceStream, when configured, serves CheckPositions' per-tick prices
+	// from a push-based Binance websocket subscription instead of always
+	// hitting REST. A symbol missing or stale in priceStream still falls
+	// back to priceService.FetchRealTimePrices, so a dropped/lagging socket
+	// degrades to the pre-existing polling behavior rather than stalling.
+	priceStream *PriceStream
 }
 
-// NewVirtualBrokerService creates a new VirtualBrokerService
+// NewVirtualBrokerService creates a new VirtualBrokerService. futuresExchange
+// may be nil, in which case REAL-mode closes keep using aiService. riskModel
+// and klineProvider may be nil, in which case OpenPositionWithRiskModel falls
+// back to the signal's own SL/TP. instrumentRepo may be nil, in which case
+// REAL-mode close sizes are submitted unrounded. gateway may be nil, in which
+// case ExecuteClose calls go straight to aiService. fundingService may be
+// nil, in which case positions never accrue funding (FundingPaid stays 0).
+// priceStream may be nil, in which case CheckPositions always fetches prices
+// over REST, exactly as before this field was added.
 func NewVirtualBrokerService(
 	positionRepo domain.PositionRepository,
 	userRepo domain.UserRepository,
@@ -36,6 +85,14 @@ func NewVirtualBrokerService(
 	signalRepo domain.SignalRepository,
 	notificationService NotificationService,
 	aiService domain.AIService, // Injected dependency
+	orderLimiter *OrderRateLimiter,
+	futuresExchange binance.FuturesExchange,
+	riskModel domain.RiskModel,
+	klineProvider domain.KlineProvider,
+	instrumentRepo domain.InstrumentRepository,
+	gateway *ExecutionGateway,
+	fundingService *FundingService,
+	priceStream *PriceStream,
 ) *VirtualBrokerService {
 	return &VirtualBrokerService{
 		positionRepo:        positionRepo,
@@ -44,9 +101,54 @@ func NewVirtualBrokerService(
 		signalRepo:          signalRepo,
 		notificationService: notificationService,
 		aiService:           aiService,
+		orderLimiter:        orderLimiter,
+		cooldowns:           NewCooldownTracker(),
+		futuresExchange:     futuresExchange,
+		riskModel:           riskModel,
+		klineProvider:       klineProvider,
+		instrumentRepo:      instrumentRepo,
+		gateway:             gateway,
+		fundingService:      fundingService,
+		priceStream:         priceStream,
 	}
 }
 
+// executeClose routes a REAL-mode ExecuteClose call through gateway when
+// configured (rate-limited, retried, and notified on exhausted failure),
+// falling back to calling aiService directly otherwise. params.PositionSide
+// is domain.PositionSideBoth/Long/Short, forwarded so the order lands under
+// the correct side in hedge mode -- see domain.Position.PositionSide.
+func (s *VirtualBrokerService) executeClose(ctx context.Context, params *domain.CloseParams) (*domain.ExecutionResult, error) {
+	if s.gateway != nil {
+		return s.gateway.ExecuteClose(ctx, params)
+	}
+	return s.aiService.ExecuteClose(ctx, params)
+}
+
+// roundCloseQuantity rounds qty down to symbol's exchange step size before a
+// REAL-mode close, and reports whether qty*price still clears the symbol's
+// MinNotional afterward. price is the pre-trade estimate (current/trigger
+// price), since market-order closes don't know their fill price until the
+// exchange returns one. When instrumentRepo isn't configured or its lookup
+// fails, qty passes through unrounded with ok true. ok is false only when
+// the rounded order would be rejected as under MinNotional, in which case
+// the caller should log and skip rather than submit a guaranteed-to-fail
+// order.
+func (s *VirtualBrokerService) roundCloseQuantity(ctx context.Context, symbol string, qty, price float64) (roundedQty float64, ok bool) {
+	if s.instrumentRepo == nil {
+		return qty, true
+	}
+
+	info, err := s.instrumentRepo.GetInstrumentInfo(ctx, symbol)
+	if err != nil {
+		log.Printf("[WARN] VirtualBroker: failed to load instrument info for %s, closing unrounded: %v", symbol, err)
+		return qty, true
+	}
+
+	roundedQty = info.RoundQuantity(qty)
+	return roundedQty, info.MeetsMinNotional(roundedQty, price)
+}
+
 // CheckPositions checks all open positions and closes them if TP/SL is hit
 func (s *VirtualBrokerService) CheckPositions(ctx context.Context) error {
 	// Get all open positions
@@ -61,6 +163,13 @@ func (s *VirtualBrokerService) CheckPositions(ctx context.Context) error {
 
 	log.Printf("Found %d open position(s)", len(positions))
 
+	// Accrue any funding windows crossed since the last tick before TP/SL is
+	// evaluated, so a closing position's displayed PnL already reflects
+	// funding paid/received while it was open.
+	if s.fundingService != nil {
+		s.fundingService.AccrueDue(ctx, positions)
+	}
+
 	// Extract unique symbols
 	symbolMap := make(map[string]bool)
 	for _, pos := range positions {
@@ -72,13 +181,36 @@ func (s *VirtualBrokerService) CheckPositions(ctx context.Context) error {
 		symbols = append(symbols, symbol)
 	}
 
-	// Fetch current prices
-	prices, err := s.priceService.FetchRealTimePrices(ctx, symbols)
-	if err != nil {
-		if strings.Contains(err.Error(), "missing prices") {
-			log.Printf("[WARN]  Partial Price Fetch: %v", err)
-		} else {
-			return fmt.Errorf("failed to fetch real-time prices: %w", err)
+	// Fetch current prices: prefer priceStream's push-based cache per
+	// symbol, falling back to the REST poller for whatever it doesn't have
+	// fresh -- so a dropped/lagging websocket degrades to the pre-existing
+	// all-REST behavior instead of stalling SL/TP checks.
+	prices := make(map[string]float64, len(symbols))
+	restSymbols := symbols
+	if s.priceStream != nil {
+		restSymbols = restSymbols[:0]
+		for _, symbol := range symbols {
+			if price, ok := s.priceStream.Latest(symbol); ok {
+				prices[symbol] = price
+				continue
+			}
+			restSymbols = append(restSymbols, symbol)
+		}
+	}
+
+	if len(restSymbols) > 0 {
+		restPrices, err := s.priceService.FetchRealTimePrices(ctx, restSymbols)
+		if err != nil {
+			if strings.Contains(err.Error(), "missing prices") {
+				log.Printf("[WARN]  Partial Price Fetch: %v", err)
+			} else if len(prices) == 0 {
+				return fmt.Errorf("failed to fetch real-time prices: %w", err)
+			} else {
+				log.Printf("[WARN] VirtualBroker: REST fallback price fetch failed, continuing with %d stream price(s): %v", len(prices), err)
+			}
+		}
+		for symbol, price := range restPrices {
+			prices[symbol] = price
 		}
 	}
 
@@ -90,6 +222,25 @@ func (s *VirtualBrokerService) CheckPositions(ctx context.Context) error {
 			continue
 		}
 
+		// Scaled take-profit: partially close whichever TP level currentPrice
+		// has just reached, before evaluating the full SL/TP below. The
+		// remaining (reduced) size is re-evaluated on the next tick instead
+		// of falling through to a full close in the same pass.
+		if idx, ok := position.NextTPLevel(currentPrice); ok {
+			if err := s.executePartialTP(ctx, position, idx, currentPrice); err != nil {
+				log.Printf("[ERR] VirtualBroker: partial TP for %s failed: %v", position.Symbol, err)
+			}
+			continue
+		}
+
+		// Flat percentage trailing stop: ratchets SLPrice off HighWaterMark/
+		// LowWaterMark, independent of the CheckSLTP comparison below.
+		if newSL, moved := position.UpdateTrailingStopPct(currentPrice); moved {
+			if err := s.positionRepo.UpdateTrailingMarkState(ctx, position.ID, newSL, position.HighWaterMark, position.LowWaterMark); err != nil {
+				log.Printf("[WARN] VirtualBroker: failed to persist trailing stop for %s: %v", position.Symbol, err)
+			}
+		}
+
 		// Check if TP or SL is hit
 		shouldClose, status, closedBy := position.CheckSLTP(currentPrice)
 		if !shouldClose {
@@ -108,24 +259,76 @@ func (s *VirtualBrokerService) CheckPositions(ctx context.Context) error {
 		// === REAL TRADING CLOSE LOGIC ===
 		exitPrice := currentPrice // Default to trigger price
 		if user.Mode == domain.ModeReal {
+			if user.InCooldown(time.Now()) {
+				log.Printf("[Broker] Skipping REAL CLOSE for %s: user %s in exchange cooldown until %s", position.Symbol, user.ID, user.TradeCooldownUntil)
+				continue
+			}
+
+			// Throttle outbound orders so many auto-trading users can't
+			// collectively trip Binance's -1003 TOO_MANY_REQUESTS ban
+			if err := s.orderLimiter.Wait(ctx); err != nil {
+				log.Printf("[ERR] VirtualBroker: order rate limiter: %v", err)
+				continue
+			}
+
 			// Determine opposite side
 			closeSide := "SELL"
 			if position.Side == "SHORT" {
 				closeSide = "BUY"
 			}
 
-			// Execute Real Close
-			res, err := s.aiService.ExecuteClose(ctx, position.Symbol, closeSide, position.Size)
-			if err != nil {
-				log.Printf("[ERR] VirtualBroker: FAILED to execute REAL CLOSE for %s: %v", position.Symbol, err)
-				continue // Don't close position in DB if execution failed
+			closeSize, notionalOK := s.roundCloseQuantity(ctx, position.Symbol, position.Size, currentPrice)
+			if !notionalOK {
+				log.Printf("[Broker] skipping REAL CLOSE for %s: rounded size %.8f @ %.4f is below MinNotional", position.Symbol, closeSize, currentPrice)
+				continue
+			}
+
+			// Execute Real Close. Prefer the direct Binance Futures adapter
+			// when configured; fall back to the legacy aiService path otherwise.
+			if s.futuresExchange != nil {
+				res, err := s.futuresExchange.ClosePosition(ctx, position.Symbol, position.Side, position.PositionSide, closeSize)
+				if err != nil {
+					if isExchangeThrottled(err) {
+						until := s.cooldowns.Escalate(user.ID, time.Now())
+						if cdErr := s.userRepo.SetTradeCooldownUntil(ctx, user.ID, until); cdErr != nil {
+							log.Printf("[WARN] VirtualBroker: failed to persist trade cooldown for %s: %v", user.ID, cdErr)
+						}
+						log.Printf("[Broker] exchange throttled user %s, cooling down until %s", user.ID, until)
+					}
+					log.Printf("[ERR] VirtualBroker: FAILED to execute REAL CLOSE for %s: %v", position.Symbol, err)
+					continue // Don't close position in DB if execution failed
+				}
+				s.cooldowns.Reset(user.ID)
+				exitPrice = res.ExitPrice
+				log.Printf("[Broker] REAL CLOSE SUCCESS: %s @ %.4f", position.Symbol, exitPrice)
+			} else {
+				res, err := s.executeClose(ctx, &domain.CloseParams{
+					Symbol:       position.Symbol,
+					Side:         closeSide,
+					PositionSide: position.PositionSide,
+					Quantity:     closeSize,
+					APIKey:       user.BinanceAPIKey,
+					APISecret:    user.BinanceAPISecret,
+				})
+				if err != nil {
+					if isExchangeThrottled(err) {
+						until := s.cooldowns.Escalate(user.ID, time.Now())
+						if cdErr := s.userRepo.SetTradeCooldownUntil(ctx, user.ID, until); cdErr != nil {
+							log.Printf("[WARN] VirtualBroker: failed to persist trade cooldown for %s: %v", user.ID, cdErr)
+						}
+						log.Printf("[Broker] exchange throttled user %s, cooling down until %s", user.ID, until)
+					}
+					log.Printf("[ERR] VirtualBroker: FAILED to execute REAL CLOSE for %s: %v", position.Symbol, err)
+					continue // Don't close position in DB if execution failed
+				}
+				s.cooldowns.Reset(user.ID)
+				exitPrice = res.AvgPrice // Use actual execution price
+				log.Printf("[Broker] REAL CLOSE SUCCESS: %s @ %.4f", position.Symbol, exitPrice)
 			}
-			exitPrice = res.AvgPrice // Use actual execution price
-			log.Printf("[Broker] REAL CLOSE SUCCESS: %s @ %.4f", position.Symbol, exitPrice)
 		}
 
 		// Calculate PnL with fees using Exit Price
-		netPnL := s.calculateNetPnL(position, exitPrice)
+		netPnL := s.calculateNetPnL(ctx, position, exitPrice)
 		pnlPercent := position.CalculatePnLPercent(exitPrice)
 
 		// Close position in DB
@@ -142,6 +345,10 @@ func (s *VirtualBrokerService) CheckPositions(ctx context.Context) error {
 			continue
 		}
 
+		if s.priceStream != nil {
+			s.priceStream.NotifyPositionsChanged()
+		}
+
 		// Update user balance (ONLY PAPER MODE)
 		if user.Mode == domain.ModePaper {
 			newBalance := user.PaperBalance + netPnL
@@ -166,16 +373,24 @@ func (s *VirtualBrokerService) CheckPositions(ctx context.Context) error {
 			if err := s.signalRepo.UpdateReviewStatus(ctx, *position.SignalID, reviewResult, &pnlPercent); err != nil {
 				log.Printf("WARNING: Failed to update signal review status: %v", err)
 			}
+		}
 
-			// Send Notification
-			if s.notificationService != nil {
+		// Send Notification
+		if s.notificationService != nil {
+			topic := notification.TopicPositionClosedWin
+			if reviewResult == "LOSS" {
+				topic = notification.TopicPositionClosedLoss
+			}
+			payload := notification.Payload{Symbol: position.Symbol, Side: position.Side, Price: exitPrice, PnL: &netPnL, FundingPaid: &position.FundingPaid}
+			if position.SignalID != nil {
 				if sig, err := s.signalRepo.GetByID(ctx, *position.SignalID); err == nil {
 					sig.ReviewResult = &reviewResult
-					if err := s.notificationService.SendReview(*sig, &netPnL); err != nil {
-						log.Printf("WARNING: Failed to send auto-close notification: %v", err)
-					}
+					payload.Signal = sig
 				}
 			}
+			if err := s.notificationService.Emit(ctx, topic, payload); err != nil {
+				log.Printf("WARNING: Failed to send auto-close notification: %v", err)
+			}
 		}
 
 		log.Printf("[OK] Position CLOSED: %s %s | Entry=%.2f Exit=%.2f | PnL=%.2f USDT | Status=%s",
@@ -185,32 +400,373 @@ func (s *VirtualBrokerService) CheckPositions(ctx context.Context) error {
 	return nil
 }
 
-// calculateNetPnL calculates net PnL after fees
+// HandleUserDataEvent reconciles a Binance USER_DATA fill event against the
+// matching open Position, writing back ExitPrice/PnL/ClosedBy. Only
+// ORDER_TRADE_UPDATE events carrying a ClosedBy (i.e. a filled reduce-only
+// SL/TP/close order) close anything; other events are ignored.
+func (s *VirtualBrokerService) HandleUserDataEvent(ctx context.Context, event binance.UserDataEvent) error {
+	if event.Type != binance.UserDataEventOrderTradeUpdate || event.ClosedBy == "" {
+		return nil
+	}
+
+	positions, err := s.positionRepo.GetOpenPositions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get open positions: %w", err)
+	}
+
+	for _, position := range positions {
+		if position.Symbol != event.Symbol {
+			continue
+		}
+
+		exitPrice := event.ExitPrice
+		netPnL := event.PnL
+		pnlPercent := position.CalculatePnLPercent(exitPrice)
+		closedBy := event.ClosedBy
+
+		now := time.Now()
+		position.ExitPrice = &exitPrice
+		position.PnL = &netPnL
+		position.PnLPercent = &pnlPercent
+		position.ClosedBy = &closedBy
+		position.ClosedAt = &now
+		if netPnL < 0 {
+			position.Status = domain.StatusClosedLoss
+		} else {
+			position.Status = domain.StatusClosedWin
+		}
+
+		if err := s.positionRepo.Update(ctx, position); err != nil {
+			return fmt.Errorf("failed to update position %s from user data event: %w", position.ID, err)
+		}
+
+		log.Printf("[Broker] USER_DATA CLOSE: %s %s | Exit=%.4f | PnL=%.2f USDT | ClosedBy=%s",
+			position.Symbol, position.Side, exitPrice, netPnL, closedBy)
+		return nil
+	}
+
+	log.Printf("[WARN] VirtualBroker: USER_DATA close for %s had no matching open position", event.Symbol)
+	return nil
+}
+
+// CloseAllLayers closes every open layer of a scaled-entry signal at
+// exitPrice in a single transaction (via positionRepo.UpdateBatch), so a
+// PanicButton/CloseAllPositions call against a layered signal can't leave
+// some layers closed and others open.
+func (s *VirtualBrokerService) CloseAllLayers(ctx context.Context, parentSignalID uuid.UUID, exitPrice float64, closedBy string) error {
+	layers, err := s.positionRepo.GetByParentSignalID(ctx, parentSignalID)
+	if err != nil {
+		return fmt.Errorf("failed to get layers for signal %s: %w", parentSignalID, err)
+	}
+
+	now := time.Now()
+	toClose := make([]*domain.Position, 0, len(layers))
+	for _, layer := range layers {
+		if layer.Status != domain.StatusOpen {
+			continue
+		}
+
+		netPnL := s.calculateNetPnL(ctx, layer, exitPrice)
+		pnlPercent := layer.CalculatePnLPercent(exitPrice)
+		status := domain.StatusClosedWin
+		if netPnL < 0 {
+			status = domain.StatusClosedLoss
+		}
+
+		layer.ExitPrice = &exitPrice
+		layer.PnL = &netPnL
+		layer.PnLPercent = &pnlPercent
+		layer.ClosedBy = &closedBy
+		layer.Status = status
+		layer.ClosedAt = &now
+		toClose = append(toClose, layer)
+	}
+
+	if len(toClose) == 0 {
+		return nil
+	}
+
+	if err := s.positionRepo.UpdateBatch(ctx, toClose); err != nil {
+		return fmt.Errorf("failed to close layers for signal %s: %w", parentSignalID, err)
+	}
+
+	log.Printf("[Broker] CLOSED %d layer(s) for signal %s @ %.4f", len(toClose), parentSignalID, exitPrice)
+	return nil
+}
+
+// OpenPositionWithRiskModel opens a REAL-mode position for a signal. If
+// slPrice/tpPrice are both 0 (the signal carried no explicit SL/TP) and a
+// riskModel+klineProvider are configured, SL/TP are instead derived from ATR
+// via riskModel.ComputeSLTP; the signal is skipped (nil, nil) if the
+// resulting range is too tight per the risk model's MinPriceRangePct.
+// SLTP_ATR_SIZING=true forces this ATR-based recalculation even when the
+// signal already carried an explicit SL/TP, so volatile symbols always get
+// volatility-scaled stops instead of whatever fixed-percent SL/TP arrived.
+// positionSide should be computed via domain.PositionSideForSide(user's
+// PositionMode, side) by the caller -- it is stored on the Position and
+// forwarded on REAL-mode closes, so a hedge-mode user's LONG and SHORT
+// positions on the same symbol close under their own sides. Nothing here
+// looks up an open position by (symbol, positionSide).
+func (s *VirtualBrokerService) OpenPositionWithRiskModel(ctx context.Context, signalID *uuid.UUID, userID uuid.UUID, symbol, side, positionSide string, entryPrice, slPrice, tpPrice, size, leverage float64) (*domain.Position, error) {
+	var entryATR *float64
+
+	atrSizing := getEnvBool("SLTP_ATR_SIZING", false)
+	if (atrSizing || (slPrice == 0 && tpPrice == 0)) && s.riskModel != nil && s.klineProvider != nil {
+		klines, err := s.klineProvider.GetKlines(ctx, symbol, "15m", 50)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch klines for %s: %w", symbol, err)
+		}
+
+		sl, tp, atr, ok := s.riskModel.ComputeSLTP(side, entryPrice, klines)
+		if !ok {
+			log.Printf("[Broker] Skipping %s %s: ATR range too tight to trade", symbol, side)
+			return nil, nil
+		}
+		slPrice, tpPrice, entryATR = sl, tp, &atr
+	}
+
+	position := &domain.Position{
+		ID:           uuid.New(),
+		UserID:       userID,
+		SignalID:     signalID,
+		Symbol:       symbol,
+		Side:         side,
+		PositionSide: positionSide,
+		EntryPrice:   entryPrice,
+		SLPrice:      slPrice,
+		TPPrice:      tpPrice,
+		Size:         size,
+		Leverage:     leverage,
+		Status:       domain.StatusOpen,
+		CreatedAt:    time.Now(),
+		EntryATR:     entryATR,
+	}
+
+	if err := s.positionRepo.Save(ctx, position); err != nil {
+		return nil, fmt.Errorf("failed to save position: %w", err)
+	}
+
+	if s.priceStream != nil {
+		s.priceStream.NotifyPositionsChanged()
+	}
+
+	return position, nil
+}
+
+// PanicCloseAll closes every open REAL-mode position for a user at its
+// current market price, grouping scaled-entry layers by ParentSignalID so
+// each signal's layers close atomically via CloseAllLayers rather than one
+// Update call per layer.
+func (s *VirtualBrokerService) PanicCloseAll(ctx context.Context, userID uuid.UUID) error {
+	positions, err := s.positionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get positions for user %s: %w", userID, err)
+	}
+
+	open := make([]*domain.Position, 0, len(positions))
+	symbolMap := make(map[string]bool)
+	for _, pos := range positions {
+		if pos.Status != domain.StatusOpen {
+			continue
+		}
+		open = append(open, pos)
+		symbolMap[pos.Symbol] = true
+	}
+
+	if len(open) == 0 {
+		return nil
+	}
+
+	symbols := make([]string, 0, len(symbolMap))
+	for symbol := range symbolMap {
+		symbols = append(symbols, symbol)
+	}
+
+	prices, err := s.priceService.FetchRealTimePrices(ctx, symbols)
+	if err != nil && !strings.Contains(err.Error(), "missing prices") {
+		return fmt.Errorf("failed to fetch real-time prices: %w", err)
+	}
+
+	grouped := make(map[uuid.UUID][]*domain.Position)
+	var standalone []*domain.Position
+	for _, pos := range open {
+		if pos.ParentSignalID != nil {
+			grouped[*pos.ParentSignalID] = append(grouped[*pos.ParentSignalID], pos)
+			continue
+		}
+		standalone = append(standalone, pos)
+	}
+
+	for parentSignalID, layers := range grouped {
+		price, ok := prices[layers[0].Symbol]
+		if !ok {
+			log.Printf("WARNING: Price not found for %s, skipping signal %s", layers[0].Symbol, parentSignalID)
+			continue
+		}
+		if err := s.CloseAllLayers(ctx, parentSignalID, price, domain.ClosedByManual); err != nil {
+			log.Printf("ERROR: Failed to panic-close layers for signal %s: %v", parentSignalID, err)
+		}
+	}
+
+	for _, position := range standalone {
+		price, ok := prices[position.Symbol]
+		if !ok {
+			log.Printf("WARNING: Price not found for %s, skipping position %s", position.Symbol, position.ID)
+			continue
+		}
+
+		netPnL := s.calculateNetPnL(ctx, position, price)
+		pnlPercent := position.CalculatePnLPercent(price)
+		status := domain.StatusClosedWin
+		if netPnL < 0 {
+			status = domain.StatusClosedLoss
+		}
+
+		now := time.Now()
+		closedBy := domain.ClosedByManual
+		position.ExitPrice = &price
+		position.PnL = &netPnL
+		position.PnLPercent = &pnlPercent
+		position.ClosedBy = &closedBy
+		position.Status = status
+		position.ClosedAt = &now
+
+		if err := s.positionRepo.Update(ctx, position); err != nil {
+			log.Printf("ERROR: Failed to panic-close position %s: %v", position.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// calculateNetPnL calculates a position's full realized net PnL after fees,
+// across the whole trade -- not just whatever size is still open.
 // Formula:
-// - GrossPnL = (ExitPrice - EntryPrice) * Size * (1 if Long, -1 if Short)
-// - EntryFee = Size * EntryPrice * 0.0005
-// - ExitFee = Size * ExitPrice * 0.0005
-// - NetPnL = GrossPnL - EntryFee - ExitFee
-func (s *VirtualBrokerService) calculateNetPnL(position *domain.Position, exitPrice float64) float64 {
-	// Calculate gross PnL
+//   - GrossPnL = sum over (each partial fill + the final exit) of
+//     (ExitPrice - EntryPrice) * ExitSize * (1 if Long, -1 if Short)
+//   - EntryFee = TotalFilledSize * EntryPrice * 0.0004 (taker, on every unit
+//     that ever filled, not just what's left open)
+//   - ExitFee = sum over (each partial fill + the final exit) of
+//     ExitSize * ExitPrice * 0.0004
+//   - NetPnL = GrossPnL - EntryFee - sum(ExitFee) - FundingPaid
+//
+// Positions without any scaled take-profit (the common case) reduce to the
+// original single-exit formula, since GetPartialFills returns nothing.
+// FundingPaid is whatever FundingService.AccrueDue has already accrued onto
+// the position (0 when fundingService isn't configured).
+func (s *VirtualBrokerService) calculateNetPnL(ctx context.Context, position *domain.Position, exitPrice float64) float64 {
+	feeRate := TradingFeeTakerPercent / 100.0 // Convert 0.04% to 0.0004
+
+	// Remaining size's gross PnL/fee at the final exit price
 	grossPnL := position.CalculateGrossPnL(exitPrice)
+	totalFees := position.Size * exitPrice * feeRate
+	totalFilledSize := position.Size
 
-	// Calculate fees using Binance Futures taker fee (0.04% for market orders)
-	// Both entry and exit are market orders, so use taker fee
-	feeRate := TradingFeeTakerPercent / 100.0 // Convert 0.04% to 0.0004
-	entryFee := position.Size * position.EntryPrice * feeRate
-	exitFee := position.Size * exitPrice * feeRate
-	totalFees := entryFee + exitFee
+	fills, err := s.positionRepo.GetPartialFills(ctx, position.ID)
+	if err != nil {
+		log.Printf("[WARN] VirtualBroker: failed to load partial fills for %s: %v", position.Symbol, err)
+	}
+	for _, fill := range fills {
+		fillPnL := (fill.Price - position.EntryPrice) * fill.Size
+		if !position.IsLong() {
+			fillPnL = (position.EntryPrice - fill.Price) * fill.Size
+		}
+		grossPnL += fillPnL
+		totalFees += fill.Fee
+		totalFilledSize += fill.Size
+	}
 
-	// Net PnL = Gross PnL - Fees
-	netPnL := grossPnL - totalFees
+	entryFee := totalFilledSize * position.EntryPrice * feeRate
+	totalFees += entryFee
+
+	netPnL := grossPnL - totalFees - position.FundingPaid
 
 	log.Printf("   PnL Calculation for %s:", position.Symbol)
-	log.Printf("   - Gross PnL: %.4f USDT", grossPnL)
-	log.Printf("   - Entry Fee (0.04%% taker): %.4f USDT", entryFee)
-	log.Printf("   - Exit Fee (0.04%% taker): %.4f USDT", exitFee)
+	log.Printf("   - Gross PnL (incl. %d partial fill(s)): %.4f USDT", len(fills), grossPnL)
+	log.Printf("   - Entry Fee (0.04%% taker, %.6f total filled size): %.4f USDT", totalFilledSize, entryFee)
 	log.Printf("   - Total Fees: %.4f USDT", totalFees)
+	log.Printf("   - Funding Paid: %.4f USDT", position.FundingPaid)
 	log.Printf("   - Net PnL: %.4f USDT", netPnL)
 
 	return netPnL
 }
+
+// executePartialTP closes Size*TPLevels[idx].SizeFraction of position at
+// currentPrice: in REAL mode the reduce is executed via aiService.ExecuteClose
+// first so a failure there leaves Size/TPLevels untouched for a retry next
+// tick; the resulting exit is then persisted as a PartialFill (for
+// calculateNetPnL) and the level is marked Filled with Size reduced via
+// UpdateTPLevelState.
+func (s *VirtualBrokerService) executePartialTP(ctx context.Context, position *domain.Position, idx int, currentPrice float64) error {
+	level := position.TPLevels[idx]
+	closeSize := position.Size * level.SizeFraction
+	if closeSize <= 0 {
+		return nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, position.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user %s: %w", position.UserID, err)
+	}
+
+	exitPrice := currentPrice
+	if user.Mode == domain.ModeReal {
+		closeSide := "SELL"
+		if position.Side == domain.SideShort {
+			closeSide = "BUY"
+		}
+
+		roundedSize, notionalOK := s.roundCloseQuantity(ctx, position.Symbol, closeSize, currentPrice)
+		if !notionalOK {
+			log.Printf("[Broker] skipping partial TP for %s: rounded size %.8f @ %.4f is below MinNotional", position.Symbol, roundedSize, currentPrice)
+			return nil
+		}
+		closeSize = roundedSize
+
+		res, err := s.executeClose(ctx, &domain.CloseParams{
+			Symbol:       position.Symbol,
+			Side:         closeSide,
+			PositionSide: position.PositionSide,
+			Quantity:     closeSize,
+			APIKey:       user.BinanceAPIKey,
+			APISecret:    user.BinanceAPISecret,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to execute partial TP close: %w", err)
+		}
+		exitPrice = res.AvgPrice
+	}
+
+	feeRate := TradingFeeTakerPercent / 100.0
+	fee := closeSize * exitPrice * feeRate
+
+	position.TPLevels[idx].Filled = true
+	position.Size -= closeSize
+
+	if err := s.positionRepo.UpdateTPLevelState(ctx, position.ID, position.TPLevels, position.Size); err != nil {
+		return fmt.Errorf("failed to persist partial TP: %w", err)
+	}
+
+	fill := &domain.PartialFill{
+		ID:         uuid.New(),
+		PositionID: position.ID,
+		Price:      exitPrice,
+		Size:       closeSize,
+		Fee:        fee,
+		ClosedAt:   time.Now(),
+	}
+	if err := s.positionRepo.SavePartialFill(ctx, fill); err != nil {
+		log.Printf("[WARN] VirtualBroker: failed to record partial fill for %s: %v", position.Symbol, err)
+	}
+
+	log.Printf("[Broker] PARTIAL TP %d/%d: %s %s closed %.6f @ %.4f", idx+1, len(position.TPLevels), position.Symbol, position.Side, closeSize, exitPrice)
+
+	if s.notificationService != nil {
+		detail := fmt.Sprintf("Partial TP %d/%d", idx+1, len(position.TPLevels))
+		if err := s.notificationService.Emit(ctx, notification.TopicPositionClosedWin, notification.Payload{Symbol: position.Symbol, Side: position.Side, Price: exitPrice, Detail: detail}); err != nil {
+			log.Printf("[WARN] VirtualBroker: failed to send partial TP notification: %v", err)
+		}
+	}
+
+	return nil
+}
@@ -9,44 +9,139 @@ import (
 	"github.com/google/uuid"
 
 	"neurotrade/internal/domain"
+	"neurotrade/internal/notification"
+	"neurotrade/internal/reliability"
 )
 
-// NotificationService defines the interface for sending notifications
+// NotificationService is the narrow interface this package needs from
+// notification.Router (mirrors service.NotificationService's same narrow
+// Emit-only shape).
 type NotificationService interface {
-	SendSignal(signal domain.Signal) error
-	SendReview(signal domain.Signal) error
+	Emit(ctx context.Context, topic notification.Topic, payload notification.Payload) error
 }
 
+// RealPositionOpener opens a REAL-mode position for a signal, mirroring the
+// PAPER-mode auto-open below but against the live broker. Satisfied by
+// *service.VirtualBrokerService; kept as a narrow interface here so
+// TradingService doesn't need to depend on the whole service package.
+type RealPositionOpener interface {
+	OpenPositionWithRiskModel(ctx context.Context, signalID *uuid.UUID, userID uuid.UUID, symbol, side, positionSide string, entryPrice, slPrice, tpPrice, size, leverage float64) (*domain.Position, error)
+}
+
+// StrategyModeProvider exposes the global SCALPER/INVESTOR trading mode, so
+// ProcessMarketScan can filter signals against each user's
+// SignalSubscriptions. Satisfied by *repository.SystemSettingsRepository.
+type StrategyModeProvider interface {
+	GetTradingMode(ctx context.Context) (string, error)
+}
+
+// StatsRecorder feeds a closed trade's realized PnL into per-user rolling
+// performance stats. Satisfied by *service.StatsAggregator; kept narrow so
+// TradingService doesn't need to depend on the whole service package.
+type StatsRecorder interface {
+	RecordTrade(ctx context.Context, userID uuid.UUID, symbol string, pnl, balanceAfter float64) error
+}
+
+// HedgeOpener opens/closes the opposite-side live hedge leg for a
+// hedge-enabled user's paper position. Satisfied by *service.HedgeService;
+// kept narrow so TradingService doesn't need to depend on the whole service
+// package.
+type HedgeOpener interface {
+	OpenHedge(ctx context.Context, paper *domain.PaperPosition) error
+	CloseHedge(ctx context.Context, paperPositionID uuid.UUID) error
+}
+
+// defaultStrategyMode is used when strategyModeProvider is nil or its lookup
+// fails, matching SystemSettingsRepository.GetTradingMode's own default.
+const defaultStrategyMode = "SCALPER"
+
+// defaultStaleTickTolerance is how old a cached ticker price can be before
+// ClosePosition refuses to trust it and falls back to EntryPrice.
+const defaultStaleTickTolerance = 10 * time.Second
+
 // TradingService handles core trading logic
 type TradingService struct {
-	aiService           domain.AIService
-	signalRepo          domain.SignalRepository
-	positionRepo        domain.PaperPositionRepository
-	userRepo            domain.UserRepository
-	notificationService NotificationService
-	minConfidence       int
-	defaultUserID       uuid.UUID // For Phase 3, we'll use a default user (later will be per-user)
+	aiService              domain.AIService
+	signalRepo             domain.SignalRepository
+	positionRepo           domain.PaperPositionRepository
+	userRepo               domain.UserRepository
+	notificationService    NotificationService
+	tickerPriceService     domain.TickerPriceService
+	realPositionOpener     RealPositionOpener
+	signalSubscriptionRepo domain.SignalSubscriptionRepository
+	strategyModeProvider   StrategyModeProvider
+	statsRecorder          StatsRecorder
+	hedgeOpener            HedgeOpener
+	staleTickTolerance     time.Duration
+
+	// instrumentRepo, when configured, rounds a signal's EntryPrice/SLPrice/
+	// TPPrice to the symbol's exchange precision before it's persisted. Nil
+	// disables rounding, leaving prices exactly as the AI engine returned
+	// them (the pre-existing behavior).
+	instrumentRepo domain.InstrumentRepository
 }
 
-// NewTradingService creates a new TradingService
+// NewTradingService creates a new TradingService. instrumentRepo may be nil,
+// in which case signal prices are persisted unrounded.
 func NewTradingService(
 	aiService domain.AIService,
 	signalRepo domain.SignalRepository,
 	positionRepo domain.PaperPositionRepository,
 	userRepo domain.UserRepository,
 	notificationService NotificationService,
-	minConfidence int,
-	defaultUserID uuid.UUID,
+	tickerPriceService domain.TickerPriceService,
+	realPositionOpener RealPositionOpener,
+	signalSubscriptionRepo domain.SignalSubscriptionRepository,
+	strategyModeProvider StrategyModeProvider,
+	statsRecorder StatsRecorder,
+	hedgeOpener HedgeOpener,
+	instrumentRepo domain.InstrumentRepository,
 ) *TradingService {
 	return &TradingService{
-		aiService:           aiService,
-		signalRepo:          signalRepo,
-		positionRepo:        positionRepo,
-		userRepo:            userRepo,
-		notificationService: notificationService,
-		minConfidence:       minConfidence,
-		defaultUserID:       defaultUserID,
+		aiService:              aiService,
+		signalRepo:             signalRepo,
+		positionRepo:           positionRepo,
+		userRepo:               userRepo,
+		notificationService:    notificationService,
+		tickerPriceService:     tickerPriceService,
+		realPositionOpener:     realPositionOpener,
+		signalSubscriptionRepo: signalSubscriptionRepo,
+		strategyModeProvider:   strategyModeProvider,
+		statsRecorder:          statsRecorder,
+		hedgeOpener:            hedgeOpener,
+		staleTickTolerance:     defaultStaleTickTolerance,
+		instrumentRepo:         instrumentRepo,
+	}
+}
+
+// roundSignalPrices rounds signal's EntryPrice/SLPrice/TPPrice to symbol's
+// exchange tick size before it's persisted, so a REAL-mode auto-open can
+// submit these as valid limit prices without a separate rounding pass.
+// EntryPrice rounds toward the side that opens the position (buy up for
+// LONG, sell down for SHORT); SLPrice/TPPrice round toward the side that
+// would close it (the opposite of EntryPrice's). instrumentRepo being nil,
+// or its lookup failing, leaves signal unrounded.
+func (ts *TradingService) roundSignalPrices(ctx context.Context, signal *domain.Signal) {
+	if ts.instrumentRepo == nil {
+		return
+	}
+
+	info, err := ts.instrumentRepo.GetInstrumentInfo(ctx, signal.Symbol)
+	if err != nil {
+		log.Printf("WARNING: failed to load instrument info for %s, saving signal unrounded: %v", signal.Symbol, err)
+		return
+	}
+
+	if signal.Type == domain.SideShort {
+		signal.EntryPrice = info.RoundSellPrice(signal.EntryPrice)
+		signal.SLPrice = info.RoundBuyPrice(signal.SLPrice)
+		signal.TPPrice = info.RoundBuyPrice(signal.TPPrice)
+		return
 	}
+
+	signal.EntryPrice = info.RoundBuyPrice(signal.EntryPrice)
+	signal.SLPrice = info.RoundSellPrice(signal.SLPrice)
+	signal.TPPrice = info.RoundSellPrice(signal.TPPrice)
 }
 
 // ProcessMarketScan performs a complete market scan and saves high-confidence signals
@@ -55,15 +150,25 @@ func (ts *TradingService) ProcessMarketScan(ctx context.Context, balance float64
 	startTime := time.Now()
 
 	// Step 1: Call Python AI Engine to analyze market
+	strategy := ts.currentStrategyMode(ctx)
+
 	log.Println("Calling Python AI Engine for market analysis...")
-	aiSignals, err := ts.aiService.AnalyzeMarket(ctx, balance)
+	aiSignals, err := ts.aiService.AnalyzeMarket(ctx, balance, strategy)
 	if err != nil {
 		return fmt.Errorf("failed to analyze market: %w", err)
 	}
 
 	log.Printf("Received %d signals from AI Engine", len(aiSignals))
 
-	// Step 2: Process each signal
+	// Step 2: Fetch the active traders to fan signals out to, instead of one
+	// shared defaultUserID - each trader filters and sizes independently.
+	traders, err := ts.userRepo.GetActiveTraders(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get active traders: %w", err)
+	}
+	log.Printf("Fanning out to %d active trader(s)", len(traders))
+
+	// Step 3: Process each signal
 	savedCount := 0
 	for _, aiSignal := range aiSignals {
 		// Skip WAIT signals (not actionable)
@@ -72,24 +177,20 @@ func (ts *TradingService) ProcessMarketScan(ctx context.Context, balance float64
 			continue
 		}
 
-		// Check confidence threshold
-		if aiSignal.CombinedConfidence < ts.minConfidence {
-			log.Printf("Skipping %s: confidence %d%% below threshold %d%%",
-				aiSignal.Symbol, aiSignal.CombinedConfidence, ts.minConfidence)
-			continue
-		}
-
 		// Create domain signal
 		signal := ts.convertAISignalToDomain(aiSignal)
+		ts.roundSignalPrices(ctx, signal)
 
 		// Save signal to database
 		if err := ts.signalRepo.Save(ctx, signal); err != nil {
 			log.Printf("ERROR: Failed to save signal for %s: %v", aiSignal.Symbol, err)
+			reliability.SignalSavedTotal.WithLabelValues("error").Inc()
 			continue
 		}
+		reliability.SignalSavedTotal.WithLabelValues("success").Inc()
 
 		// Log success
-		log.Printf("âœ“ Saved High Confidence Signal: %s | %s | Confidence: %d%% | Entry: %.4f | SL: %.4f | TP: %.4f",
+		log.Printf("âœ“ Saved Signal: %s | %s | Confidence: %d%% | Entry: %.4f | SL: %.4f | TP: %.4f",
 			signal.Symbol,
 			signal.Type,
 			signal.Confidence,
@@ -98,33 +199,83 @@ func (ts *TradingService) ProcessMarketScan(ctx context.Context, balance float64
 			signal.TPPrice,
 		)
 
-		// Send Telegram notification
+		// Send notification
 		if ts.notificationService != nil {
-			if err := ts.notificationService.SendSignal(*signal); err != nil {
-				log.Printf("WARNING: Failed to send Telegram notification: %v", err)
+			if err := ts.notificationService.Emit(ctx, notification.TopicNewSignal, notification.Payload{Signal: signal}); err != nil {
+				log.Printf("WARNING: Failed to send notification: %v", err)
 			}
 		}
 
-		// Auto-create paper position for this signal
-		if err := ts.createPaperPosition(ctx, signal, aiSignal.TradeParams, balance); err != nil {
-			log.Printf("WARNING: Failed to create paper position for %s: %v", signal.Symbol, err)
-			// Don't stop - signal is already saved
-		}
-
 		savedCount++
+
+		// Auto-open a position for this signal on behalf of every active
+		// trader who is subscribed and above their own confidence threshold:
+		// PAPER simulates it locally, REAL routes it to the live broker.
+		for _, user := range traders {
+			if aiSignal.CombinedConfidence < user.MinConfidence {
+				log.Printf("Skipping %s for %s: confidence %d%% below their threshold %d%%",
+					signal.Symbol, user.Username, aiSignal.CombinedConfidence, user.MinConfidence)
+				continue
+			}
+
+			if !ts.userAllowsSignal(ctx, user.ID, signal.Symbol, strategy) {
+				log.Printf("Skipping %s for %s: not subscribed to this symbol/strategy", signal.Symbol, user.Username)
+				continue
+			}
+
+			if err := ts.openPositionForUser(ctx, user, signal, aiSignal.TradeParams); err != nil {
+				log.Printf("WARNING: Failed to open position for %s on %s: %v", user.Username, signal.Symbol, err)
+				// Don't stop - signal is already saved, other traders still get a turn
+			}
+		}
 	}
 
-	// Step 3: Log summary
+	// Step 4: Log summary
 	elapsed := time.Since(startTime)
 	log.Println("=== Market Scan Complete ===")
 	log.Printf("Total AI Signals: %d", len(aiSignals))
 	log.Printf("Saved Signals: %d", savedCount)
+	log.Printf("Active Traders: %d", len(traders))
 	log.Printf("Execution Time: %.2f seconds", elapsed.Seconds())
 	log.Println("===========================")
 
 	return nil
 }
 
+// currentStrategyMode returns the global SCALPER/INVESTOR mode used to match
+// SignalSubscriptions, falling back to defaultStrategyMode if
+// strategyModeProvider isn't wired up or its lookup fails.
+func (ts *TradingService) currentStrategyMode(ctx context.Context) string {
+	if ts.strategyModeProvider == nil {
+		return defaultStrategyMode
+	}
+
+	mode, err := ts.strategyModeProvider.GetTradingMode(ctx)
+	if err != nil {
+		log.Printf("WARNING: Failed to get trading mode, defaulting to %s: %v", defaultStrategyMode, err)
+		return defaultStrategyMode
+	}
+
+	return mode
+}
+
+// userAllowsSignal reports whether userID should receive a signal for
+// symbol/strategy, per their SignalSubscriptions. signalSubscriptionRepo may
+// be nil in tests/partial wiring, in which case every signal is allowed.
+func (ts *TradingService) userAllowsSignal(ctx context.Context, userID uuid.UUID, symbol, strategy string) bool {
+	if ts.signalSubscriptionRepo == nil {
+		return true
+	}
+
+	subs, err := ts.signalSubscriptionRepo.GetForUser(ctx, userID)
+	if err != nil {
+		log.Printf("WARNING: Failed to get signal subscriptions for user %s, allowing signal: %v", userID, err)
+		return true
+	}
+
+	return domain.AllowsSignal(subs, symbol, strategy)
+}
+
 // convertAISignalToDomain converts AI signal response to domain signal
 func (ts *TradingService) convertAISignalToDomain(aiSignal *domain.AISignalResponse) *domain.Signal {
 	signal := &domain.Signal{
@@ -163,8 +314,12 @@ func (ts *TradingService) GetSignalsBySymbol(ctx context.Context, symbol string,
 	return ts.signalRepo.GetBySymbol(ctx, symbol, limit)
 }
 
-// createPaperPosition automatically creates a paper trading position for a high-confidence signal
-func (ts *TradingService) createPaperPosition(ctx context.Context, signal *domain.Signal, tradeParams *domain.TradeParams, balance float64) error {
+// openPositionForUser auto-opens a position for a single active trader
+// against a signal: PAPER simulates it locally, REAL routes it to
+// realPositionOpener (the live broker). ModeReal with no realPositionOpener
+// configured is a no-op, same as the pre-existing ModePaper-only behavior
+// was for any other mode.
+func (ts *TradingService) openPositionForUser(ctx context.Context, user *domain.User, signal *domain.Signal, tradeParams *domain.TradeParams) error {
 	if tradeParams == nil {
 		return fmt.Errorf("trade params not available")
 	}
@@ -173,22 +328,6 @@ func (ts *TradingService) createPaperPosition(ctx context.Context, signal *domai
 		return fmt.Errorf("invalid entry price: %.4f", signal.EntryPrice)
 	}
 
-	// Get user to check if they're in PAPER mode
-	if ts.defaultUserID == uuid.Nil {
-		return fmt.Errorf("default user ID is not set (system initialization issue)")
-	}
-
-	user, err := ts.userRepo.GetByID(ctx, ts.defaultUserID)
-	if err != nil {
-		return fmt.Errorf("failed to get default user (%s): %w", ts.defaultUserID, err)
-	}
-
-	// Only create position if user is in PAPER mode
-	if user.Mode != domain.ModePaper {
-		log.Printf("Skipping paper position creation: user is in %s mode", user.Mode)
-		return nil
-	}
-
 	// Determine position side based on signal type
 	var side string
 	if signal.Type == "LONG" {
@@ -199,9 +338,33 @@ func (ts *TradingService) createPaperPosition(ctx context.Context, signal *domai
 		return fmt.Errorf("invalid signal type: %s", signal.Type)
 	}
 
-	// Calculate position size in base asset (BTC, ETH, etc.)
-	// Size = PositionSizeUSDT / EntryPrice
-	positionSize := tradeParams.PositionSizeUSDT / signal.EntryPrice
+	// Size the position off this user's own balance/percent rather than the
+	// AI engine's shared suggestion, falling back to it if unset.
+	positionSizeUSDT := tradeParams.PositionSizeUSDT
+	if user.PositionSizePercent > 0 {
+		positionSizeUSDT = user.PaperBalance * user.PositionSizePercent / 100
+	}
+	positionSize := positionSizeUSDT / signal.EntryPrice
+
+	if user.Mode == domain.ModeReal {
+		return ts.createRealPosition(ctx, user, signal, side, positionSize)
+	}
+
+	if user.Mode != domain.ModePaper {
+		log.Printf("Skipping auto-open: user is in %s mode", user.Mode)
+		return nil
+	}
+
+	if user.MaxConcurrentPositions > 0 {
+		openCount, err := ts.countOpenPositions(ctx, user.ID)
+		if err != nil {
+			return fmt.Errorf("failed to count open positions: %w", err)
+		}
+		if openCount >= user.MaxConcurrentPositions {
+			log.Printf("Skipping auto-open for %s: already at max concurrent positions (%d)", user.Username, user.MaxConcurrentPositions)
+			return nil
+		}
+	}
 
 	// Create paper position
 	position := &domain.PaperPosition{
@@ -226,6 +389,64 @@ func (ts *TradingService) createPaperPosition(ctx context.Context, signal *domai
 	log.Printf("ðŸŽ¯ Auto-created Paper Position: %s %s | Size: %.6f | Entry: %.4f",
 		position.Symbol, position.Side, position.Size, position.EntryPrice)
 
+	// Cross-exchange hedge mode is opt-in per user (see service.HedgeService)
+	if user.HedgeEnabled && ts.hedgeOpener != nil {
+		if err := ts.hedgeOpener.OpenHedge(ctx, position); err != nil {
+			log.Printf("WARNING: Failed to open hedge for position %s: %v", position.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// countOpenPositions counts a user's open paper positions, for enforcing
+// MaxConcurrentPositions. PaperPositionRepository has no dedicated count
+// method, so this filters GetByUserID's results.
+func (ts *TradingService) countOpenPositions(ctx context.Context, userID uuid.UUID) (int, error) {
+	positions, err := ts.positionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, position := range positions {
+		if position.Status == domain.StatusOpen {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// createRealPosition routes a signal to the live broker for a REAL-mode
+// user. No-op (logged) if realPositionOpener hasn't been wired up, the same
+// posture futuresExchange/riskModel take elsewhere in this codebase for
+// optional REAL-mode dependencies.
+func (ts *TradingService) createRealPosition(ctx context.Context, user *domain.User, signal *domain.Signal, side string, positionSize float64) error {
+	if ts.realPositionOpener == nil {
+		log.Printf("Skipping REAL position open for %s: no realPositionOpener configured", signal.Symbol)
+		return nil
+	}
+
+	positionSide := domain.PositionSideForSide(user.PositionMode, side)
+
+	position, err := ts.realPositionOpener.OpenPositionWithRiskModel(
+		ctx, &signal.ID, user.ID, signal.Symbol, side, positionSide,
+		signal.EntryPrice, signal.SLPrice, signal.TPPrice, positionSize, user.Leverage,
+	)
+	if err != nil {
+		if ts.notificationService != nil {
+			ts.notificationService.Emit(ctx, notification.TopicRealTradeFailed, notification.Payload{Symbol: signal.Symbol, Side: side, Detail: err.Error()})
+		}
+		return fmt.Errorf("failed to open real position: %w", err)
+	}
+
+	log.Printf("ðŸŽ¯ Auto-opened REAL Position: %s %s | Size: %.6f | Entry: %.4f",
+		position.Symbol, position.Side, position.Size, position.EntryPrice)
+
+	if ts.notificationService != nil {
+		ts.notificationService.Emit(ctx, notification.TopicPositionOpened, notification.Payload{Symbol: position.Symbol, Side: position.Side, Price: position.EntryPrice})
+	}
+
 	return nil
 }
 
@@ -247,27 +468,38 @@ func (ts *TradingService) ClosePosition(ctx context.Context, positionID uuid.UUI
 		return fmt.Errorf("position is already closed")
 	}
 
-	// Calculate PnL similar to Panic Button but for single position
-	// ideally we should fetch real price here, but for now we'll simulate or use last known
-	// For manual close, we really should try to get the real price if possible.
-	// However, TradingService doesn't have direct access to MarketPriceService (it's in handlers/services).
-	// We'll assume the handler passes the current price or we accept a slight lag/simulated execution.
-	// To keep it safe and simple for this "Panic/Manual" close, we'll use similar logic to Panic:
-	// If we can't fetch price, we might use EntryPrice (break even) or logic from VirtualBroker.
-	// BUT wait, TradingService has `aiService` but not `priceService`.
-	// Let's rely on the handler to likely not have passed price, so we might need to assume a price
-	// or update TradingService to have price access.
-	// Given the constraints and existing code, we will implement a "Force Close" using Entry Price
-	// (or just mark as ClosedManual to be processed? No, user wants immediate PnL).
-	// Let's use EntryPrice for now as "Emergency Close" logic if we can't get price,
-	// OR arguably better: WE SHOULD inject PriceService into TradingService?
-	// User asked to check for "logic errors". Using EntryPrice for manual close is a logic error (0 PnL).
-	// Let's stick to the styling of CloseAllPositions for consistency for now,
-	// but add a TODO or note. Actually, let's look at CloseAllPositions... it uses EntryPrice!
-	// "For panic button, we use entry price as exit (worst case scenario)" -> This is indeed suboptimal but safe.
-
-	exitPrice := position.EntryPrice
+	// Unwind any hedge leg before closing the paper position itself, so a
+	// failure here aborts the close instead of leaving a naked hedge behind
+	// (see service.HedgeService).
+	if ts.hedgeOpener != nil {
+		if err := ts.hedgeOpener.CloseHedge(ctx, position.ID); err != nil {
+			return fmt.Errorf("failed to close hedge leg: %w", err)
+		}
+	}
+
+	// Prefer a fresh mark price over the ticker cache over EntryPrice, in
+	// that order, so a manual/panic close doesn't silently realize ~0 PnL
+	// just because the network hiccuped.
+	exitPrice, status := ts.resolveExitPrice(ctx, position.Symbol, position.EntryPrice)
+
+	pnl, err := ts.finalizeClose(ctx, position, exitPrice, status)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("âœ“ Manually Closed position %s %s | PnL: %.2f USDT", position.Symbol, position.Side, pnl)
+	return nil
+}
 
+// finalizeClose realizes PnL against exitPrice, persists the closed
+// position, updates the user's paper balance, records profit stats, and
+// sends the win/loss notification. An empty status has finalizeClose decide
+// between StatusClosedWin/StatusClosedLoss itself based on the sign of
+// realized PnL; callers that already know the status (ClosePosition's
+// manual/panic paths, always StatusClosedManual/StatusClosedEmergency) pass
+// it explicitly instead. Shared so MonitorOpenPositions' trailing-stop path
+// doesn't have to duplicate ClosePosition's accounting.
+func (ts *TradingService) finalizeClose(ctx context.Context, position *domain.PaperPosition, exitPrice float64, status string) (float64, error) {
 	var pnl float64
 	if position.Side == domain.SideLong {
 		pnl = (exitPrice - position.EntryPrice) * position.Size
@@ -281,55 +513,83 @@ func (ts *TradingService) ClosePosition(ctx context.Context, positionID uuid.UUI
 	exitFee := position.Size * exitPrice * feeRate
 	pnl = pnl - entryFee - exitFee
 
+	if status == "" {
+		if pnl >= 0 {
+			status = domain.StatusClosedWin
+		} else {
+			status = domain.StatusClosedLoss
+		}
+	}
+
 	now := time.Now()
 	position.ExitPrice = &exitPrice
 	position.PnL = &pnl
-	position.Status = domain.StatusClosedManual
+	position.Status = status
 	position.ClosedAt = &now
 
 	if err := ts.positionRepo.Update(ctx, position); err != nil {
-		return fmt.Errorf("failed to update position: %w", err)
+		return 0, fmt.Errorf("failed to update position: %w", err)
 	}
 
 	// Update user balance
 	user, err := ts.userRepo.GetByID(ctx, position.UserID)
 	if err != nil {
-		return fmt.Errorf("failed to get user: %w", err)
+		return 0, fmt.Errorf("failed to get user: %w", err)
 	}
 
 	newBalance := user.PaperBalance + pnl
 	if err := ts.userRepo.UpdateBalance(ctx, position.UserID, newBalance, domain.ModePaper); err != nil {
-		return fmt.Errorf("failed to update balance: %w", err)
+		return 0, fmt.Errorf("failed to update balance: %w", err)
+	}
+
+	if ts.statsRecorder != nil {
+		if err := ts.statsRecorder.RecordTrade(ctx, position.UserID, position.Symbol, pnl, newBalance); err != nil {
+			log.Printf("WARNING: Failed to record profit stats: %v", err)
+		}
 	}
 
 	// Send Notification
 	if ts.notificationService != nil {
-		// Construct a manual signal/update for notification
-		// We can reuse SendReview or make a new one. SendReview is good.
-		// We need to fetch the original signal to pass to SendReview?
-		// Or just construct a dummy one with enough info.
-		// Let's try to fetch the signal if possible, otherwise mock it.
+		topic := notification.TopicPositionClosedWin
+		if pnl < 0 {
+			topic = notification.TopicPositionClosedLoss
+		}
+		payload := notification.Payload{Symbol: position.Symbol, Side: position.Side, Price: exitPrice, PnL: &pnl}
 		if position.SignalID != nil {
 			if sig, err := ts.signalRepo.GetByID(ctx, *position.SignalID); err == nil {
-				status := "MANUAL_CLOSE"
-				sig.ReviewResult = &status // Custom status
-				// Pass the PnL we just calculated
-				// But SendReview might calculate its own or use what's passed?
-				// Looking at SendReview code... it checks ReviewResult.
-				// It doesn't seem to take PnL explicitly in the struct for the message?
-				// Wait, SendReview code: "SendSignal(signal domain.Signal)".
-				// It formats message based on fields.
-				// Let's update SendReview in next step to handle this better if needed.
-				// For now, let's just trigger it.
-				if err := ts.notificationService.SendReview(*sig); err != nil {
-					log.Printf("WARNING: Failed to send close notification: %v", err)
-				}
+				manualClose := "MANUAL_CLOSE"
+				sig.ReviewResult = &manualClose
+				payload.Signal = sig
 			}
 		}
+		if err := ts.notificationService.Emit(ctx, topic, payload); err != nil {
+			log.Printf("WARNING: Failed to send close notification: %v", err)
+		}
 	}
 
-	log.Printf("âœ“ Manually Closed position %s %s | PnL: %.2f USDT", position.Symbol, position.Side, pnl)
-	return nil
+	return pnl, nil
+}
+
+// resolveExitPrice picks the exit price ClosePosition should realize PnL
+// against: a fresh mark price first, then the ticker cache if it's not
+// older than staleTickTolerance, and only entryPrice (StatusClosedEmergency,
+// ~0 PnL) if both fail. tickerPriceService may be nil in tests/partial
+// wiring, in which case this falls straight through to the emergency path.
+func (ts *TradingService) resolveExitPrice(ctx context.Context, symbol string, entryPrice float64) (exitPrice float64, status string) {
+	if ts.tickerPriceService != nil {
+		if price, err := ts.tickerPriceService.GetMarkPrice(ctx, symbol); err == nil {
+			return price, domain.StatusClosedManual
+		} else {
+			log.Printf("WARNING: Failed to fetch mark price for %s, falling back to ticker cache: %v", symbol, err)
+		}
+
+		if price, age, ok := ts.tickerPriceService.GetLastPrice(symbol); ok && age <= ts.staleTickTolerance {
+			return price, domain.StatusClosedManual
+		}
+	}
+
+	log.Printf("WARNING: No fresh price available for %s, closing at entry price (emergency)", symbol)
+	return entryPrice, domain.StatusClosedEmergency
 }
 
 // CloseAllPositions closes all open positions for a user (PANIC BUTTON)
@@ -365,3 +625,130 @@ func (ts *TradingService) CloseAllPositions(ctx context.Context, userIDStr strin
 	log.Printf("ðŸš¨ PANIC BUTTON COMPLETE: Closed %d positions", closedCount)
 	return nil
 }
+
+// MonitorOpenPositions checks every open paper position's SL/TP against a
+// fresh mark price and auto-closes any that have been breached, instead of
+// relying on the user to notice and hit manual/panic close themselves.
+func (ts *TradingService) MonitorOpenPositions(ctx context.Context) error {
+	if ts.tickerPriceService == nil {
+		return fmt.Errorf("ticker price service is not configured")
+	}
+
+	positions, err := ts.positionRepo.GetOpenPositions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get open positions: %w", err)
+	}
+
+	closedCount := 0
+	trailingClosedCount := 0
+	for _, position := range positions {
+		price, err := ts.tickerPriceService.GetMarkPrice(ctx, position.Symbol)
+		if err != nil {
+			log.Printf("WARNING: Monitor: failed to fetch price for %s: %v", position.Symbol, err)
+			continue
+		}
+
+		// CheckTrailing first, ratcheting the ladder and persisting it even
+		// when it doesn't close the position, so a restart doesn't lose the
+		// high/low water mark. A hard SL/TP breach still wins below if the
+		// ladder hasn't activated yet.
+		if trailingClosed, err := ts.checkTrailingStop(ctx, position, price); err != nil {
+			log.Printf("ERROR: Monitor: failed to check trailing stop for position %s: %v", position.ID, err)
+		} else if trailingClosed {
+			trailingClosedCount++
+			continue
+		}
+
+		if !breachesSLTP(position, price) {
+			continue
+		}
+
+		if err := ts.ClosePosition(ctx, position.ID, position.UserID, true); err != nil {
+			log.Printf("ERROR: Monitor: failed to auto-close position %s: %v", position.ID, err)
+			continue
+		}
+		closedCount++
+	}
+
+	if closedCount > 0 {
+		log.Printf("[OK] Monitor: auto-closed %d position(s) on SL/TP", closedCount)
+	}
+	if trailingClosedCount > 0 {
+		log.Printf("[OK] Monitor: auto-closed %d position(s) on trailing stop", trailingClosedCount)
+	}
+
+	return nil
+}
+
+// checkTrailingStop runs PaperPosition.CheckTrailing's activation-ratio/
+// callback-rate ladder against price, persisting the ratcheted high/low
+// water price and active tier either way. Returns (true, nil) once the
+// ladder itself closes the position -- as a WIN or LOSS depending on the
+// sign of realized PnL, via finalizeClose -- distinct from a plain SL/TP
+// breach, which ClosePosition always marks StatusClosedManual/
+// StatusClosedEmergency regardless of PnL.
+func (ts *TradingService) checkTrailingStop(ctx context.Context, position *domain.PaperPosition, price float64) (bool, error) {
+	shouldClose, closedBy := position.CheckTrailing(price)
+
+	if err := ts.positionRepo.UpdateTrailingTierState(ctx, position.ID, position.HighWaterPrice, position.LowWaterPrice, position.ActiveTrailingTier); err != nil {
+		log.Printf("WARNING: Monitor: failed to persist trailing tier state for position %s: %v", position.ID, err)
+	}
+
+	if !shouldClose {
+		return false, nil
+	}
+
+	if ts.hedgeOpener != nil {
+		if err := ts.hedgeOpener.CloseHedge(ctx, position.ID); err != nil {
+			return false, fmt.Errorf("failed to close hedge leg: %w", err)
+		}
+	}
+
+	pnl, err := ts.finalizeClose(ctx, position, price, "")
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[OK] %s tier %d hit for position %s %s | PnL: %.2f USDT", closedBy, position.ActiveTrailingTier, position.Symbol, position.Side, pnl)
+	return true, nil
+}
+
+// breachesSLTP reports whether currentPrice has crossed position's SL or TP
+func breachesSLTP(position *domain.PaperPosition, currentPrice float64) bool {
+	if position.Side == domain.SideLong {
+		return currentPrice <= position.SLPrice || currentPrice >= position.TPPrice
+	}
+	return currentPrice >= position.SLPrice || currentPrice <= position.TPPrice
+}
+
+// CloseStalePositions force-closes every open paper position that's been
+// open longer than maxAge, regardless of SL/TP -- a backstop for positions
+// MonitorOpenPositions can't close because price polling fell behind (e.g.
+// a delisted symbol the ticker feed stopped updating). Driven by the
+// CLOSE_STALE_POSITIONS scheduled job rather than the 1-minute monitor tick.
+func (ts *TradingService) CloseStalePositions(ctx context.Context, maxAge time.Duration) error {
+	positions, err := ts.positionRepo.GetOpenPositions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get open positions: %w", err)
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	closedCount := 0
+	for _, position := range positions {
+		if position.CreatedAt.After(cutoff) {
+			continue
+		}
+
+		if err := ts.ClosePosition(ctx, position.ID, position.UserID, true); err != nil {
+			log.Printf("ERROR: CloseStalePositions: failed to close position %s: %v", position.ID, err)
+			continue
+		}
+		closedCount++
+	}
+
+	if closedCount > 0 {
+		log.Printf("[OK] CloseStalePositions: closed %d position(s) older than %s", closedCount, maxAge)
+	}
+
+	return nil
+}